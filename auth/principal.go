@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type Role string
+
+const (
+	RoleAdmin        Role = "admin"
+	RoleProgramStaff Role = "program-staff"
+	RoleAdvisor      Role = "advisor"
+	RoleStudent      Role = "student"
+)
+
+// RoleBinding grants a role within a single program. A ProgramID of 0 means
+// the role applies to every program. Admin bindings are never scoped.
+type RoleBinding struct {
+	Role      Role `json:"role"`
+	ProgramID int  `json:"program_id"`
+}
+
+type Principal struct {
+	Subject   string        `json:"subject"`
+	Email     string        `json:"email"`
+	StudentID string        `json:"student_id"`
+	Roles     []RoleBinding `json:"roles"`
+}
+
+// HasRole reports whether the principal holds one of roles in the given
+// program. A programID that does not identify a program matches no program.
+// Admins always pass.
+func (p *Principal) HasRole(programID int, roles ...Role) bool {
+	if p.IsAdmin() {
+		return true
+	}
+	if p == nil || programID <= 0 {
+		return false
+	}
+
+	for _, binding := range p.Roles {
+		if binding.ProgramID != 0 && binding.ProgramID != programID {
+			continue
+		}
+		if slices.Contains(roles, binding.Role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasRoleInAnyProgram reports whether the principal holds one of roles in at
+// least one program. It does not say which, so access to data of a specific
+// program must still be checked with HasRole. Admins always pass.
+func (p *Principal) HasRoleInAnyProgram(roles ...Role) bool {
+	if p.IsAdmin() {
+		return true
+	}
+	if p == nil {
+		return false
+	}
+
+	for _, binding := range p.Roles {
+		if slices.Contains(roles, binding.Role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Principal) IsAdmin() bool {
+	if p == nil {
+		return false
+	}
+	for _, binding := range p.Roles {
+		if binding.Role == RoleAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRoleBinding parses a role claim of the form "<role>" or
+// "<role>:<program_id>", e.g. "admin" or "program-staff:2".
+func ParseRoleBinding(value string) (RoleBinding, error) {
+	roleName, programPart, scoped := strings.Cut(strings.TrimSpace(value), ":")
+
+	role := Role(roleName)
+	switch role {
+	case RoleAdmin, RoleProgramStaff, RoleAdvisor, RoleStudent:
+	default:
+		return RoleBinding{}, fmt.Errorf("unknown role %q", roleName)
+	}
+
+	binding := RoleBinding{Role: role}
+	if scoped && role == RoleAdmin {
+		return RoleBinding{}, fmt.Errorf("role %q cannot be scoped to a program", roleName)
+	}
+	if scoped {
+		programID, err := strconv.Atoi(programPart)
+		if err != nil || programID <= 0 {
+			return RoleBinding{}, fmt.Errorf("invalid program id in role %q", value)
+		}
+		binding.ProgramID = programID
+	}
+
+	return binding, nil
+}
+
+type principalContextKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
@@ -2,7 +2,10 @@ package configs
 
 import (
 	"log"
+	"log/slog"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -22,3 +25,264 @@ func GetPort() string {
 
 	return port
 }
+
+type AuthConfig struct {
+	JWKSURL    string
+	Secret     string
+	Issuer     string
+	Audience   string
+	RolesClaim string
+}
+
+func GetAuthConfig() *AuthConfig {
+	rolesClaim, ok := os.LookupEnv("JWT_ROLES_CLAIM")
+	if !ok || rolesClaim == "" {
+		rolesClaim = "roles"
+	}
+
+	return &AuthConfig{
+		JWKSURL:    os.Getenv("JWT_JWKS_URL"),
+		Secret:     os.Getenv("JWT_SECRET"),
+		Issuer:     os.Getenv("JWT_ISSUER"),
+		Audience:   os.Getenv("JWT_AUDIENCE"),
+		RolesClaim: rolesClaim,
+	}
+}
+
+type ImportWorkerConfig struct {
+	Workers      int
+	PollInterval time.Duration
+	Lease        time.Duration
+	MaxAttempts  int
+}
+
+func GetImportWorkerConfig() *ImportWorkerConfig {
+	return &ImportWorkerConfig{
+		Workers:      getEnvInt("IMPORT_WORKERS", 2),
+		PollInterval: getEnvDuration("IMPORT_POLL_INTERVAL", 5*time.Second),
+		Lease:        getEnvDuration("IMPORT_JOB_LEASE", 2*time.Minute),
+		MaxAttempts:  getEnvInt("IMPORT_JOB_MAX_ATTEMPTS", 3),
+	}
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// OutboxConfig controls the outbox relay. Lease is how long a claimed batch
+// is reserved for the relay that claimed it; MaxAttempts is how often an
+// event is tried before it is marked dead.
+type OutboxConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxBackoff   time.Duration
+	Lease        time.Duration
+	MaxAttempts  int
+}
+
+func GetOutboxConfig() *OutboxConfig {
+	return &OutboxConfig{
+		PollInterval: getEnvDuration("OUTBOX_POLL_INTERVAL", 2*time.Second),
+		BatchSize:    getEnvInt("OUTBOX_BATCH_SIZE", 100),
+		MaxBackoff:   getEnvDuration("OUTBOX_MAX_BACKOFF", 5*time.Minute),
+		Lease:        getEnvDuration("OUTBOX_LEASE", 2*time.Minute),
+		MaxAttempts:  getEnvInt("OUTBOX_MAX_ATTEMPTS", 20),
+	}
+}
+
+// GetReindexRate returns how many projects per second a reindex publishes
+// when the request does not set its own rate.
+func GetReindexRate() int {
+	return getEnvInt("REINDEX_RATE_PER_SECOND", 20)
+}
+
+const (
+	StorageDriverMinIO = "minio"
+	StorageDriverLocal = "local"
+)
+
+// StorageConfig selects the object storage backend. The local driver keeps
+// objects under LocalDir and serves its own signed URLs below PublicURL.
+// DownloadURLTTL bounds the lifetime of links handed out for project files.
+type StorageConfig struct {
+	Driver         string
+	LocalDir       string
+	PublicURL      string
+	SigningKey     string
+	DownloadURLTTL time.Duration
+}
+
+func GetStorageConfig() *StorageConfig {
+	driver := os.Getenv("STORAGE_DRIVER")
+	if driver == "" {
+		driver = StorageDriverMinIO
+	}
+
+	localDir := os.Getenv("STORAGE_LOCAL_DIR")
+	if localDir == "" {
+		localDir = "./data/storage"
+	}
+
+	publicURL := os.Getenv("STORAGE_PUBLIC_URL")
+	if publicURL == "" {
+		publicURL = "http://localhost:" + GetPort()
+	}
+
+	return &StorageConfig{
+		Driver:         driver,
+		LocalDir:       localDir,
+		PublicURL:      publicURL,
+		SigningKey:     os.Getenv("STORAGE_SIGNING_KEY"),
+		DownloadURLTTL: getEnvDuration("STORAGE_DOWNLOAD_URL_TTL", 5*time.Minute),
+	}
+}
+
+// UploadConfig bounds direct-to-storage uploads. URLTTL is how long a
+// presigned PUT URL stays valid and Expiry how long an upload may wait to be
+// attached to a project. MaxChunkSize caps one request of a resumable upload.
+// PDFs larger than MaxPDFTextSize are stored without extracting their text,
+// since extraction needs the whole file in memory.
+type UploadConfig struct {
+	URLTTL         time.Duration
+	Expiry         time.Duration
+	MaxFileSize    int64
+	MaxChunkSize   int64
+	MaxPDFTextSize int64
+}
+
+// ProjectTrashConfig controls how long deleted projects stay restorable.
+// Once Retention has passed the purge job, which runs every PurgeInterval,
+// removes the project and its files for good. The same job removes uploads
+// that expired without being attached to a project.
+type ProjectTrashConfig struct {
+	Retention     time.Duration
+	PurgeInterval time.Duration
+}
+
+func GetProjectTrashConfig() *ProjectTrashConfig {
+	return &ProjectTrashConfig{
+		Retention:     getEnvDuration("PROJECT_TRASH_RETENTION", 30*24*time.Hour),
+		PurgeInterval: getEnvDuration("PROJECT_PURGE_INTERVAL", time.Hour),
+	}
+}
+
+// ReadinessConfig controls the dependency checks behind /readyz. Each check
+// fails if it takes longer than CheckTimeout; Bucket is the bucket that must
+// exist in object storage.
+type ReadinessConfig struct {
+	CheckTimeout time.Duration
+	Bucket       string
+}
+
+func GetReadinessConfig() *ReadinessConfig {
+	return &ReadinessConfig{
+		CheckTimeout: getEnvDuration("READINESS_CHECK_TIMEOUT", 2*time.Second),
+		Bucket:       os.Getenv("MINIO_PROJECT_BUCKET"),
+	}
+}
+
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+// LogConfig selects the minimum level and the output format of the
+// structured logger.
+type LogConfig struct {
+	Level  slog.Level
+	Format string
+}
+
+func GetLogConfig() *LogConfig {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(os.Getenv("LOG_LEVEL"))); err != nil {
+		level = slog.LevelInfo
+	}
+
+	format := os.Getenv("LOG_FORMAT")
+	if format != LogFormatText {
+		format = LogFormatJSON
+	}
+
+	return &LogConfig{
+		Level:  level,
+		Format: format,
+	}
+}
+
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+)
+
+// TracingConfig selects where spans are exported. The OTLP exporter reads
+// its endpoint and headers from the standard OTEL_EXPORTER_OTLP_* variables.
+// SampleRatio is the share of new traces recorded; requests that arrive with
+// a sampled parent are always recorded.
+type TracingConfig struct {
+	Exporter    string
+	ServiceName string
+	SampleRatio float64
+}
+
+func GetTracingConfig() *TracingConfig {
+	exporter := os.Getenv("TRACING_EXPORTER")
+	if exporter == "" {
+		exporter = TracingExporterNone
+	}
+
+	serviceName := os.Getenv("OTEL_SERVICE_NAME")
+	if serviceName == "" {
+		serviceName = "project-service"
+	}
+
+	sampleRatio, err := strconv.ParseFloat(os.Getenv("TRACING_SAMPLE_RATIO"), 64)
+	if err != nil || sampleRatio < 0 || sampleRatio > 1 {
+		sampleRatio = 1
+	}
+
+	return &TracingConfig{
+		Exporter:    exporter,
+		ServiceName: serviceName,
+		SampleRatio: sampleRatio,
+	}
+}
+
+// ShutdownConfig bounds a graceful shutdown. DrainTimeout is how long
+// in-flight HTTP requests may take to finish once the server stops accepting
+// new ones; WorkerDrainTimeout is how long each background service may take
+// to finish the work it has started before that work is cancelled.
+type ShutdownConfig struct {
+	DrainTimeout       time.Duration
+	WorkerDrainTimeout time.Duration
+}
+
+func GetShutdownConfig() *ShutdownConfig {
+	return &ShutdownConfig{
+		DrainTimeout:       getEnvDuration("SHUTDOWN_DRAIN_TIMEOUT", 20*time.Second),
+		WorkerDrainTimeout: getEnvDuration("SHUTDOWN_WORKER_DRAIN_TIMEOUT", 10*time.Second),
+	}
+}
+
+func GetUploadConfig() *UploadConfig {
+	return &UploadConfig{
+		URLTTL:         getEnvDuration("UPLOAD_URL_TTL", time.Hour),
+		Expiry:         getEnvDuration("UPLOAD_EXPIRY", 24*time.Hour),
+		MaxFileSize:    int64(getEnvInt("UPLOAD_MAX_FILE_SIZE_MB", 5*1024)) << 20,
+		MaxChunkSize:   int64(getEnvInt("UPLOAD_MAX_CHUNK_SIZE_MB", 16)) << 20,
+		MaxPDFTextSize: int64(getEnvInt("UPLOAD_MAX_PDF_TEXT_SIZE_MB", 64)) << 20,
+	}
+}
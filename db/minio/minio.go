@@ -8,19 +8,24 @@ import (
 	"github.com/minio/minio-go/v7/pkg/credentials"
 )
 
-func NewMinIOConnection() (*minio.Client, error) {
+// NewMinIOConnection returns a MinIO client and a function that closes its
+// idle connections once it is no longer used.
+func NewMinIOConnection() (*minio.Client, func(), error) {
 	endpoint := os.Getenv("MINIO_ENDPOINT")
 	accessKeyID := os.Getenv("MINIO_ROOT_USER")
 	secretAccessKey := os.Getenv("MINIO_ROOT_PASSWORD")
 
+	transport, err := minio.DefaultTransport(false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to MinIO: %w", err)
+	}
 	minioClient, err := minio.New(endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(accessKeyID, secretAccessKey, ""),
-		Secure: false,
+		Creds:     credentials.NewStaticV4(accessKeyID, secretAccessKey, ""),
+		Secure:    false,
+		Transport: transport,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to MinIO: %w", err)
+		return nil, nil, fmt.Errorf("failed to connect to MinIO: %w", err)
 	}
-
-	fmt.Println("Successfully connected to MinIO")
-	return minioClient, nil
+	return minioClient, transport.CloseIdleConnections, nil
 }
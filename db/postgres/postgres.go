@@ -2,10 +2,13 @@ package db
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 
+	"github.com/project-box/logging"
+	"github.com/project-box/metrics"
 	"github.com/project-box/models"
+	"github.com/project-box/tracing"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -31,6 +34,12 @@ func GetPostgresConfig() *PostgresConfig {
 }
 
 func migrateModel(db *gorm.DB) error {
+	if err := mergeDuplicateKeywords(db); err != nil {
+		return fmt.Errorf("migration error: %w", err)
+	}
+	if err := backfillProjectStatus(db); err != nil {
+		return fmt.Errorf("migration error: %w", err)
+	}
 	if err := db.AutoMigrate(
 		&models.Config{},
 		&models.ProjectRole{},
@@ -47,32 +56,180 @@ func migrateModel(db *gorm.DB) error {
 		&models.Program{},
 		&models.PDF{},
 		&models.PDFPage{},
+		&models.ImportJob{},
+		&models.OutboxEvent{},
+		&models.ProjectStatusHistory{},
+		&models.Rubric{},
+		&models.RubricCriterion{},
+		&models.RubricRoleWeight{},
+		&models.ProjectEvaluation{},
+		&models.EvaluationScore{},
+		&models.DefenseRoom{},
+		&models.DefenseSlot{},
+		&models.Defense{},
+		&models.PendingUpload{},
+		&models.AuditLog{},
 	); err != nil {
 		return fmt.Errorf("migration error: %w", err)
 	}
+	if err := protectAuditLog(db); err != nil {
+		return fmt.Errorf("migration error: %w", err)
+	}
+	if err := uniqueSharedProjectNumberCounters(db); err != nil {
+		return fmt.Errorf("migration error: %w", err)
+	}
+	return nil
+}
+
+// uniqueSharedProjectNumberCounters keeps one counter per academic year and
+// semester among those shared by all programs. Their program_id is NULL, which
+// the regular unique index never treats as a conflict. Duplicates left by
+// concurrent creates are folded into the oldest row at the highest number.
+func uniqueSharedProjectNumberCounters(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			`UPDATE project_number_counters c SET number = d.number
+			FROM (
+				SELECT MIN(id) AS id, MAX(number) AS number FROM project_number_counters
+				WHERE program_id IS NULL GROUP BY academic_year, semester
+			) d
+			WHERE c.id = d.id`,
+			`DELETE FROM project_number_counters c
+			USING project_number_counters keep
+			WHERE c.program_id IS NULL AND keep.program_id IS NULL
+				AND keep.academic_year = c.academic_year AND keep.semester = c.semester
+				AND keep.id < c.id`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_project_number_counters_shared
+			ON project_number_counters (academic_year, semester) WHERE program_id IS NULL`,
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// backfillProjectStatus adds the status column to a projects table created
+// before projects had a lifecycle and marks those projects completed. Without
+// it the column default would turn every existing project into a proposal.
+func backfillProjectStatus(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.Project{}) || db.Migrator().HasColumn(&models.Project{}, "Status") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("ALTER TABLE projects ADD COLUMN status varchar(20)").Error; err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE projects SET status = ?", models.ProjectStatusCompleted).Error
+	})
+}
+
+// mergeDuplicateKeywords folds keywords that share a name within a program
+// into the oldest of them, so the unique index on (keyword, program_id) can
+// be created. Projects tagged with a duplicate are tagged with the survivor.
+func mergeDuplicateKeywords(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.Keyword{}) || !db.Migrator().HasTable("project_keywords") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			`CREATE TEMPORARY TABLE keyword_merges ON COMMIT DROP AS
+			SELECT id, MIN(id) OVER (PARTITION BY program_id, keyword) AS keep_id FROM keywords`,
+			// A project tagged with several copies keeps only the oldest tag.
+			`DELETE FROM project_keywords pk
+			USING keyword_merges m, project_keywords other, keyword_merges om
+			WHERE m.id = pk.keyword_id
+				AND other.project_id = pk.project_id
+				AND om.id = other.keyword_id
+				AND om.keep_id = m.keep_id
+				AND other.keyword_id < pk.keyword_id`,
+			`UPDATE project_keywords pk SET keyword_id = m.keep_id
+			FROM keyword_merges m
+			WHERE pk.keyword_id = m.id AND m.id <> m.keep_id`,
+			`DELETE FROM keywords k USING keyword_merges m WHERE k.id = m.id AND m.id <> m.keep_id`,
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// protectAuditLog makes audit_logs append-only by rejecting every update and
+// delete at the database, whichever code path attempts them.
+func protectAuditLog(db *gorm.DB) error {
+	statements := []string{
+		`CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_logs is append-only';
+		END;
+		$$ LANGUAGE plpgsql`,
+		`DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs`,
+		`CREATE TRIGGER audit_logs_append_only BEFORE UPDATE OR DELETE ON audit_logs
+		FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only()`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func NewPostgresDatabase() *gorm.DB {
+// NewPostgresDatabase connects to and migrates the database. It returns nil
+// if either fails; the cleanup function closes the connection pool.
+func NewPostgresDatabase(logger *slog.Logger) (*gorm.DB, func()) {
 	configs := GetPostgresConfig()
 	if configs == nil {
-		return nil
+		return nil, func() {}
 	}
 	dsn := fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
 		configs.Host, configs.User, configs.Password, configs.DBName, configs.Port, configs.SSLMode,
 	)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		TranslateError: true,
+		Logger:         logging.NewGormLogger(logger),
+	})
 
 	if err != nil {
-		log.Println(err)
-		return nil
+		logger.Error("Failed to connect to PostgreSQL", "error", err)
+		return nil, func() {}
+	}
+	logger.Info("Successfully connected to PostgreSQL")
+	closeDatabase := func() {
+		sqlDB, err := db.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil {
+			logger.Error("Failed to close PostgreSQL connection", "error", err)
+			return
+		}
+		logger.Info("Closed PostgreSQL connection")
+	}
+
+	if err = db.Use(metrics.GormPlugin{}); err != nil {
+		logger.Error("Failed to register GORM metrics", "error", err)
+		closeDatabase()
+		return nil, func() {}
+	}
+	if err = db.Use(tracing.GormPlugin{}); err != nil {
+		logger.Error("Failed to register GORM tracing", "error", err)
+		closeDatabase()
+		return nil, func() {}
 	}
-	fmt.Println("Successfully connected to PostgreSQL!")
 	if err = migrateModel(db); err != nil {
-		log.Println(err)
-		return nil
+		logger.Error("Failed to migrate PostgreSQL", "error", err)
+		closeDatabase()
+		return nil, func() {}
 	}
 
-	return db
+	return db, closeDatabase
 }
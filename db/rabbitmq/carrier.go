@@ -0,0 +1,24 @@
+package db
+
+import rabbitmq "github.com/rabbitmq/amqp091-go"
+
+// headerCarrier lets OpenTelemetry propagators read and write trace context
+// in the headers of a RabbitMQ message.
+type headerCarrier rabbitmq.Table
+
+func (c headerCarrier) Get(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+func (c headerCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -1,46 +1,262 @@
 package db
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
+	"sync"
+	"time"
 
+	"github.com/project-box/logging"
+	"github.com/project-box/metrics"
+	"github.com/project-box/tracing"
 	rabbitmq "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// DeclareExchange sets up the exchange for publishing messages
-func DeclareExchange(channel *rabbitmq.Channel) *rabbitmq.Channel {
-	err := channel.ExchangeDeclare(
-		"project_service.elastic_search", // exchange name
-		"direct",                         // exchange type (e.g., "direct", "fanout", "topic", "headers")
-		true,                             // durable
-		false,                            // auto delete
-		false,                            // internal
-		false,                            // no wait
-		nil,                              // arguments
+const (
+	// SearchExchange receives the project change events consumed by the
+	// search service.
+	SearchExchange = "project_service.search"
+	// SearchRoutingKey is the routing key of project create, update and
+	// delete events.
+	SearchRoutingKey = "project_service.events.crud"
+
+	defaultSearchQueue = "project_service.search.events"
+
+	reconnectInitialBackoff = time.Second
+	reconnectMaxBackoff     = time.Minute
+)
+
+var ErrPublisherUnavailable = errors.New("rabbitmq publisher is not connected")
+
+// Publisher owns the RabbitMQ connection used to publish events. It
+// reconnects with backoff when the broker goes away, so publishing fails
+// fast with ErrPublisherUnavailable during an outage instead of crashing the
+// service.
+type Publisher interface {
+	// Publish sends msg and waits for the broker to confirm it.
+	Publish(ctx context.Context, exchange, routingKey string, msg rabbitmq.Publishing) error
+	// Health returns nil while the publisher is connected, otherwise the
+	// reason it is not.
+	Health() error
+}
+
+type publisherImpl struct {
+	url         string
+	searchQueue string
+	logger      *slog.Logger
+
+	mu      sync.RWMutex
+	channel *rabbitmq.Channel
+	lastErr error
+
+	// publishMu serialises publishes so confirms are awaited one at a time.
+	publishMu sync.Mutex
+}
+
+// NewRabbitMQPublisher starts connecting to the broker in the background and
+// returns immediately. The returned cleanup function closes the connection.
+func NewRabbitMQPublisher(logger *slog.Logger) (Publisher, func()) {
+	searchQueue := os.Getenv("RABBITMQ_SEARCH_QUEUE")
+	if searchQueue == "" {
+		searchQueue = defaultSearchQueue
+	}
+
+	publisher := &publisherImpl{
+		url:         os.Getenv("RABBITMQ_SERVER_URL"),
+		searchQueue: searchQueue,
+		logger:      logger.With("component", "rabbitmq"),
+		lastErr:     errors.New("rabbitmq connection not established yet"),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		publisher.run(ctx)
+	}()
+
+	return publisher, func() {
+		cancel()
+		<-done
+	}
+}
+
+func (p *publisherImpl) Publish(ctx context.Context, exchange, routingKey string, msg rabbitmq.Publishing) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, exchange+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemRabbitmq,
+			semconv.MessagingOperationTypePublish,
+			semconv.MessagingDestinationName(exchange),
+			semconv.MessagingRabbitmqDestinationRoutingKey(routingKey),
+		),
 	)
+	defer func() {
+		metrics.QueuePublishTotal.WithLabelValues(exchange, metrics.Result(err)).Inc()
+		tracing.End(span, err)
+	}()
+
+	p.mu.RLock()
+	channel := p.channel
+	p.mu.RUnlock()
+	if channel == nil {
+		return ErrPublisherUnavailable
+	}
+
+	// Consumers log the ID of the request that caused the message and
+	// continue its trace from the publish span.
+	headers := rabbitmq.Table{}
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+	if requestId := logging.RequestIDFromContext(ctx); requestId != "" {
+		headers[logging.RequestIDMessageHeader] = requestId
+	}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(headers))
+	msg.Headers = headers
+
+	p.publishMu.Lock()
+	defer p.publishMu.Unlock()
+
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, false, false, msg)
 	if err != nil {
-		panic(err)
+		return err
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return errors.New("message was rejected by rabbitmq")
 	}
+	return nil
+}
+
+func (p *publisherImpl) Health() error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 
-	fmt.Println("Successfully connected to RabbitMQ")
-	return channel
+	if p.channel == nil || p.channel.IsClosed() {
+		return fmt.Errorf("%w: %v", ErrPublisherUnavailable, p.lastErr)
+	}
+	return nil
 }
 
-// NewRabbitMQConnection creates and returns a RabbitMQ connection and channel for publishing
-func NewRabbitMQConnection() *rabbitmq.Channel {
-	rabbitmqServerURL := os.Getenv("RABBITMQ_SERVER_URL")
+// run keeps a connection open until ctx is cancelled.
+func (p *publisherImpl) run(ctx context.Context) {
+	backoff := reconnectInitialBackoff
+	for {
+		connection, channel, err := p.connect()
+		if err != nil {
+			p.setDisconnected(err)
+			p.logger.Warn("Failed to connect to RabbitMQ, retrying", "backoff", backoff, "error", err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, reconnectMaxBackoff)
+			continue
+		}
+
+		backoff = reconnectInitialBackoff
+		p.setConnected(channel)
+		p.logger.Info("Successfully connected to RabbitMQ")
+
+		connectionClosed := connection.NotifyClose(make(chan *rabbitmq.Error, 1))
+		channelClosed := channel.NotifyClose(make(chan *rabbitmq.Error, 1))
+
+		select {
+		case <-ctx.Done():
+			p.setDisconnected(errors.New("rabbitmq publisher stopped"))
+			connection.Close()
+			return
+		case err := <-connectionClosed:
+			p.setDisconnected(closeReason(err))
+		case err := <-channelClosed:
+			p.setDisconnected(closeReason(err))
+			connection.Close()
+		}
+		p.logger.Warn("RabbitMQ connection lost, reconnecting")
+	}
+}
 
-	connectRabbitMQ, err := rabbitmq.Dial(rabbitmqServerURL)
+func (p *publisherImpl) connect() (*rabbitmq.Connection, *rabbitmq.Channel, error) {
+	connection, err := rabbitmq.Dial(p.url)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
-	// Create a channel
-	channelRabbitMQ, err := connectRabbitMQ.Channel()
+	channel, err := connection.Channel()
 	if err != nil {
-		connectRabbitMQ.Close()
-		panic(err)
+		connection.Close()
+		return nil, nil, err
+	}
+
+	if err := p.declareTopology(channel); err != nil {
+		connection.Close()
+		return nil, nil, err
+	}
+
+	if err := channel.Confirm(false); err != nil {
+		connection.Close()
+		return nil, nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
 	}
 
-	return DeclareExchange(channelRabbitMQ)
+	return connection, channel, nil
+}
+
+// declareTopology declares the exchange and queue the service publishes to.
+func (p *publisherImpl) declareTopology(channel *rabbitmq.Channel) error {
+	err := channel.ExchangeDeclare(
+		SearchExchange, // exchange name
+		"direct",       // exchange type
+		true,           // durable
+		false,          // auto delete
+		false,          // internal
+		false,          // no wait
+		nil,            // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange %s: %w", SearchExchange, err)
+	}
+
+	if _, err := channel.QueueDeclare(p.searchQueue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare queue %s: %w", p.searchQueue, err)
+	}
+
+	if err := channel.QueueBind(p.searchQueue, SearchRoutingKey, SearchExchange, false, nil); err != nil {
+		return fmt.Errorf("failed to bind queue %s: %w", p.searchQueue, err)
+	}
+
+	return nil
+}
+
+func (p *publisherImpl) setConnected(channel *rabbitmq.Channel) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.channel = channel
+	p.lastErr = nil
+}
+
+func (p *publisherImpl) setDisconnected(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.channel = nil
+	p.lastErr = err
+}
+
+func closeReason(err *rabbitmq.Error) error {
+	if err == nil {
+		return errors.New("rabbitmq connection closed")
+	}
+	return err
 }
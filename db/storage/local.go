@@ -0,0 +1,397 @@
+package db
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// metadataDir holds one JSON sidecar per object with the attributes the
+// filesystem cannot keep for us. Bucket names may not start with a dot, so it
+// never collides with a bucket.
+const metadataDir = ".metadata"
+
+var errInvalidObjectKey = errors.New("invalid bucket or object key")
+
+type localMetadata struct {
+	ContentType string `json:"content_type"`
+	ETag        string `json:"etag"`
+}
+
+// localStorage keeps objects on disk under root/<bucket>/<key>. Presigned
+// URLs point back at ServeHTTP, which must be mounted at publicURL.
+type localStorage struct {
+	root       string
+	publicURL  *url.URL
+	signingKey []byte
+}
+
+// NewLocalStorage returns a filesystem-backed ObjectStorage. publicURL is the
+// externally reachable base of the /storage route; signingKey authenticates
+// the URLs it hands out and is generated at random when empty.
+func NewLocalStorage(root, publicURL, signingKey string, logger *slog.Logger) (ObjectStorage, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	base, err := url.Parse(strings.TrimRight(publicURL, "/") + "/storage")
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage public URL: %w", err)
+	}
+
+	key := []byte(signingKey)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		logger.Warn("STORAGE_SIGNING_KEY is not set; signed storage URLs will not survive a restart")
+	}
+
+	logger.Info("Using local object storage", "root", root)
+	return &localStorage{root: root, publicURL: base, signingKey: key}, nil
+}
+
+func validObjectPath(bucket, key string) bool {
+	if bucket == "" || strings.HasPrefix(bucket, ".") || strings.ContainsAny(bucket, `/\`) {
+		return false
+	}
+	if key == "" || strings.Contains(key, `\`) {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *localStorage) objectPath(bucket, key string) (string, error) {
+	if !validObjectPath(bucket, key) {
+		return "", errInvalidObjectKey
+	}
+	return filepath.Join(s.root, bucket, filepath.FromSlash(key)), nil
+}
+
+func (s *localStorage) metadataPath(bucket, key string) string {
+	return filepath.Join(s.root, metadataDir, bucket, filepath.FromSlash(key)+".json")
+}
+
+func (s *localStorage) readMetadata(bucket, key string) localMetadata {
+	var meta localMetadata
+	data, err := os.ReadFile(s.metadataPath(bucket, key))
+	if err == nil {
+		_ = json.Unmarshal(data, &meta)
+	}
+	return meta
+}
+
+func (s *localStorage) writeMetadata(bucket, key string, meta localMetadata) error {
+	metaPath := s.metadataPath(bucket, key)
+	if err := os.MkdirAll(filepath.Dir(metaPath), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(metaPath, data, 0o644)
+}
+
+func (s *localStorage) PutObject(ctx context.Context, bucket, key string, reader io.Reader, size int64, opts PutOptions) (_ *ObjectInfo, err error) {
+	ctx, span := startStorageSpan(ctx, "local", "put", bucket, key)
+	defer func() { endStorageSpan(span, err) }()
+
+	objectPath, err := s.objectPath(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(objectPath), 0o755); err != nil {
+		return nil, err
+	}
+
+	// Write next to the destination and rename so readers never see a
+	// partially written object.
+	tmp, err := os.CreateTemp(filepath.Dir(objectPath), ".upload-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := md5.New()
+	written, err := io.Copy(io.MultiWriter(tmp, hash), reader)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 && written != size {
+		return nil, fmt.Errorf("object size mismatch: expected %d bytes, got %d", size, written)
+	}
+
+	meta := localMetadata{ContentType: opts.ContentType, ETag: hex.EncodeToString(hash.Sum(nil))}
+	if err := s.writeMetadata(bucket, key, meta); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), objectPath); err != nil {
+		return nil, err
+	}
+
+	return s.StatObject(ctx, bucket, key)
+}
+
+func (s *localStorage) GetObject(ctx context.Context, bucket, key string) (_ ObjectReader, _ *ObjectInfo, err error) {
+	ctx, span := startStorageSpan(ctx, "local", "get", bucket, key)
+	defer func() { endStorageSpan(span, err) }()
+
+	objectPath, err := s.objectPath(bucket, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := os.Open(objectPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := s.StatObject(ctx, bucket, key)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return file, info, nil
+}
+
+func (s *localStorage) StatObject(ctx context.Context, bucket, key string) (*ObjectInfo, error) {
+	objectPath, err := s.objectPath(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(objectPath)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && stat.IsDir()) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	meta := s.readMetadata(bucket, key)
+	return &ObjectInfo{
+		Key:          key,
+		Size:         stat.Size(),
+		ContentType:  meta.ContentType,
+		ETag:         meta.ETag,
+		LastModified: stat.ModTime(),
+	}, nil
+}
+
+func (s *localStorage) RemoveObject(ctx context.Context, bucket, key string) (err error) {
+	_, span := startStorageSpan(ctx, "local", "delete", bucket, key)
+	defer func() { endStorageSpan(span, err) }()
+
+	objectPath, err := s.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+	// Like S3, removing a missing object is not an error.
+	if err := os.Remove(objectPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Remove(s.metadataPath(bucket, key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *localStorage) ListObjects(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error) {
+	if !validObjectPath(bucket, "x") {
+		return nil, errInvalidObjectKey
+	}
+	bucketDir := filepath.Join(s.root, bucket)
+
+	var objects []ObjectInfo
+	err := filepath.WalkDir(bucketDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(bucketDir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := s.StatObject(ctx, bucket, key)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, *info)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// BucketExists reports whether the storage root is reachable. Bucket
+// directories are created by the first write, so every valid bucket exists
+// as long as the root does.
+func (s *localStorage) BucketExists(ctx context.Context, bucket string) (bool, error) {
+	if !validObjectPath(bucket, "x") {
+		return false, errInvalidObjectKey
+	}
+	stat, err := os.Stat(s.root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return stat.IsDir(), nil
+}
+
+func (s *localStorage) PresignGetObject(ctx context.Context, bucket, key string, ttl time.Duration, reqParams url.Values) (*url.URL, error) {
+	return s.presign(http.MethodGet, bucket, key, ttl, reqParams)
+}
+
+func (s *localStorage) PresignPutObject(ctx context.Context, bucket, key string, ttl time.Duration) (*url.URL, error) {
+	return s.presign(http.MethodPut, bucket, key, ttl, nil)
+}
+
+func (s *localStorage) presign(method, bucket, key string, ttl time.Duration, reqParams url.Values) (*url.URL, error) {
+	if !validObjectPath(bucket, key) {
+		return nil, errInvalidObjectKey
+	}
+
+	query := url.Values{}
+	for name, values := range reqParams {
+		query[name] = append([]string(nil), values...)
+	}
+	query.Set("expires", strconv.FormatInt(time.Now().Add(ttl).Unix(), 10))
+	query.Set("signature", s.sign(method, bucket, key, query))
+
+	signed := *s.publicURL
+	signed.Path = path.Join(s.publicURL.Path, bucket, key)
+	signed.RawQuery = query.Encode()
+	return &signed, nil
+}
+
+// sign authenticates the method, object and every query parameter except the
+// signature itself, so response overrides cannot be altered.
+func (s *localStorage) sign(method, bucket, key string, query url.Values) string {
+	unsigned := url.Values{}
+	for name, values := range query {
+		if name != "signature" {
+			unsigned[name] = values
+		}
+	}
+
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(method + "\n" + bucket + "/" + key + "\n" + unsigned.Encode()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *localStorage) verify(method, bucket, key string, query url.Values) bool {
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return false
+	}
+	expected := s.sign(method, bucket, key, query)
+	return hmac.Equal([]byte(expected), []byte(query.Get("signature")))
+}
+
+// ServeHTTP serves the URLs produced by PresignGetObject and PresignPutObject.
+// It expects the /storage prefix to have been stripped from the request path.
+func (s *localStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if !validObjectPath(bucket, key) {
+		http.NotFound(w, r)
+		return
+	}
+
+	method := r.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	if !s.verify(method, bucket, key, r.URL.Query()) {
+		http.Error(w, "invalid or expired signature", http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		s.serveObject(w, r, bucket, key)
+	case http.MethodPut:
+		info, err := s.PutObject(r.Context(), bucket, key, r.Body, r.ContentLength, PutOptions{ContentType: r.Header.Get("Content-Type")})
+		if err != nil {
+			http.Error(w, "failed to store object", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("ETag", strconv.Quote(info.ETag))
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *localStorage) serveObject(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	object, info, err := s.GetObject(r.Context(), bucket, key)
+	if errors.Is(err, ErrObjectNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to read object", http.StatusInternalServerError)
+		return
+	}
+	defer object.Close()
+
+	query := r.URL.Query()
+	contentType := info.ContentType
+	if override := query.Get("response-content-type"); override != "" {
+		contentType = override
+	}
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	if disposition := query.Get("response-content-disposition"); disposition != "" {
+		w.Header().Set("Content-Disposition", disposition)
+	}
+	if info.ETag != "" {
+		w.Header().Set("ETag", strconv.Quote(info.ETag))
+	}
+
+	http.ServeContent(w, r, path.Base(key), info.LastModified, object)
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	minioconn "github.com/project-box/db/minio"
+	"github.com/project-box/metrics"
+)
+
+type minioStorage struct {
+	client *minio.Client
+}
+
+func NewMinIOStorage(logger *slog.Logger) (ObjectStorage, func(), error) {
+	client, closeConnection, err := minioconn.NewMinIOConnection()
+	if err != nil {
+		return nil, nil, err
+	}
+	logger.Info("Successfully connected to MinIO")
+	return &minioStorage{client: client}, func() {
+		closeConnection()
+		logger.Info("Closed MinIO connections")
+	}, nil
+}
+
+// startMinIOCall starts the span of a MinIO call. The returned function ends
+// it and records the duration and outcome of the call. A missing object is an
+// answer rather than a failure of the store.
+func startMinIOCall(ctx context.Context, operation, bucket, key string) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := startStorageSpan(ctx, "minio", operation, bucket, key)
+
+	return ctx, func(err error) {
+		if errors.Is(err, ErrObjectNotFound) {
+			err = nil
+		}
+		metrics.StorageOperationDuration.WithLabelValues(operation, metrics.Result(err)).Observe(metrics.Since(start))
+		if err != nil {
+			metrics.StorageOperationFailures.WithLabelValues(operation).Inc()
+		}
+		endStorageSpan(span, err)
+	}
+}
+
+func (s *minioStorage) PutObject(ctx context.Context, bucket, key string, reader io.Reader, size int64, opts PutOptions) (*ObjectInfo, error) {
+	ctx, end := startMinIOCall(ctx, "put", bucket, key)
+	info, err := s.client.PutObject(ctx, bucket, key, reader, size, minio.PutObjectOptions{ContentType: opts.ContentType})
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectInfo{
+		Key:          info.Key,
+		Size:         info.Size,
+		ContentType:  opts.ContentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+func (s *minioStorage) GetObject(ctx context.Context, bucket, key string) (_ ObjectReader, _ *ObjectInfo, err error) {
+	ctx, end := startMinIOCall(ctx, "get", bucket, key)
+	defer func() { end(err) }()
+
+	object, err := s.client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, nil, mapMinIOError(err)
+	}
+
+	// GetObject is lazy; Stat is the first call that reaches the server.
+	stat, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, nil, mapMinIOError(err)
+	}
+	return object, toObjectInfo(stat), nil
+}
+
+func (s *minioStorage) StatObject(ctx context.Context, bucket, key string) (*ObjectInfo, error) {
+	ctx, end := startMinIOCall(ctx, "stat", bucket, key)
+	stat, err := s.client.StatObject(ctx, bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		err = mapMinIOError(err)
+	}
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+	return toObjectInfo(stat), nil
+}
+
+func (s *minioStorage) RemoveObject(ctx context.Context, bucket, key string) error {
+	ctx, end := startMinIOCall(ctx, "delete", bucket, key)
+	err := s.client.RemoveObject(ctx, bucket, key, minio.RemoveObjectOptions{})
+	end(err)
+	return err
+}
+
+func (s *minioStorage) ListObjects(ctx context.Context, bucket, prefix string) (_ []ObjectInfo, err error) {
+	ctx, end := startMinIOCall(ctx, "list", bucket, prefix)
+	defer func() { end(err) }()
+
+	var objects []ObjectInfo
+	for object := range s.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		objects = append(objects, *toObjectInfo(object))
+	}
+	return objects, nil
+}
+
+func (s *minioStorage) BucketExists(ctx context.Context, bucket string) (bool, error) {
+	return s.client.BucketExists(ctx, bucket)
+}
+
+func (s *minioStorage) PresignGetObject(ctx context.Context, bucket, key string, ttl time.Duration, reqParams url.Values) (*url.URL, error) {
+	ctx, end := startMinIOCall(ctx, "presign_get", bucket, key)
+	presigned, err := s.client.PresignedGetObject(ctx, bucket, key, ttl, reqParams)
+	end(err)
+	return presigned, err
+}
+
+func (s *minioStorage) PresignPutObject(ctx context.Context, bucket, key string, ttl time.Duration) (*url.URL, error) {
+	ctx, end := startMinIOCall(ctx, "presign_put", bucket, key)
+	presigned, err := s.client.PresignedPutObject(ctx, bucket, key, ttl)
+	end(err)
+	return presigned, err
+}
+
+func toObjectInfo(info minio.ObjectInfo) *ObjectInfo {
+	return &ObjectInfo{
+		Key:          info.Key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+	}
+}
+
+func mapMinIOError(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrObjectNotFound
+	}
+	return err
+}
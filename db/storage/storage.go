@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/project-box/configs"
+	"github.com/project-box/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+type PutOptions struct {
+	ContentType string
+}
+
+// ObjectReader is the body of a stored object. It can be seeked so handlers
+// can answer range requests without buffering the whole object.
+type ObjectReader interface {
+	io.ReadSeekCloser
+}
+
+// ObjectStorage is the blob store behind project files, icons and import
+// sheets. Implementations must return ErrObjectNotFound for missing keys.
+type ObjectStorage interface {
+	PutObject(ctx context.Context, bucket, key string, reader io.Reader, size int64, opts PutOptions) (*ObjectInfo, error)
+	GetObject(ctx context.Context, bucket, key string) (ObjectReader, *ObjectInfo, error)
+	StatObject(ctx context.Context, bucket, key string) (*ObjectInfo, error)
+	RemoveObject(ctx context.Context, bucket, key string) error
+	ListObjects(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error)
+	BucketExists(ctx context.Context, bucket string) (bool, error)
+	// PresignGetObject returns a URL that downloads the object without further
+	// authentication until ttl elapses. reqParams may override response
+	// headers such as response-content-disposition.
+	PresignGetObject(ctx context.Context, bucket, key string, ttl time.Duration, reqParams url.Values) (*url.URL, error)
+	// PresignPutObject returns a URL that accepts a single PUT of the object
+	// body until ttl elapses.
+	PresignPutObject(ctx context.Context, bucket, key string, ttl time.Duration) (*url.URL, error)
+}
+
+// startStorageSpan starts the client span of an object storage call.
+func startStorageSpan(ctx context.Context, driver, operation, bucket, key string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "storage."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("storage.driver", driver),
+			attribute.String("storage.bucket", bucket),
+			attribute.String("storage.key", key),
+		),
+	)
+}
+
+// endStorageSpan ends a span started by startStorageSpan. A missing object is
+// an answer rather than a failure of the store.
+func endStorageSpan(span trace.Span, err error) {
+	if errors.Is(err, ErrObjectNotFound) {
+		err = nil
+	}
+	tracing.End(span, err)
+}
+
+// NewObjectStorage opens the backend selected by STORAGE_DRIVER. The cleanup
+// function releases its connections.
+func NewObjectStorage(logger *slog.Logger) (ObjectStorage, func(), error) {
+	cfg := configs.GetStorageConfig()
+
+	switch cfg.Driver {
+	case configs.StorageDriverMinIO:
+		return NewMinIOStorage(logger)
+	case configs.StorageDriverLocal:
+		objectStorage, err := NewLocalStorage(cfg.LocalDir, cfg.PublicURL, cfg.SigningKey, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		return objectStorage, func() {}, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
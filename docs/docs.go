@@ -24,8 +24,323 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/v1/admin/reindex": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the progress of the latest reindex",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get search reindex progress",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReindexStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "No reindex has been started",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publishes a reindex operation for every project, optionally limited to a program or academic year",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Start a search reindex",
+                "parameters": [
+                    {
+                        "description": "Reindex scope and rate",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReindexRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReindexStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "A reindex is already running",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists recorded creates, updates and deletes, newest first. Each entry's changes map every changed field to its before and after value. Program staff must filter by one of their programs; admins may read the whole log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "List audit log entries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID (required unless admin)",
+                        "name": "program_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "project",
+                            "staff",
+                            "student",
+                            "keyword",
+                            "config",
+                            "project_config",
+                            "project_resource_config",
+                            "import_job",
+                            "project_resource"
+                        ],
+                        "type": "string",
+                        "description": "Entity type",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subject or email of whoever made the change",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete",
+                            "restore",
+                            "purge"
+                        ],
+                        "type": "string",
+                        "description": "Action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest time, inclusive (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest time, exclusive (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuditLogPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not program staff of the program",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/audit/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Downloads every entry matching the filters, oldest first, as a CSV file. The changes column holds the same JSON as the list endpoint.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Export audit log entries as CSV",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID (required unless admin)",
+                        "name": "program_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "project",
+                            "staff",
+                            "student",
+                            "keyword",
+                            "config",
+                            "project_config",
+                            "project_resource_config",
+                            "import_job",
+                            "project_resource"
+                        ],
+                        "type": "string",
+                        "description": "Entity type",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subject or email of whoever made the change",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete",
+                            "restore",
+                            "purge"
+                        ],
+                        "type": "string",
+                        "description": "Action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest time, inclusive (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest time, exclusive (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV file",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not program staff of the program",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
         "/v1/configs": {
             "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "description": "Creates a new config or updates an existing config for the given program",
                 "produces": [
                     "application/json"
@@ -59,6 +374,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "404": {
                         "description": "Program not found",
                         "schema": {
@@ -78,6 +400,11 @@ const docTemplate = `{
         },
         "/v1/configs/academic-years": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "description": "Retrieves all academic years",
                 "produces": [
                     "application/json"
@@ -108,6 +435,11 @@ const docTemplate = `{
         },
         "/v1/configs/program/{program_id}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "description": "Fetches all config for a given program",
                 "produces": [
                     "application/json"
@@ -161,6 +493,11 @@ const docTemplate = `{
         },
         "/v1/configs/{id}": {
             "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "description": "Deletes a configuration by its ID",
                 "produces": [
                     "application/json"
@@ -193,6 +530,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "404": {
                         "description": "Config not found",
                         "schema": {
@@ -210,26 +554,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/keywords": {
+        "/v1/defenses": {
             "get": {
-                "description": "Get all keywords for a specific program",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Lists the defenses booked for a program",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Keywords"
+                    "Defense"
                 ],
-                "summary": "Get keywords by program",
+                "summary": "List defenses",
                 "parameters": [
                     {
-                        "type": "string",
+                        "type": "integer",
                         "description": "Program ID",
                         "name": "program_id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Academic year",
+                        "name": "academic_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester",
+                        "name": "semester",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -238,23 +596,33 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Keyword"
+                                "$ref": "#/definitions/models.Defense"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Update an existing keyword",
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Books a room during a slot for a project's defense, moving an earlier booking of the project. Bookings that double-book a committee member, a student or the room are rejected with the conflicts found.",
                 "consumes": [
                     "application/json"
                 ],
@@ -262,17 +630,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Keywords"
+                    "Defense"
                 ],
-                "summary": "Update a keyword",
+                "summary": "Schedule a defense",
                 "parameters": [
                     {
-                        "description": "Keyword",
-                        "name": "keyword",
+                        "description": "Booking",
+                        "name": "defense",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Keyword"
+                            "$ref": "#/definitions/dtos.DefenseRequest"
                         }
                     }
                 ],
@@ -280,40 +648,55 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Keyword"
+                            "$ref": "#/definitions/models.Defense"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid booking",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Project, slot or room not found",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflicting bookings, listed under conflicts",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/defenses/proposals": {
             "post": {
-                "description": "Create a new keyword",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assigns every unscheduled project of the semester that is in progress or in its defense to the earliest conflict-free slot and room. Projects that fit nowhere are returned with the bookings that block them. Set apply to book the proposals.",
                 "consumes": [
                     "application/json"
                 ],
@@ -321,102 +704,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Keywords"
+                    "Defense"
                 ],
-                "summary": "Create a keyword",
+                "summary": "Propose defense slots",
                 "parameters": [
                     {
-                        "description": "Keyword",
-                        "name": "keyword",
+                        "description": "Proposal request",
+                        "name": "proposal",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Keyword"
+                            "$ref": "#/definitions/dtos.DefenseProposalRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Keyword"
+                            "$ref": "#/definitions/dtos.DefenseProposalResult"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/keywords/all": {
-            "get": {
-                "description": "Get all keywords for a specific program",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Keywords"
-                ],
-                "summary": "Get all keywords",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Keyword"
-                            }
+                            "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/v1/keywords/{id}": {
+        "/v1/defenses/rooms": {
             "get": {
-                "description": "Get a keyword by ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Lists the rooms a program holds project defenses in",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Keywords"
+                    "Defense"
                 ],
-                "summary": "Get a keyword",
+                "summary": "List defense rooms",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Keyword ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "program_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -424,22 +777,35 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Keyword"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DefenseRoom"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Invalid program ID",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Delete a keyword by ID",
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a room that defenses of the program can be booked in",
                 "consumes": [
                     "application/json"
                 ],
@@ -447,52 +813,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Keywords"
+                    "Defense"
                 ],
-                "summary": "Delete a keyword",
+                "summary": "Create a defense room",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Keyword ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Room",
+                        "name": "room",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DefenseRoomRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.DefenseRoom"
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Invalid room",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/v1/programs": {
-            "get": {
-                "description": "Retrieves all programs from the database",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Program"
-                ],
-                "summary": "Get All Programs",
-                "responses": {
-                    "200": {
-                        "description": "Successfully fetched programs",
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Program"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
@@ -503,40 +856,63 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "update program",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/v1/defenses/rooms/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Deletes a room that has no defenses booked in it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Program"
+                    "Defense"
                 ],
-                "summary": "Update Program",
+                "summary": "Delete a defense room",
                 "parameters": [
                     {
-                        "description": "Program details",
-                        "name": "program",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Program"
-                        }
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated program",
+                        "description": "Room deleted successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or parameters",
+                        "description": "Invalid room ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Defenses are booked in the room",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -550,39 +926,48 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Creates a new program in the database",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/v1/defenses/rooms/{id}/calendar.ics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns every defense booked in the room as an iCalendar file",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "Program"
+                    "Defense"
                 ],
-                "summary": "Create a New Program",
+                "summary": "Export a room's defense calendar",
                 "parameters": [
                     {
-                        "description": "Program creation details",
-                        "name": "program",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.CreateProgramRequest"
-                        }
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully created program",
+                    "200": {
+                        "description": "iCalendar file",
                         "schema": {
-                            "$ref": "#/definitions/models.Program"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid room ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -598,50 +983,112 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/projectConfigs": {
-            "put": {
-                "description": "Update all project config if ID is provided, otherwise insert new project config",
-                "consumes": [
-                    "application/json"
+        "/v1/defenses/slots": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Lists the time slots defenses of a program can be booked in",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ProjectConfig"
+                    "Defense"
                 ],
-                "summary": "Upsert project config",
+                "summary": "List defense slots",
                 "parameters": [
                     {
-                        "description": "Configurations",
-                        "name": "configs",
-                        "in": "body",
-                        "required": true,
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "program_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Academic year",
+                        "name": "academic_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester",
+                        "name": "semester",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dtos.ProjectConfigUpsertRequest"
+                                "$ref": "#/definitions/models.DefenseSlot"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a time slot in which any of the program's rooms can be booked for a defense",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Defense"
+                ],
+                "summary": "Create a defense slot",
+                "parameters": [
+                    {
+                        "description": "Slot",
+                        "name": "slot",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DefenseSlotRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully updated config",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.DefenseSlot"
                         }
                     },
                     "400": {
-                        "description": "Invalid program ID",
+                        "description": "Invalid slot",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Program not found",
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -657,44 +1104,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/projectConfigs/program/{program_id}": {
-            "get": {
-                "description": "Fetches all config for a given program",
+        "/v1/defenses/slots/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a slot that has no defenses booked in it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ProjectConfig"
+                    "Defense"
                 ],
-                "summary": "Get config by program ID",
+                "summary": "Delete a defense slot",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Program ID",
-                        "name": "program_id",
+                        "description": "Slot ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully fetched config",
+                        "description": "Slot deleted successfully",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.ProjectConfigResponse"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid program ID",
+                        "description": "Invalid slot ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Program not found",
+                        "description": "Slot not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Defenses are booked in the slot",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -710,40 +1174,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/projectResourceConfigs": {
-            "put": {
-                "description": "Insert or update project resource configurations. If an ID is provided, it updates the configuration; otherwise, it inserts a new configuration.",
-                "consumes": [
-                    "application/json"
+        "/v1/defenses/staffs/{staff_id}/calendar.ics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns every defense of the projects a staff member is on the committee of as an iCalendar file",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "ProjectResourceConfig"
+                    "Defense"
                 ],
-                "summary": "Upsert Project Resource Configurations",
+                "summary": "Export a staff member's defense calendar",
                 "parameters": [
                     {
-                        "description": "configuration to upsert",
-                        "name": "configs",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ProjectResourceConfig"
-                        }
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "staff_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully upsert configurations",
+                        "description": "iCalendar file",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid staff ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -759,47 +1222,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/projectResourceConfigs/program/{program_id}": {
+        "/v1/defenses/{id}": {
             "get": {
-                "description": "Fetch all project resource configurations for a given program ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches a defense booking with its project and room",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ProjectResourceConfig"
+                    "Defense"
                 ],
-                "summary": "Get Project Resource Config by Program ID",
+                "summary": "Get a defense",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Program ID",
-                        "name": "program_id",
+                        "description": "Defense ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully fetched configurations",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/models.ProjectResourceConfig"
-                                }
-                            }
+                            "$ref": "#/definitions/models.Defense"
                         }
                     },
                     "400": {
-                        "description": "Invalid program ID",
+                        "description": "Invalid defense ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "No configurations found for the given program ID",
+                        "description": "Defense not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -813,22 +1275,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/projectResources/{id}": {
+            },
             "delete": {
-                "description": "Deletes a project resource by its ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a defense booking",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Resource"
+                    "Defense"
                 ],
-                "summary": "Delete a project resource",
+                "summary": "Cancel a defense",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Resource ID",
+                        "type": "integer",
+                        "description": "Defense ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -836,21 +1301,35 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Project Resource deleted successfully",
+                        "description": "Defense cancelled successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid defense ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Resource not found",
+                        "description": "Defense not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Failed to delete resource record",
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -859,225 +1338,143 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/projectRoles/program/{program_id}": {
+        "/v1/keywords": {
             "get": {
-                "description": "Retrieves all project roles for a given program ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all keywords for a specific program",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ProjectRole"
+                    "Keywords"
                 ],
-                "summary": "Get all project roles by program ID",
+                "summary": "Get keywords by program",
                 "parameters": [
                     {
-                        "type": "integer",
+                        "type": "string",
                         "description": "Program ID",
                         "name": "program_id",
-                        "in": "path",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved project roles",
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.ProjectRole"
+                                "$ref": "#/definitions/models.Keyword"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid program ID",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/v1/projects": {
-            "post": {
-                "description": "Creates a new project with the provided data",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing keyword",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Project"
+                    "Keywords"
                 ],
-                "summary": "Create a new project",
+                "summary": "Update a keyword",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "List of URLs",
-                        "name": "urls",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "List of Titles",
-                        "name": "titles",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Upload Files",
-                        "name": "files",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Project Data",
-                        "name": "project",
-                        "in": "formData",
-                        "required": true
+                        "description": "Keyword",
+                        "name": "keyword",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Keyword"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully created project",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Project"
+                            "$ref": "#/definitions/models.Keyword"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
-                    }
-                }
-            }
-        },
-        "/v1/projects/{id}": {
-            "put": {
-                "description": "Updates a project by its ID with the provided data",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Project"
-                ],
-                "summary": "Update an existing project",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "List of URLs",
-                        "name": "urls",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "List of Titles",
-                        "name": "titles",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Upload Files",
-                        "name": "files",
-                        "in": "formData"
                     },
-                    {
-                        "type": "string",
-                        "description": "Project Data",
-                        "name": "project",
-                        "in": "formData",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Successfully updated project",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.Project"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Invalid project ID or request",
+                    "409": {
+                        "description": "The program already has this keyword",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Deletes the specified project using its ID",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Project"
-                ],
-                "summary": "Delete a project by ID",
-                "parameters": [
+            "post": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "BearerAuth": []
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "Project deleted successfully",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid project ID",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/staffs": {
-            "put": {
-                "description": "Updates an staff by their ID with the provided data",
+                "description": "Create a new keyword",
                 "consumes": [
                     "application/json"
                 ],
@@ -1085,297 +1482,283 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Staff"
+                    "Keywords"
                 ],
-                "summary": "Update an existing staff",
+                "summary": "Create a keyword",
                 "parameters": [
                     {
-                        "description": "Updated Staff Data",
-                        "name": "staff",
+                        "description": "Keyword",
+                        "name": "keyword",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.UpdateStaffRequest"
+                            "$ref": "#/definitions/models.Keyword"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully updated staff",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dtos.StaffResponse"
+                            "$ref": "#/definitions/models.Keyword"
                         }
                     },
                     "400": {
-                        "description": "Invalid staff ID or request",
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Creates a new staff",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Staff"
-                ],
-                "summary": "Create a new staff",
-                "parameters": [
-                    {
-                        "description": "Staff Data",
-                        "name": "staff",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.CreateStaffRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Successfully created staff",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.StaffResponse"
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Invalid request",
+                    "409": {
+                        "description": "The program already has this keyword",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/staffs/GetAllStaffs": {
+        "/v1/keywords/all": {
             "get": {
-                "description": "Fetches all staffs",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all keywords for a specific program",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Staff"
+                    "Keywords"
                 ],
-                "summary": "Get all staffs",
+                "summary": "Get all keywords",
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved staffs",
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dtos.StaffResponse"
+                                "$ref": "#/definitions/models.Keyword"
                             }
                         }
                     },
-                    "404": {
-                        "description": "Staffs not found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/staffs/email/{email}": {
+        "/v1/keywords/{id}": {
             "get": {
-                "description": "Retrieves a staff member by their email",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a keyword by ID",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Staff"
+                    "Keywords"
                 ],
-                "summary": "Get staff by email",
+                "summary": "Get a keyword",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Staff Email",
-                        "name": "email",
+                        "description": "Keyword ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved staff",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.StaffResponse"
+                            "$ref": "#/definitions/models.Keyword"
                         }
                     },
-                    "400": {
-                        "description": "Invalid email",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/v1/staffs/program/{program_id}": {
-            "get": {
-                "description": "Fetches all staffs for a given program",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a keyword by ID",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Staff"
+                    "Keywords"
                 ],
-                "summary": "Get staffs by program ID",
+                "summary": "Delete a keyword",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Program ID",
-                        "name": "program_id",
+                        "type": "string",
+                        "description": "Keyword ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully retrieved staffs",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.StaffResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid program ID",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Staffs not found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/staffs/{id}": {
+        "/v1/programs": {
             "get": {
-                "description": "Fetches an staff by their ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves all programs from the database",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Staff"
-                ],
-                "summary": "Get staff by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Staff ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Program"
                 ],
+                "summary": "Get All Programs",
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved staff",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.StaffResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid staff ID",
+                        "description": "Successfully fetched programs",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Program"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Staff not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/v1/students/program/{program_id}": {
-            "get": {
-                "description": "Retrieves a list of students for a given program ID, academic year, and semester",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "update program",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Student"
+                    "Program"
                 ],
-                "summary": "Get students by program ID, academic year, and semester",
+                "summary": "Update Program",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Program ID",
-                        "name": "program_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Academic Year",
-                        "name": "academic_year",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Semester",
-                        "name": "semester",
-                        "in": "query",
-                        "required": true
+                        "description": "Program details",
+                        "name": "program",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Program"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved students",
+                        "description": "Successfully updated program",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Student"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid parameters",
+                        "description": "Invalid request body or parameters",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1389,39 +1772,44 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/students/program/{program_id}/current_year": {
-            "get": {
-                "description": "Retrieves a list of students for a given program ID and current year",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new program in the database",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Student"
+                    "Program"
                 ],
-                "summary": "Get students by program ID and current year",
+                "summary": "Create a New Program",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Program ID",
-                        "name": "program_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Program creation details",
+                        "name": "program",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateProgramRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully retrieved students",
+                    "201": {
+                        "description": "Successfully created program",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Student"
-                            }
+                            "$ref": "#/definitions/models.Program"
                         }
                     },
                     "400": {
-                        "description": "Invalid parameters",
+                        "description": "Invalid request body",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1437,32 +1825,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/students/{student_id}": {
+        "/v1/programs/{id}/trash": {
             "get": {
-                "description": "Retrieves a list of students for a given student ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the projects of the program that are in the trash, most recently deleted first, with the time each one will be purged for good",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Student"
+                    "Project"
                 ],
-                "summary": "Get students by student ID",
+                "summary": "List a program's deleted projects",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Student ID",
-                        "name": "student_id",
+                        "description": "Program ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved students",
+                        "description": "Deleted projects",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Student"
+                                "$ref": "#/definitions/dtos.TrashedProject"
                             }
                         }
                     },
@@ -1473,6 +1866,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "403": {
+                        "description": "Not program staff of the program",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1483,35 +1883,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/students/{student_id}/check": {
-            "get": {
-                "description": "Checks if a student has permission to create a project based on their student ID",
+        "/v1/projectConfigs": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update all project config if ID is provided, otherwise insert new project config",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Student"
+                    "ProjectConfig"
                 ],
-                "summary": "Check student permission for creating a project",
+                "summary": "Upsert project config",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Student ID",
-                        "name": "student_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Configurations",
+                        "name": "configs",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ProjectConfigUpsertRequest"
+                            }
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully checked permission",
+                        "description": "Successfully updated config",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid student ID",
+                        "description": "Invalid program ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Program not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1527,19 +1954,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/uploads/program/{program_id}/create-project": {
-            "post": {
-                "description": "Uploads and processes a create project file for a given program ID",
-                "consumes": [
-                    "multipart/form-data"
+        "/v1/projectConfigs/program/{program_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Fetches all config for a given program",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Upload"
+                    "ProjectConfig"
                 ],
-                "summary": "Upload create project file",
+                "summary": "Get config by program ID",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1547,25 +1976,88 @@ const docTemplate = `{
                         "name": "program_id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetched config",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ProjectConfigResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid program ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Program not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projectResourceConfigs": {
+            "put": {
+                "security": [
                     {
-                        "type": "file",
-                        "description": "Create Project File",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Insert or update project resource configurations. If an ID is provided, it updates the configuration; otherwise, it inserts a new configuration.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProjectResourceConfig"
+                ],
+                "summary": "Upsert Project Resource Configurations",
+                "parameters": [
+                    {
+                        "description": "configuration to upsert",
+                        "name": "configs",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProjectResourceConfig"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "file processed successfully",
+                        "description": "Successfully upsert configurations",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid program ID or failed to retrieve the file",
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1581,19 +2073,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/uploads/program/{program_id}/create-staff": {
-            "post": {
-                "description": "Uploads and processes a create staff file for a given program ID",
-                "consumes": [
-                    "multipart/form-data"
+        "/v1/projectResourceConfigs/program/{program_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Fetch all project resource configurations for a given program ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Upload"
+                    "ProjectResourceConfig"
                 ],
-                "summary": "Upload create staff file",
+                "summary": "Get Project Resource Config by Program ID",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1601,25 +2095,30 @@ const docTemplate = `{
                         "name": "program_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Create Staff File",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "file processed successfully",
+                        "description": "Successfully fetched configurations",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/models.ProjectResourceConfig"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid program ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Invalid program ID or failed to retrieve the file",
+                    "404": {
+                        "description": "No configurations found for the given program ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1635,52 +2134,82 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/uploads/program/{program_id}/student-enrollment": {
-            "post": {
-                "description": "Uploads and processes a student enrollment file for a given program ID",
-                "consumes": [
-                    "multipart/form-data"
+        "/v1/projectResources/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Deletes one version of a project resource and its file. Deleting the current version makes the newest remaining version current.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Upload"
+                    "Resource"
                 ],
-                "summary": "Upload student enrollment file",
+                "summary": "Delete a project resource",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Program ID",
-                        "name": "program_id",
+                        "description": "Resource ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Student Enrollment File",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "ETag of the resource's project as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "file processed successfully",
+                        "description": "Project Resource deleted successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid program ID or failed to retrieve the file",
+                        "description": "Invalid resource ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "You do not have access to this resource",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since it was read; the body holds the current project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to delete resource record",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1689,51 +2218,75 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/projectResourceConfigs": {
-            "put": {
-                "description": "Insert or update project resource configurations. If an ID is provided, it updates the configuration; otherwise, it inserts a new configuration.",
-                "consumes": [
-                    "multipart/form-data"
+        "/v1/projectResources/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Redirects to a short-lived signed URL for the resource file, or streams it when mode=stream. Files of private projects are only available to program staff and project members.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "ProjectResourceConfig"
+                    "Resource"
                 ],
-                "summary": "Upsert Project Resource Configurations",
+                "summary": "Download a project resource",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "configuration to upsert",
-                        "name": "configs",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Icon file",
-                        "name": "icon",
-                        "in": "formData"
+                        "type": "string",
+                        "description": "redirect (default) or stream",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ask the browser to display the file instead of saving it",
+                        "name": "inline",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully upsert configurations",
+                        "description": "Resource file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "302": {
+                        "description": "Redirect to a signed download URL"
+                    },
+                    "400": {
+                        "description": "Invalid resource ID or mode",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "403": {
+                        "description": "You do not have access to this resource",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to download resource",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1742,77 +2295,5025 @@ const docTemplate = `{
                 }
             }
         },
-        "/v2/staffs/program/{program_id}": {
-            "get": {
-                "description": "Fetches all staffs by program id",
+        "/v1/projectResources/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Makes the given version the current version of its title.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Staff"
+                    "Resource"
                 ],
-                "summary": "Get all staffs by program id",
+                "summary": "Restore a version of a project resource",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Program ID",
-                        "name": "program_id",
+                        "description": "Resource version ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the resource's project as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved staffs",
+                        "description": "Restored version",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Staff"
-                            }
+                            "$ref": "#/definitions/dtos.ProjectResource"
                         }
                     },
                     "400": {
-                        "description": "Invalid program ID",
+                        "description": "Invalid resource ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "You do not have access to this resource",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Staffs not found",
+                        "description": "Resource not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "dtos.AcademicYearResponse": {
-            "type": "object",
-            "properties": {
-                "year_ad": {
-                    "type": "integer"
-                },
-                "year_be": {
-                    "type": "integer"
-                }
+                    },
+                    "412": {
+                        "description": "Project changed since it was read; the body holds the current project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projectResources/{id}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns every version of the resource's title within its project, newest first. Any version ID may be used.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Resource"
+                ],
+                "summary": "List the versions of a project resource",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Versions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ProjectResource"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid resource ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "You do not have access to this resource",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projectRoles/program/{program_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves all project roles for a given program ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProjectRole"
+                ],
+                "summary": "Get all project roles by program ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "program_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved project roles",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProjectRole"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid program ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists projects matching the given filters. Pages are selected either by offset or by the next_cursor returned from a previous page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "List projects",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "program_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Academic year",
+                        "name": "academic_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester",
+                        "name": "semester",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Section ID",
+                        "name": "section_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "staff_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "student_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Keyword ID",
+                        "name": "keyword_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyword text (case-insensitive, partial match)",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Public projects only",
+                        "name": "is_public",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lifecycle status (proposal, rejected, in_progress, defense, completed, archived)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "project_no",
+                            "title_th",
+                            "title_en",
+                            "academic_year",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved projects",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new project with the provided data",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Create a new project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List of URLs",
+                        "name": "urls",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "List of Titles",
+                        "name": "titles",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Upload Files",
+                        "name": "files",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project Data",
+                        "name": "project",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created project",
+                        "schema": {
+                            "$ref": "#/definitions/models.Project"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "No role in the project's program",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/finalize": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a project whose file resources were uploaded with presigned URLs. Each upload is checked against its declared size, checksum and content type before it is attached.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Create a project from uploaded files",
+                "parameters": [
+                    {
+                        "description": "Project and resources",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.FinalizeProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created project",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectData"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not signed in, or no role in the project's program",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Upload already attached to a project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "410": {
+                        "description": "Upload expired",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Uploaded file does not match its declaration",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/uploads": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Declares files with their size, content type and SHA-256 checksum and returns one presigned PUT URL per file. Upload each file directly to its URL, then create the project with POST /v1/projects/finalize.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Request presigned upload URLs for project files",
+                "parameters": [
+                    {
+                        "description": "Files to upload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Upload URLs",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or unsupported file",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not signed in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/uploads/resumable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Declares a file that will be sent in chunks with PATCH /v1/projects/uploads/{id}. An interrupted upload is resumed by asking for its status and sending the next chunk from the returned offset. A complete upload is attached with POST /v1/projects/finalize.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Start a resumable upload",
+                "parameters": [
+                    {
+                        "description": "File to upload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectUploadFile"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Upload status",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ResumableUploadStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or unsupported file",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not signed in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/uploads/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns how many bytes have been received, i.e. the offset the next chunk must start at.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Get the status of a resumable upload",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Upload status",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ResumableUploadStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid upload ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "410": {
+                        "description": "Upload expired",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Appends the raw request body at the offset given in the Upload-Offset header, which must equal the number of bytes received so far. The request needs a Content-Length. The final chunk assembles the file.",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Upload a chunk of a resumable upload",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of the first byte of this chunk",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Chunk bytes",
+                        "name": "chunk",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Upload status",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ResumableUploadStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid upload ID, offset or chunk size",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Offset does not match, or another chunk is being written",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "410": {
+                        "description": "Upload expired",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches a project with its program, staffs, members, keywords and resources",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Get a project by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved project",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectData"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces a project with the provided data; members, staff and keywords are set to exactly those in the request. Use PATCH /v1/projects/{id} and the sub-resource endpoints to change only part of a project. A resource entry with the id of a current resource keeps it; an uploaded file whose title matches a current resource becomes its new version. Current resources left out of the request are no longer current but keep their version history. Changing the staff, academic year or semester is reserved for program staff and the project's advisors; a project moved to another program, academic year or semester gets a new project number.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Update an existing project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List of URLs",
+                        "name": "urls",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "List of Titles",
+                        "name": "titles",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Upload Files",
+                        "name": "files",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project Data",
+                        "name": "project",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated project",
+                        "schema": {
+                            "$ref": "#/definitions/models.Project"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID or request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not program staff or a member of the project, or not allowed to change its staff or term",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "is_public set before the project is completed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since it was read; the body holds the current project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves the project to the trash. It is hidden from every listing and from search, and can be restored by program staff until the trash retention period has passed; after that it is purged together with its files.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Delete a project by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Project deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not program staff of the project's program",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since it was read; the body holds the current project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes only the fields present in the body. Members, staff, keywords and resources are left untouched; use their sub-resource endpoints to change them. Changing academic_year or semester is reserved for program staff and the project's advisors and gives the project a new project number.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Partially update a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PatchProjectRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated project",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectData"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID or request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not program staff or a member of the project, or not allowed to move it to another term",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "is_public set before the project is completed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since it was read; the body holds the current project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approves a proposal and moves the project to in_progress. Only an advisor of the project may approve it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Approve a project proposal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review comment",
+                        "name": "review",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status change",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProjectStatusHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID or request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not an advisor of the project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Project is not a proposal",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/evaluations": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records the caller's scores and comments for every criterion of a rubric. The caller must be on the project's committee and the project must be in its defense. Submitting again replaces the previous evaluation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Submit an evaluation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Evaluation",
+                        "name": "evaluation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.EvaluationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProjectEvaluation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid evaluation",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not on the project's committee",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project or rubric not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Project is not in its defense",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/keywords": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tags the project with a keyword of its program without changing the other keywords",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Add a keyword to a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Keyword to add",
+                        "name": "keyword",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectKeywordRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated project",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectData"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or keyword of another program",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not program staff or a member of the project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project or keyword not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Project already has the keyword",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since it was read; the body holds the current project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/keywords/{keyword_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a single keyword from the project",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Remove a keyword from a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Keyword ID",
+                        "name": "keyword_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated project",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectData"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not program staff or a member of the project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found or keyword is not on the project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since it was read; the body holds the current project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/members": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a student of the project's program without changing the other members",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Add a member to a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Student to add",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectMemberRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated project",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectData"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or student of another program",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not program staff or a member of the project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project or student not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Student is already a member",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since it was read; the body holds the current project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/members/{student_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a single student from the project",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Remove a member from a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Student ID (students.id)",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated project",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectData"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not program staff or a member of the project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found or student is not a member",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since it was read; the body holds the current project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rejects a proposal with a comment. Only an advisor of the project may reject it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Reject a project proposal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review comment",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status change",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProjectStatusHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID or missing comment",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not an advisor of the project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Project is not a proposal",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/resources": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Uploads a single file or adds a single link. When the title matches a current resource the new one becomes its next version; other resources are left untouched.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Add a resource to a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource title",
+                        "name": "title",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link, when not uploading a file",
+                        "name": "url",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "File, when not adding a link",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated project",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectData"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not program staff or a member of the project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since it was read; the body holds the current project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/resources/{resource_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Takes a current resource off the project. Its files and version history are kept and it can be brought back with the restore endpoint.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Remove a resource from a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Project resource ID",
+                        "name": "resource_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated project",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectData"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not program staff or a member of the project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found or resource is not a current resource of it",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since it was read; the body holds the current project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Brings a project back from the trash with its members, staff, keywords and resources, and returns it to search results",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Restore a deleted project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored project",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectData"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not program staff of the project's program",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Project is not in the trash",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/scores": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the per-criterion, per-evaluator and per-role scores of a project and its final grade under a rubric",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Get a project's score breakdown",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rubric ID",
+                        "name": "rubric_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectScore"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project or rubric ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project or rubric not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/staffs": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assigns a staff member of the project's program in the given project role. A staff member may hold several roles on one project. Only program staff and the project's advisors may change its staff.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Assign staff to a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Staff assignment",
+                        "name": "staff",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectStaffRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated project",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectData"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or staff/role of another program",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not program staff or an advisor of the project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project, staff or project role not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Staff already holds this role",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since it was read; the body holds the current project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/staffs/{staff_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes every assignment of the staff member to the project, or only the one in project_role_id when it is given. Only program staff and the project's advisors may change its staff.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Remove staff from a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "staff_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only remove this role",
+                        "name": "project_role_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project as last read, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated project",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectData"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Project version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not program staff or an advisor of the project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found or staff is not assigned",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Project changed since it was read; the body holds the current project",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "Missing If-Match header",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/status": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a project along its lifecycle (proposal, rejected, in_progress, defense, completed, archived). Decisions on a proposal are reserved for the project's advisors, and rejecting a proposal requires a comment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Change project status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProjectStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status change",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProjectStatusHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID or status, or a rejection without a comment",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Transition not allowed from the current status",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/status-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the status changes of a project, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Project"
+                ],
+                "summary": "Get project status history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status history",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProjectStatusHistory"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rubrics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the grading rubrics of a program with their criteria and role weights",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rubric"
+                ],
+                "summary": "List rubrics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "program_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Rubric"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid program ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a grading rubric with weighted criteria and per-role weights",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rubric"
+                ],
+                "summary": "Create a rubric",
+                "parameters": [
+                    {
+                        "description": "Rubric",
+                        "name": "rubric",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RubricRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Rubric"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rubric",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rubrics/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches a rubric with its criteria and role weights",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rubric"
+                ],
+                "summary": "Get a rubric",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rubric ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Rubric"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rubric ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Rubric not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces a rubric's criteria and role weights. Once evaluations exist, criteria can only be renamed or reweighted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rubric"
+                ],
+                "summary": "Update a rubric",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rubric ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rubric",
+                        "name": "rubric",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RubricRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Rubric"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rubric",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Rubric not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Rubric already has evaluations",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a rubric that has no evaluations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rubric"
+                ],
+                "summary": "Delete a rubric",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rubric ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rubric deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rubric ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Rubric not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Rubric already has evaluations",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rubrics/{id}/scores": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the score breakdown of every project in the rubric's program that has reached its defense",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Get score breakdowns for a rubric",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rubric ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Academic year",
+                        "name": "academic_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester",
+                        "name": "semester",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ProjectScore"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rubric ID or query",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Rubric not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staffs": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates an staff by their ID with the provided data",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Update an existing staff",
+                "parameters": [
+                    {
+                        "description": "Updated Staff Data",
+                        "name": "staff",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateStaffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated staff",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.StaffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid staff ID or request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Staff not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new staff",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Create a new staff",
+                "parameters": [
+                    {
+                        "description": "Staff Data",
+                        "name": "staff",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateStaffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created staff",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.StaffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staffs/GetAllStaffs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches all staffs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Get all staffs",
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved staffs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.StaffResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Staffs not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staffs/email/{email}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a staff member by their email",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Get staff by email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staff Email",
+                        "name": "email",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved staff",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.StaffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid email",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staffs/program/{program_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches all staffs for a given program",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Get staffs by program ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "program_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved staffs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.StaffResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid program ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Staffs not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staffs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches an staff by their ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Get staff by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved staff",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.StaffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid staff ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Staff not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/students/program/{program_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of students for a given program ID, academic year, and semester",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student"
+                ],
+                "summary": "Get students by program ID, academic year, and semester",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "program_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Academic Year",
+                        "name": "academic_year",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester",
+                        "name": "semester",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved students",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Student"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/students/program/{program_id}/current_year": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of students for a given program ID and current year",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student"
+                ],
+                "summary": "Get students by program ID and current year",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "program_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved students",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Student"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/students/{student_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of students for a given student ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student"
+                ],
+                "summary": "Get students by student ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved students",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Student"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid program ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/students/{student_id}/check": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Checks if a student has permission to create a project based on their student ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student"
+                ],
+                "summary": "Check student permission for creating a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully checked permission",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid student ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/uploads/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the status, progress, row errors and created entity IDs of an import job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Get import job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Import job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid import job ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Import job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/uploads/jobs/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Puts a failed import job back in the queue",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Retry import job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Import job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid import job ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Import job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Import job is not failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/uploads/program/{program_id}/create-project": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues a create project file of a given program ID for background processing",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Upload create project file",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "program_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Create Project File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the file and return the report without saving",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid program ID or failed to retrieve the file",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/uploads/program/{program_id}/create-staff": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues a create staff file of a given program ID for background processing",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Upload create staff file",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "program_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Create Staff File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the file and return the report without saving",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid program ID or failed to retrieve the file",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/uploads/program/{program_id}/student-enrollment": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues a student enrollment file of a given program ID for background processing",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Upload student enrollment file",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "program_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Student Enrollment File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the file and return the report without saving",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid program ID or failed to retrieve the file",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/projectResourceConfigs": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Insert or update project resource configurations. If an ID is provided, it updates the configuration; otherwise, it inserts a new configuration.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProjectResourceConfig"
+                ],
+                "summary": "Upsert Project Resource Configurations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "configuration to upsert",
+                        "name": "configs",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Icon file",
+                        "name": "icon",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully upsert configurations",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/staffs/program/{program_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches all staffs by program id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Get all staffs by program id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "program_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved staffs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Staff"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid program ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Staffs not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dtos.AcademicYearResponse": {
+            "type": "object",
+            "properties": {
+                "year_ad": {
+                    "type": "integer"
+                },
+                "year_be": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.AuditLogPage": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AuditLog"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CreateProgramRequest": {
+            "type": "object",
+            "properties": {
+                "program_name_en": {
+                    "type": "string"
+                },
+                "program_name_th": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreateStaffRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name_en": {
+                    "type": "string"
+                },
+                "first_name_th": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "last_name_en": {
+                    "type": "string"
+                },
+                "last_name_th": {
+                    "type": "string"
+                },
+                "prefix_en": {
+                    "type": "string"
+                },
+                "prefix_th": {
+                    "type": "string"
+                },
+                "program_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CriterionScore": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "criterion_id": {
+                    "type": "integer"
+                },
+                "max_score": {
+                    "type": "number"
+                },
+                "min_score": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.CriterionScoreRequest": {
+            "type": "object",
+            "required": [
+                "criterion_id"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "criterion_id": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.DefenseConflict": {
+            "type": "object",
+            "properties": {
+                "defense_id": {
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "project_no": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.DefenseProposal": {
+            "type": "object",
+            "properties": {
+                "defense_id": {
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "project_no": {
+                    "type": "string"
+                },
+                "room_id": {
+                    "type": "integer"
+                },
+                "room_name": {
+                    "type": "string"
+                },
+                "slot_id": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.DefenseProposalRequest": {
+            "type": "object",
+            "required": [
+                "academic_year",
+                "program_id",
+                "semester"
+            ],
+            "properties": {
+                "academic_year": {
+                    "type": "integer"
+                },
+                "apply": {
+                    "type": "boolean"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "project_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "semester": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.DefenseProposalResult": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean"
+                },
+                "proposals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.DefenseProposal"
+                    }
+                },
+                "unscheduled": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.UnscheduledProject"
+                    }
+                }
+            }
+        },
+        "dtos.DefenseRequest": {
+            "type": "object",
+            "required": [
+                "project_id",
+                "room_id",
+                "slot_id"
+            ],
+            "properties": {
+                "project_id": {
+                    "type": "integer"
+                },
+                "room_id": {
+                    "type": "integer"
+                },
+                "slot_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.DefenseRoomRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "program_id"
+            ],
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "program_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.DefenseSlotRequest": {
+            "type": "object",
+            "required": [
+                "academic_year",
+                "ends_at",
+                "program_id",
+                "semester",
+                "starts_at"
+            ],
+            "properties": {
+                "academic_year": {
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "semester": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.EvaluationRequest": {
+            "type": "object",
+            "required": [
+                "rubric_id",
+                "scores"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "rubric_id": {
+                    "type": "integer"
+                },
+                "scores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CriterionScoreRequest"
+                    }
+                }
+            }
+        },
+        "dtos.EvaluatorScore": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "criteria": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CriterionScore"
+                    }
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name_en": {
+                    "type": "string"
+                },
+                "last_name_en": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "submitted_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.FileExtension": {
+            "type": "object",
+            "properties": {
+                "extension_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mime_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.FinalizeProjectRequest": {
+            "type": "object",
+            "required": [
+                "project"
+            ],
+            "properties": {
+                "project": {
+                    "$ref": "#/definitions/models.ProjectRequest"
+                },
+                "project_resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.FinalizeProjectResource"
+                    }
+                }
+            }
+        },
+        "dtos.FinalizeProjectResource": {
+            "type": "object",
+            "properties": {
+                "title": {
+                    "type": "string"
+                },
+                "upload_id": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ImportJob": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "percent_complete": {
+                    "type": "integer"
+                },
+                "processed_rows": {
+                    "type": "integer"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "report": {
+                    "$ref": "#/definitions/dtos.ImportReport"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ImportReport": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "entity_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ImportRowError"
+                    }
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "would_create": {
+                    "type": "integer"
+                },
+                "would_update": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.Keyword": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "keyword": {
+                    "type": "string"
+                },
+                "program": {
+                    "$ref": "#/definitions/dtos.Program"
+                },
+                "program_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.PDF": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "pages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.PDFPage"
+                    }
+                },
+                "project_resource_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.PDFPage": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "page_number": {
+                    "type": "integer"
+                },
+                "pdf_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.PatchProjectRequest": {
+            "type": "object",
+            "properties": {
+                "abstract_text": {
+                    "type": "string"
+                },
+                "academic_year": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "is_public": {
+                    "type": "boolean"
+                },
+                "section_id": {
+                    "type": "string"
+                },
+                "semester": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "title_en": {
+                    "type": "string"
+                },
+                "title_th": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.Program": {
+            "type": "object",
+            "properties": {
+                "abbreviation": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "program_name_en": {
+                    "type": "string"
+                },
+                "program_name_th": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ProjectConfigResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ProjectConfigUpsertRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ProjectData": {
+            "type": "object",
+            "properties": {
+                "abstract_text": {
+                    "type": "string"
+                },
+                "academic_year": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_public": {
+                    "type": "boolean"
+                },
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.Keyword"
+                    }
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.Student"
+                    }
+                },
+                "program": {
+                    "$ref": "#/definitions/dtos.Program"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "project_no": {
+                    "type": "string"
+                },
+                "project_resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProjectResource"
+                    }
+                },
+                "section_id": {
+                    "type": "string"
+                },
+                "semester": {
+                    "type": "integer"
+                },
+                "staffs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProjectStaffMessage"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title_en": {
+                    "type": "string"
+                },
+                "title_th": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ProjectKeywordRequest": {
+            "type": "object",
+            "required": [
+                "keyword_id"
+            ],
+            "properties": {
+                "keyword_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ProjectMemberRequest": {
+            "type": "object",
+            "required": [
+                "student_id"
+            ],
+            "properties": {
+                "student_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ProjectPage": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProjectData"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ProjectResource": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "file_extension": {
+                    "$ref": "#/definitions/dtos.FileExtension"
+                },
+                "file_extension_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_current": {
+                    "type": "boolean"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "pdf": {
+                    "$ref": "#/definitions/dtos.PDF"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "resource_name": {
+                    "type": "string"
+                },
+                "resource_type": {
+                    "$ref": "#/definitions/dtos.ResourceType"
+                },
+                "resource_type_id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ProjectReviewRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ProjectRole": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "program": {
+                    "$ref": "#/definitions/dtos.Program"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "role_name_en": {
+                    "type": "string"
+                },
+                "role_name_th": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ProjectScore": {
+            "type": "object",
+            "properties": {
+                "complete": {
+                    "type": "boolean"
+                },
+                "final_score": {
+                    "type": "number"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "project_no": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.RoleScore"
+                    }
+                },
+                "rubric_id": {
+                    "type": "integer"
+                },
+                "title_en": {
+                    "type": "string"
+                },
+                "title_th": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ProjectStaffMessage": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name_en": {
+                    "type": "string"
+                },
+                "first_name_th": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "last_name_en": {
+                    "type": "string"
+                },
+                "last_name_th": {
+                    "type": "string"
+                },
+                "prefix_en": {
+                    "type": "string"
+                },
+                "prefix_th": {
+                    "type": "string"
+                },
+                "program": {
+                    "$ref": "#/definitions/dtos.Program"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "project_role": {
+                    "$ref": "#/definitions/dtos.ProjectRole"
+                }
+            }
+        },
+        "dtos.ProjectStaffRequest": {
+            "type": "object",
+            "required": [
+                "project_role_id",
+                "staff_id"
+            ],
+            "properties": {
+                "project_role_id": {
+                    "type": "integer"
+                },
+                "staff_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ProjectStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ProjectUploadFile": {
+            "type": "object",
+            "required": [
+                "checksum_sha256",
+                "content_type",
+                "file_name",
+                "size"
+            ],
+            "properties": {
+                "checksum_sha256": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ProjectUploadRequest": {
+            "type": "object",
+            "required": [
+                "files"
+            ],
+            "properties": {
+                "files": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProjectUploadFile"
+                    }
+                }
+            }
+        },
+        "dtos.ProjectUploadResponse": {
+            "type": "object",
+            "properties": {
+                "uploads": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProjectUploadTarget"
+                    }
+                }
+            }
+        },
+        "dtos.ProjectUploadTarget": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "method": {
+                    "type": "string"
+                },
+                "upload_id": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ReindexRequest": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "integer"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "rate_per_second": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ReindexStatus": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "failed_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "published": {
+                    "type": "integer"
+                },
+                "request": {
+                    "$ref": "#/definitions/dtos.ReindexRequest"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
             }
         },
-        "dtos.CreateProgramRequest": {
+        "dtos.ResourceType": {
             "type": "object",
             "properties": {
-                "program_name_en": {
+                "id": {
+                    "type": "integer"
+                },
+                "type_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ResumableUploadStatus": {
+            "type": "object",
+            "properties": {
+                "complete": {
+                    "type": "boolean"
+                },
+                "expires_at": {
                     "type": "string"
                 },
-                "program_name_th": {
+                "file_name": {
                     "type": "string"
+                },
+                "max_chunk_size": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "upload_id": {
+                    "type": "integer"
                 }
             }
         },
-        "dtos.CreateStaffRequest": {
+        "dtos.RoleScore": {
+            "type": "object",
+            "properties": {
+                "evaluations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.EvaluatorScore"
+                    }
+                },
+                "pending_staff_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "project_role_id": {
+                    "type": "integer"
+                },
+                "role_name_en": {
+                    "type": "string"
+                },
+                "role_name_th": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.RubricCriterionRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_score": {
+                    "type": "number"
+                },
+                "min_score": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.RubricRequest": {
+            "type": "object",
+            "required": [
+                "criteria",
+                "name",
+                "program_id"
+            ],
+            "properties": {
+                "criteria": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.RubricCriterionRequest"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "role_weights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.RubricRoleWeightRequest"
+                    }
+                }
+            }
+        },
+        "dtos.RubricRoleWeightRequest": {
+            "type": "object",
+            "required": [
+                "project_role_id"
+            ],
+            "properties": {
+                "project_role_id": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.StaffResponse": {
             "type": "object",
             "properties": {
                 "email": {
@@ -1824,6 +7325,9 @@ const docTemplate = `{
                 "first_name_th": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "is_active": {
                     "type": "boolean"
                 },
@@ -1839,135 +7343,303 @@ const docTemplate = `{
                 "prefix_th": {
                     "type": "string"
                 },
+                "program": {
+                    "$ref": "#/definitions/models.Program"
+                },
                 "program_id": {
                     "type": "integer"
                 }
             }
         },
-        "dtos.ProjectConfigResponse": {
+        "dtos.Student": {
             "type": "object",
             "properties": {
+                "academic_year": {
+                    "description": "Academic year",
+                    "type": "integer"
+                },
+                "email": {
+                    "description": "Unique email address",
+                    "type": "string"
+                },
+                "first_name": {
+                    "description": "First name of the student",
+                    "type": "string"
+                },
                 "id": {
+                    "description": "Use ` + "`" + `ID` + "`" + ` as the primary key",
                     "type": "integer"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "last_name": {
+                    "description": "Last name of the student",
+                    "type": "string"
+                },
+                "program": {
+                    "$ref": "#/definitions/dtos.Program"
                 },
                 "program_id": {
+                    "description": "Program ID",
                     "type": "integer"
                 },
-                "title": {
+                "sec_lab": {
+                    "type": "string"
+                },
+                "semester": {
+                    "description": "Semester",
+                    "type": "integer"
+                },
+                "student_id": {
+                    "description": "Student ID",
                     "type": "string"
                 }
             }
         },
-        "dtos.ProjectConfigUpsertRequest": {
+        "dtos.TrashedProject": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "integer"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_no": {
+                    "type": "string"
+                },
+                "purge_at": {
+                    "type": "string"
+                },
+                "semester": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title_en": {
+                    "type": "string"
+                },
+                "title_th": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.UnscheduledProject": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.DefenseConflict"
+                    }
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "project_no": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.UpdateStaffRequest": {
             "type": "object",
             "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name_en": {
+                    "type": "string"
+                },
+                "first_name_th": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "is_active": {
                     "type": "boolean"
                 },
+                "last_name_en": {
+                    "type": "string"
+                },
+                "last_name_th": {
+                    "type": "string"
+                },
+                "prefix_en": {
+                    "type": "string"
+                },
+                "prefix_th": {
+                    "type": "string"
+                },
+                "program_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "actor_email": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "program_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Config": {
+            "type": "object",
+            "properties": {
+                "config_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "program": {
+                    "$ref": "#/definitions/models.Program"
+                },
                 "program_id": {
                     "type": "integer"
                 },
-                "title": {
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Defense": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project": {
+                    "$ref": "#/definitions/models.Project"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "room": {
+                    "$ref": "#/definitions/models.DefenseRoom"
+                },
+                "room_id": {
+                    "type": "integer"
+                },
+                "slot_id": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "dtos.StaffResponse": {
+        "models.DefenseRoom": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "first_name_en": {
-                    "type": "string"
+                "capacity": {
+                    "type": "integer"
                 },
-                "first_name_th": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "last_name_en": {
-                    "type": "string"
-                },
-                "last_name_th": {
-                    "type": "string"
-                },
-                "prefix_en": {
+                "location": {
                     "type": "string"
                 },
-                "prefix_th": {
+                "name": {
                     "type": "string"
                 },
-                "program": {
-                    "$ref": "#/definitions/models.Program"
-                },
                 "program_id": {
                     "type": "integer"
                 }
             }
         },
-        "dtos.UpdateStaffRequest": {
+        "models.DefenseSlot": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "academic_year": {
+                    "type": "integer"
                 },
-                "first_name_en": {
+                "created_at": {
                     "type": "string"
                 },
-                "first_name_th": {
+                "ends_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "last_name_en": {
-                    "type": "string"
-                },
-                "last_name_th": {
-                    "type": "string"
+                "program_id": {
+                    "type": "integer"
                 },
-                "prefix_en": {
-                    "type": "string"
+                "semester": {
+                    "type": "integer"
                 },
-                "prefix_th": {
+                "starts_at": {
                     "type": "string"
-                },
-                "program_id": {
-                    "type": "integer"
                 }
             }
         },
-        "models.Config": {
+        "models.EvaluationScore": {
             "type": "object",
             "properties": {
-                "config_name": {
+                "comment": {
                     "type": "string"
                 },
-                "id": {
+                "criterion_id": {
                     "type": "integer"
                 },
-                "program": {
-                    "$ref": "#/definitions/models.Program"
+                "evaluation_id": {
+                    "type": "integer"
                 },
-                "program_id": {
+                "id": {
                     "type": "integer"
                 },
-                "value": {
-                    "type": "string"
+                "score": {
+                    "type": "number"
                 }
             }
         },
@@ -2031,6 +7703,12 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -2076,6 +7754,9 @@ const docTemplate = `{
                         "$ref": "#/definitions/models.Staff"
                     }
                 },
+                "status": {
+                    "$ref": "#/definitions/models.ProjectStatus"
+                },
                 "title_en": {
                     "type": "string"
                 },
@@ -2084,6 +7765,103 @@ const docTemplate = `{
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ProjectEvaluation": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "project_role_id": {
+                    "type": "integer"
+                },
+                "rubric_id": {
+                    "type": "integer"
+                },
+                "scores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EvaluationScore"
+                    }
+                },
+                "staff_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProjectRequest": {
+            "type": "object",
+            "properties": {
+                "abstract_text": {
+                    "type": "string"
+                },
+                "academic_year": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_public": {
+                    "type": "boolean"
+                },
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Keyword"
+                    }
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Student"
+                    }
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "project_no": {
+                    "type": "string"
+                },
+                "project_resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProjectResource"
+                    }
+                },
+                "section_id": {
+                    "type": "string"
+                },
+                "semester": {
+                    "type": "integer"
+                },
+                "staffs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProjectStaff"
+                    }
+                },
+                "title_en": {
+                    "type": "string"
+                },
+                "title_th": {
+                    "type": "string"
                 }
             }
         },
@@ -2102,6 +7880,9 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "is_current": {
+                    "type": "boolean"
+                },
                 "path": {
                     "type": "string"
                 },
@@ -2122,6 +7903,9 @@ const docTemplate = `{
                 },
                 "url": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
@@ -2174,6 +7958,77 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ProjectStaff": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "project": {
+                    "$ref": "#/definitions/models.Project"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "project_role": {
+                    "$ref": "#/definitions/models.ProjectRole"
+                },
+                "project_role_id": {
+                    "type": "integer"
+                },
+                "staff": {
+                    "$ref": "#/definitions/models.Staff"
+                },
+                "staff_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ProjectStatus": {
+            "type": "string",
+            "enum": [
+                "proposal",
+                "rejected",
+                "in_progress",
+                "defense",
+                "completed",
+                "archived"
+            ],
+            "x-enum-varnames": [
+                "ProjectStatusProposal",
+                "ProjectStatusRejected",
+                "ProjectStatusInProgress",
+                "ProjectStatusDefense",
+                "ProjectStatusCompleted",
+                "ProjectStatusArchived"
+            ]
+        },
+        "models.ProjectStatusHistory": {
+            "type": "object",
+            "properties": {
+                "changed_by": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "$ref": "#/definitions/models.ProjectStatus"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "to_status": {
+                    "$ref": "#/definitions/models.ProjectStatus"
+                }
+            }
+        },
         "models.ResourceType": {
             "type": "object",
             "properties": {
@@ -2185,6 +8040,93 @@ const docTemplate = `{
                 }
             }
         },
+        "models.Rubric": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "criteria": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RubricCriterion"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "program": {
+                    "$ref": "#/definitions/models.Program"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "role_weights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RubricRoleWeight"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RubricCriterion": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_score": {
+                    "type": "number"
+                },
+                "min_score": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rubric_id": {
+                    "type": "integer"
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.RubricRoleWeight": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "project_role": {
+                    "$ref": "#/definitions/models.ProjectRole"
+                },
+                "project_role_id": {
+                    "type": "integer"
+                },
+                "rubric_id": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
         "models.Staff": {
             "type": "object",
             "properties": {
@@ -0,0 +1,29 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/project-box/models"
+)
+
+// AuditLogFilter narrows the audit log. From and To are RFC 3339 timestamps;
+// From is inclusive and To exclusive. Actor matches the subject or the email
+// of whoever made the change.
+type AuditLogFilter struct {
+	ProgramID  *int       `form:"program_id"`
+	EntityType *string    `form:"entity_type"`
+	EntityID   *string    `form:"entity_id"`
+	Actor      *string    `form:"actor"`
+	Action     *string    `form:"action"`
+	From       *time.Time `form:"from"`
+	To         *time.Time `form:"to"`
+	Limit      int        `form:"limit"`
+	Offset     int        `form:"offset"`
+}
+
+type AuditLogPage struct {
+	Data   []models.AuditLog `json:"data"`
+	Total  int64             `json:"total"`
+	Limit  int               `json:"limit"`
+	Offset int               `json:"offset"`
+}
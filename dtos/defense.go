@@ -0,0 +1,81 @@
+package dtos
+
+import "time"
+
+type DefenseRoomRequest struct {
+	Name      string  `json:"name" binding:"required"`
+	Location  *string `json:"location"`
+	Capacity  *int    `json:"capacity"`
+	ProgramID int     `json:"program_id" binding:"required"`
+}
+
+type DefenseSlotRequest struct {
+	ProgramID    int       `json:"program_id" binding:"required"`
+	AcademicYear int       `json:"academic_year" binding:"required"`
+	Semester     int       `json:"semester" binding:"required"`
+	StartsAt     time.Time `json:"starts_at" binding:"required"`
+	EndsAt       time.Time `json:"ends_at" binding:"required"`
+}
+
+type DefenseFilter struct {
+	ProgramID    int  `form:"program_id" binding:"required"`
+	AcademicYear *int `form:"academic_year"`
+	Semester     *int `form:"semester"`
+}
+
+type DefenseRequest struct {
+	ProjectID int `json:"project_id" binding:"required"`
+	SlotID    int `json:"slot_id" binding:"required"`
+	RoomID    int `json:"room_id" binding:"required"`
+}
+
+// DefenseProposalRequest asks for defense slots for the program's projects
+// that are in progress or in their defense and not scheduled yet. With Apply
+// set the proposals are booked right away.
+type DefenseProposalRequest struct {
+	ProgramID    int   `json:"program_id" binding:"required"`
+	AcademicYear int   `json:"academic_year" binding:"required"`
+	Semester     int   `json:"semester" binding:"required"`
+	ProjectIDs   []int `json:"project_ids"`
+	Apply        bool  `json:"apply"`
+}
+
+// DefenseConflict describes an existing defense that double-books a staff
+// member, a student or a room. ID and Label identify the double-booked staff
+// member (staff ID and email), student (student record ID and student code)
+// or room (room ID and name). DefenseID is 0 when the conflict is with
+// another proposal of the same proposal run.
+type DefenseConflict struct {
+	Type      string    `json:"type"`
+	ID        int       `json:"id"`
+	Label     string    `json:"label"`
+	DefenseID int       `json:"defense_id"`
+	ProjectID int       `json:"project_id"`
+	ProjectNo string    `json:"project_no"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+}
+
+type DefenseProposal struct {
+	ProjectID int       `json:"project_id"`
+	ProjectNo string    `json:"project_no"`
+	SlotID    int       `json:"slot_id"`
+	RoomID    int       `json:"room_id"`
+	RoomName  string    `json:"room_name"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	DefenseID *int      `json:"defense_id"`
+}
+
+type UnscheduledProject struct {
+	ProjectID int               `json:"project_id"`
+	ProjectNo string            `json:"project_no"`
+	Reason    string            `json:"reason"`
+	Conflicts []DefenseConflict `json:"conflicts"`
+}
+
+type DefenseProposalResult struct {
+	Applied     bool                 `json:"applied"`
+	Proposals   []DefenseProposal    `json:"proposals"`
+	Unscheduled []UnscheduledProject `json:"unscheduled"`
+}
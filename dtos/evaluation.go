@@ -0,0 +1,70 @@
+package dtos
+
+import "time"
+
+type CriterionScoreRequest struct {
+	CriterionID int     `json:"criterion_id" binding:"required"`
+	Score       float64 `json:"score"`
+	Comment     *string `json:"comment"`
+}
+
+type EvaluationRequest struct {
+	RubricID int                     `json:"rubric_id" binding:"required"`
+	Comment  *string                 `json:"comment"`
+	Scores   []CriterionScoreRequest `json:"scores" binding:"required"`
+}
+
+type CriterionScore struct {
+	CriterionID int     `json:"criterion_id"`
+	Name        string  `json:"name"`
+	Weight      float64 `json:"weight"`
+	MinScore    float64 `json:"min_score"`
+	MaxScore    float64 `json:"max_score"`
+	Score       float64 `json:"score"`
+	Comment     *string `json:"comment"`
+}
+
+// EvaluatorScore is one committee member's evaluation. Score is the weighted
+// criteria score scaled to 0-100.
+type EvaluatorScore struct {
+	StaffID     int              `json:"staff_id"`
+	Email       string           `json:"email"`
+	FirstNameEN string           `json:"first_name_en"`
+	LastNameEN  string           `json:"last_name_en"`
+	Score       float64          `json:"score"`
+	Comment     *string          `json:"comment"`
+	Criteria    []CriterionScore `json:"criteria"`
+	SubmittedAt time.Time        `json:"submitted_at"`
+}
+
+// RoleScore averages the evaluations of every committee member holding a
+// project role. Score is nil until at least one of them has submitted.
+type RoleScore struct {
+	ProjectRoleID int              `json:"project_role_id"`
+	RoleNameTH    string           `json:"role_name_th"`
+	RoleNameEN    string           `json:"role_name_en"`
+	Weight        float64          `json:"weight"`
+	Score         *float64         `json:"score"`
+	Evaluations   []EvaluatorScore `json:"evaluations"`
+	PendingStaff  []int            `json:"pending_staff_ids"`
+}
+
+// ProjectScore is the score breakdown of a project against a rubric.
+// FinalScore is the role-weighted average of the role scores on a 0-100
+// scale; Complete reports whether every weighted committee member has
+// submitted.
+type ProjectScore struct {
+	ProjectID  int         `json:"project_id"`
+	ProjectNo  string      `json:"project_no"`
+	TitleTH    *string     `json:"title_th"`
+	TitleEN    *string     `json:"title_en"`
+	RubricID   int         `json:"rubric_id"`
+	FinalScore *float64    `json:"final_score"`
+	Complete   bool        `json:"complete"`
+	Roles      []RoleScore `json:"roles"`
+}
+
+type ProjectScoreFilter struct {
+	AcademicYear *int `form:"academic_year"`
+	Semester     *int `form:"semester"`
+}
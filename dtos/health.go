@@ -0,0 +1,19 @@
+package dtos
+
+const (
+	HealthStatusOK   = "ok"
+	HealthStatusFail = "fail"
+)
+
+// HealthCheck is the result of checking one dependency.
+type HealthCheck struct {
+	Status    string  `json:"status" example:"ok"`
+	LatencyMS float64 `json:"latency_ms" example:"1.25"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// ReadinessReport is ok only when every check is.
+type ReadinessReport struct {
+	Status string                 `json:"status" example:"ok"`
+	Checks map[string]HealthCheck `json:"checks"`
+}
@@ -0,0 +1,22 @@
+package dtos
+
+import "time"
+
+type ImportJob struct {
+	ID              int           `json:"id"`
+	Type            string        `json:"type"`
+	Status          string        `json:"status"`
+	ProgramID       int           `json:"program_id"`
+	DryRun          bool          `json:"dry_run"`
+	FileName        string        `json:"file_name"`
+	TotalRows       int           `json:"total_rows"`
+	ProcessedRows   int           `json:"processed_rows"`
+	PercentComplete int           `json:"percent_complete"`
+	Attempts        int           `json:"attempts"`
+	Error           *string       `json:"error"`
+	Report          *ImportReport `json:"report"`
+	CreatedBy       string        `json:"created_by"`
+	CreatedAt       time.Time     `json:"created_at"`
+	StartedAt       *time.Time    `json:"started_at"`
+	FinishedAt      *time.Time    `json:"finished_at"`
+}
@@ -0,0 +1,28 @@
+package dtos
+
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column"`
+	Message string `json:"message"`
+}
+
+// ImportReport describes the outcome of parsing an uploaded Excel sheet.
+// Row numbers are the 1-based row numbers shown in Excel and EntityIDs lists
+// the records created or updated once the import is applied.
+type ImportReport struct {
+	DryRun      bool             `json:"dry_run"`
+	Applied     bool             `json:"applied"`
+	TotalRows   int              `json:"total_rows"`
+	WouldCreate int              `json:"would_create"`
+	WouldUpdate int              `json:"would_update"`
+	Errors      []ImportRowError `json:"errors"`
+	EntityIDs   []int            `json:"entity_ids"`
+}
+
+func (r *ImportReport) AddError(row int, column, message string) {
+	r.Errors = append(r.Errors, ImportRowError{Row: row, Column: column, Message: message})
+}
+
+func (r *ImportReport) HasErrors() bool {
+	return len(r.Errors) > 0
+}
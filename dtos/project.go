@@ -2,6 +2,7 @@ package dtos
 
 import (
 	"mime/multipart"
+	"time"
 
 	"github.com/project-box/models"
 )
@@ -33,7 +34,92 @@ type ProjectData struct {
 	Members          []Student             `json:"members"`
 	Keywords         []Keyword             `json:"keywords"`
 	ProjectResources []ProjectResource     `json:"project_resources"`
+	Status           string                `json:"status"`
 	IsPublic         bool                  `json:"is_public"`
+	Version          int                   `json:"version"`
 	CreatedAt        string                `json:"created_at"`
 	UpdatedAt        string                `json:"updated_at"`
 }
+
+type ProjectFilter struct {
+	ProgramID    *int    `form:"program_id"`
+	AcademicYear *int    `form:"academic_year"`
+	Semester     *int    `form:"semester"`
+	SectionID    *string `form:"section_id"`
+	StaffID      *int    `form:"staff_id"`
+	StudentID    *string `form:"student_id"`
+	KeywordID    *int    `form:"keyword_id"`
+	Keyword      *string `form:"keyword"`
+	IsPublic     *bool   `form:"is_public"`
+	Status       *string `form:"status"`
+	Cursor       string  `form:"cursor"`
+	Limit        int     `form:"limit"`
+	Offset       int     `form:"offset"`
+	SortBy       string  `form:"sort_by"`
+	Order        string  `form:"order"`
+}
+
+type ProjectPage struct {
+	Data       []ProjectData `json:"data"`
+	Total      int64         `json:"total"`
+	Limit      int           `json:"limit"`
+	Offset     int           `json:"offset"`
+	NextCursor *string       `json:"next_cursor"`
+}
+
+type ProjectReviewRequest struct {
+	Comment *string `json:"comment"`
+}
+
+type ProjectStatusRequest struct {
+	Status  string  `json:"status" binding:"required"`
+	Comment *string `json:"comment"`
+}
+
+// PatchProjectRequest changes only the fields that are present. Members,
+// staff, keywords and resources are managed through their own endpoints.
+type PatchProjectRequest struct {
+	TitleTH      *string `json:"title_th"`
+	TitleEN      *string `json:"title_en"`
+	AbstractText *string `json:"abstract_text"`
+	AcademicYear *int    `json:"academic_year" binding:"omitempty,min=1"`
+	Semester     *int    `json:"semester" binding:"omitempty,min=1"`
+	SectionID    *string `json:"section_id"`
+	IsPublic     *bool   `json:"is_public"`
+}
+
+type ProjectMemberRequest struct {
+	StudentID int `json:"student_id" binding:"required"`
+}
+
+type ProjectStaffRequest struct {
+	StaffID       int `json:"staff_id" binding:"required"`
+	ProjectRoleID int `json:"project_role_id" binding:"required"`
+}
+
+type ProjectKeywordRequest struct {
+	KeywordID int `json:"keyword_id" binding:"required"`
+}
+
+// AddProjectResourceRequest adds a single file or link. A title that matches a
+// current resource stores the upload as its new version.
+type AddProjectResourceRequest struct {
+	Title string                `form:"title" binding:"required"`
+	URL   *string               `form:"url"`
+	File  *multipart.FileHeader `form:"file"`
+}
+
+// TrashedProject is a deleted project waiting in the trash. PurgeAt is when
+// the purge job removes it and its files for good.
+type TrashedProject struct {
+	ID           int       `json:"id"`
+	ProjectNo    string    `json:"project_no"`
+	TitleTH      *string   `json:"title_th"`
+	TitleEN      *string   `json:"title_en"`
+	AcademicYear int       `json:"academic_year"`
+	Semester     int       `json:"semester"`
+	Status       string    `json:"status"`
+	DeletedAt    time.Time `json:"deleted_at"`
+	DeletedBy    *string   `json:"deleted_by"`
+	PurgeAt      time.Time `json:"purge_at"`
+}
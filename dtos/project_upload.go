@@ -0,0 +1,58 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/project-box/models"
+)
+
+type ProjectUploadFile struct {
+	FileName       string `json:"file_name" binding:"required"`
+	Size           int64  `json:"size" binding:"required,gt=0"`
+	ContentType    string `json:"content_type" binding:"required"`
+	ChecksumSHA256 string `json:"checksum_sha256" binding:"required,len=64,hexadecimal"`
+}
+
+type ProjectUploadRequest struct {
+	Files []ProjectUploadFile `json:"files" binding:"required,min=1,dive"`
+}
+
+// ProjectUploadTarget tells the client where to PUT one declared file. The
+// request must carry the listed headers.
+type ProjectUploadTarget struct {
+	UploadID  int               `json:"upload_id"`
+	FileName  string            `json:"file_name"`
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+type ProjectUploadResponse struct {
+	Uploads []ProjectUploadTarget `json:"uploads"`
+}
+
+// FinalizeProjectResource is either a link (URL) or a previously uploaded
+// file (UploadID).
+type FinalizeProjectResource struct {
+	Title    *string `json:"title"`
+	URL      *string `json:"url"`
+	UploadID *int    `json:"upload_id"`
+}
+
+type FinalizeProjectRequest struct {
+	Project          *models.ProjectRequest    `json:"project" binding:"required"`
+	ProjectResources []FinalizeProjectResource `json:"project_resources"`
+}
+
+// ResumableUploadStatus reports how much of a resumable upload has arrived.
+// The next chunk must be sent with Upload-Offset equal to Offset.
+type ResumableUploadStatus struct {
+	UploadID     int       `json:"upload_id"`
+	FileName     string    `json:"file_name"`
+	Size         int64     `json:"size"`
+	Offset       int64     `json:"offset"`
+	MaxChunkSize int64     `json:"max_chunk_size"`
+	Complete     bool      `json:"complete"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
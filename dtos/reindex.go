@@ -0,0 +1,22 @@
+package dtos
+
+import "time"
+
+type ReindexRequest struct {
+	ProgramID     *int `json:"program_id"`
+	AcademicYear  *int `json:"academic_year"`
+	RatePerSecond int  `json:"rate_per_second"`
+}
+
+type ReindexStatus struct {
+	Status     string         `json:"status"`
+	Request    ReindexRequest `json:"request"`
+	Total      int64          `json:"total"`
+	Processed  int            `json:"processed"`
+	Published  int            `json:"published"`
+	Failed     int            `json:"failed"`
+	FailedIDs  []int          `json:"failed_ids"`
+	Error      *string        `json:"error"`
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt *time.Time     `json:"finished_at"`
+}
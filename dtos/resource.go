@@ -12,5 +12,7 @@ type ProjectResource struct {
 	FileExtensionID *int          `json:"file_extension_id"`
 	FileExtension   FileExtension `json:"file_extension" gorm:"foreignKey:FileExtensionID;constraint:OnDelete:CASCADE"`
 	ProjectID       int           `json:"project_id"`
+	Version         int           `json:"version"`
+	IsCurrent       bool          `json:"is_current"`
 	CreatedAt       string        `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 }
@@ -0,0 +1,23 @@
+package dtos
+
+type RubricCriterionRequest struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name" binding:"required"`
+	Description *string `json:"description"`
+	Weight      float64 `json:"weight"`
+	MinScore    float64 `json:"min_score"`
+	MaxScore    float64 `json:"max_score"`
+}
+
+type RubricRoleWeightRequest struct {
+	ProjectRoleID int     `json:"project_role_id" binding:"required"`
+	Weight        float64 `json:"weight"`
+}
+
+type RubricRequest struct {
+	Name        string                    `json:"name" binding:"required"`
+	Description *string                   `json:"description"`
+	ProgramID   int                       `json:"program_id" binding:"required"`
+	Criteria    []RubricCriterionRequest  `json:"criteria" binding:"required"`
+	RoleWeights []RubricRoleWeightRequest `json:"role_weights"`
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/dtos"
+	"github.com/project-box/models"
+	"github.com/project-box/services"
+)
+
+type AuditHandler interface {
+	GetAuditLogs(c *gin.Context)
+	ExportAuditLogs(c *gin.Context)
+}
+
+type auditHandler struct {
+	auditService services.AuditService
+	logger       *slog.Logger
+}
+
+func NewAuditHandler(auditService services.AuditService, logger *slog.Logger) AuditHandler {
+	return &auditHandler{
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+var auditCSVHeader = []string{"id", "created_at", "actor", "actor_email", "action", "entity_type", "entity_id", "program_id", "changes"}
+
+// @Summary List audit log entries
+// @Description Lists recorded creates, updates and deletes, newest first. Each entry's changes map every changed field to its before and after value. Program staff must filter by one of their programs; admins may read the whole log.
+// @Tags Audit
+// @Produce json
+// @Param program_id query int false "Program ID (required unless admin)"
+// @Param entity_type query string false "Entity type" Enums(project, staff, student, keyword, config, project_config, project_resource_config, import_job, project_resource)
+// @Param entity_id query string false "Entity ID"
+// @Param actor query string false "Subject or email of whoever made the change"
+// @Param action query string false "Action" Enums(create, update, delete, restore, purge)
+// @Param from query string false "Earliest time, inclusive (RFC 3339)"
+// @Param to query string false "Latest time, exclusive (RFC 3339)"
+// @Param limit query int false "Page size (default 50, max 500)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} dtos.AuditLogPage
+// @Failure 400 {object} map[string]interface{} "Invalid query"
+// @Failure 403 {object} map[string]interface{} "Not program staff of the program"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/audit [get]
+func (h *auditHandler) GetAuditLogs(c *gin.Context) {
+	filter := &dtos.AuditLogFilter{}
+	if err := c.ShouldBindQuery(filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.auditService.GetAuditLogs(c.Request.Context(), filter)
+	if err != nil {
+		writeAuditError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary Export audit log entries as CSV
+// @Description Downloads every entry matching the filters, oldest first, as a CSV file. The changes column holds the same JSON as the list endpoint.
+// @Tags Audit
+// @Produce text/csv
+// @Param program_id query int false "Program ID (required unless admin)"
+// @Param entity_type query string false "Entity type" Enums(project, staff, student, keyword, config, project_config, project_resource_config, import_job, project_resource)
+// @Param entity_id query string false "Entity ID"
+// @Param actor query string false "Subject or email of whoever made the change"
+// @Param action query string false "Action" Enums(create, update, delete, restore, purge)
+// @Param from query string false "Earliest time, inclusive (RFC 3339)"
+// @Param to query string false "Latest time, exclusive (RFC 3339)"
+// @Success 200 {string} string "CSV file"
+// @Failure 400 {object} map[string]interface{} "Invalid query"
+// @Failure 403 {object} map[string]interface{} "Not program staff of the program"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/audit/export [get]
+func (h *auditHandler) ExportAuditLogs(c *gin.Context) {
+	filter := &dtos.AuditLogFilter{}
+	if err := c.ShouldBindQuery(filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The response starts with the first row, so errors found before then
+	// can still be reported as JSON.
+	writer := csv.NewWriter(c.Writer)
+	started := false
+	start := func() error {
+		started = true
+		c.Header("Content-Disposition", `attachment; filename="audit-log.csv"`)
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Status(http.StatusOK)
+		return writer.Write(auditCSVHeader)
+	}
+
+	err := h.auditService.ExportAuditLogs(c.Request.Context(), filter, func(entry *models.AuditLog) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return writer.Write(auditCSVRecord(entry))
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err != nil && !started {
+		writeAuditError(c, err)
+		return
+	}
+
+	writer.Flush()
+	if err == nil {
+		err = writer.Error()
+	}
+	if err != nil {
+		h.logger.ErrorContext(c.Request.Context(), "Failed to export audit log", "error", err)
+		c.Abort()
+	}
+}
+
+func auditCSVRecord(entry *models.AuditLog) []string {
+	var programId string
+	if entry.ProgramID != nil {
+		programId = strconv.Itoa(*entry.ProgramID)
+	}
+	return []string{
+		strconv.Itoa(entry.ID),
+		entry.CreatedAt.UTC().Format(time.RFC3339),
+		entry.Actor,
+		entry.ActorEmail,
+		entry.Action,
+		entry.EntityType,
+		entry.EntityID,
+		programId,
+		string(entry.Changes),
+	}
+}
+
+func writeAuditError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidAuditFilter):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAuditForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
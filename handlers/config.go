@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -36,6 +37,7 @@ func NewConfigHandler(configService services.ConfigService) ConfigHandler {
 // @Failure 400 {object} map[string]interface{} "Invalid program ID"
 // @Failure 404 {object} map[string]interface{} "Program not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/configs/program/{program_id} [get]
 func (h *configHandler) GetConfigByProgramId(c *gin.Context) {
 	programId, err := strconv.Atoi(c.Param("program_id"))
@@ -64,6 +66,7 @@ func (h *configHandler) GetConfigByProgramId(c *gin.Context) {
 // @Produce json
 // @Success 200 {array} dtos.AcademicYearResponse "Successfully retrieved academic years"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/configs/academic-years [get]
 func (h *configHandler) GetAllAcademicYear(c *gin.Context) {
 	academicYears, err := h.configService.GetAllAcademicYear(c.Request.Context())
@@ -82,8 +85,10 @@ func (h *configHandler) GetAllAcademicYear(c *gin.Context) {
 // @Param config body models.Config true "Config details"
 // @Success 200 {object} models.Config "Successfully upserted config"
 // @Failure 400 {object} map[string]interface{} "Invalid program ID or config data"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
 // @Failure 404 {object} map[string]interface{} "Program not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/configs [put]
 func (h *configHandler) UpsertConfig(c *gin.Context) {
 	config := &models.Config{}
@@ -91,9 +96,28 @@ func (h *configHandler) UpsertConfig(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid config data"})
 		return
 	}
+	if !isProgramStaff(c, config.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+	if config.ID != 0 {
+		existing, err := h.configService.GetConfigByID(c.Request.Context(), config.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if existing != nil && !isProgramStaff(c, existing.ProgramID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			return
+		}
+	}
 
-	config, err := h.configService.UpsertConfig(c, config)
+	config, err := h.configService.UpsertConfig(c.Request.Context(), config)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidConfig) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -108,8 +132,10 @@ func (h *configHandler) UpsertConfig(c *gin.Context) {
 // @Param id path int true "Config ID"
 // @Success 200 {object} map[string]interface{} "Successfully deleted config"
 // @Failure 400 {object} map[string]interface{} "Invalid config ID"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
 // @Failure 404 {object} map[string]interface{} "Config not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/configs/{id} [delete]
 func (h *configHandler) DeleteConfig(c *gin.Context) {
 	configIdStr := c.Param("id")
@@ -119,6 +145,20 @@ func (h *configHandler) DeleteConfig(c *gin.Context) {
 		return
 	}
 
+	config, err := h.configService.GetConfigByID(c.Request.Context(), configId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Config not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	if !isProgramStaff(c, config.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
 	err = h.configService.DeleteConfig(c.Request.Context(), configId)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
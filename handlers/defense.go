@@ -0,0 +1,477 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/dtos"
+	"github.com/project-box/repositories"
+	"github.com/project-box/services"
+	"gorm.io/gorm"
+)
+
+type DefenseHandler interface {
+	GetRooms(c *gin.Context)
+	CreateRoom(c *gin.Context)
+	DeleteRoom(c *gin.Context)
+	GetRoomCalendar(c *gin.Context)
+	GetSlots(c *gin.Context)
+	CreateSlot(c *gin.Context)
+	DeleteSlot(c *gin.Context)
+	GetDefenses(c *gin.Context)
+	GetDefense(c *gin.Context)
+	ScheduleDefense(c *gin.Context)
+	CancelDefense(c *gin.Context)
+	ProposeDefenses(c *gin.Context)
+	GetStaffCalendar(c *gin.Context)
+}
+
+type defenseHandler struct {
+	defenseService services.DefenseService
+	projectService services.ProjectService
+}
+
+func NewDefenseHandler(defenseService services.DefenseService, projectService services.ProjectService) DefenseHandler {
+	return &defenseHandler{
+		defenseService: defenseService,
+		projectService: projectService,
+	}
+}
+
+// @Summary List defense rooms
+// @Description Lists the rooms a program holds project defenses in
+// @Tags Defense
+// @Produce json
+// @Param program_id query int true "Program ID"
+// @Success 200 {array} models.DefenseRoom
+// @Failure 400 {object} map[string]interface{} "Invalid program ID"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/defenses/rooms [get]
+func (h *defenseHandler) GetRooms(c *gin.Context) {
+	programId, err := strconv.Atoi(c.Query("program_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid program ID"})
+		return
+	}
+
+	rooms, err := h.defenseService.GetRooms(c.Request.Context(), programId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rooms)
+}
+
+// @Summary Create a defense room
+// @Description Adds a room that defenses of the program can be booked in
+// @Tags Defense
+// @Accept json
+// @Produce json
+// @Param room body dtos.DefenseRoomRequest true "Room"
+// @Success 201 {object} models.DefenseRoom
+// @Failure 400 {object} map[string]interface{} "Invalid room"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/defenses/rooms [post]
+func (h *defenseHandler) CreateRoom(c *gin.Context) {
+	req := &dtos.DefenseRoomRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !isProgramStaff(c, req.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	room, err := h.defenseService.CreateRoom(c.Request.Context(), req)
+	if err != nil {
+		writeDefenseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, room)
+}
+
+// @Summary Delete a defense room
+// @Description Deletes a room that has no defenses booked in it
+// @Tags Defense
+// @Produce json
+// @Param id path int true "Room ID"
+// @Success 200 {object} map[string]interface{} "Room deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid room ID"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Room not found"
+// @Failure 409 {object} map[string]interface{} "Defenses are booked in the room"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/defenses/rooms/{id} [delete]
+func (h *defenseHandler) DeleteRoom(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid room ID"})
+		return
+	}
+
+	room, err := h.defenseService.GetRoom(c.Request.Context(), id)
+	if err != nil {
+		writeDefenseError(c, err)
+		return
+	}
+	if !isProgramStaff(c, room.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	if err := h.defenseService.DeleteRoom(c.Request.Context(), id); err != nil {
+		writeDefenseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Room deleted successfully"})
+}
+
+// @Summary Export a room's defense calendar
+// @Description Returns every defense booked in the room as an iCalendar file
+// @Tags Defense
+// @Produce text/calendar
+// @Param id path int true "Room ID"
+// @Success 200 {string} string "iCalendar file"
+// @Failure 400 {object} map[string]interface{} "Invalid room ID"
+// @Failure 404 {object} map[string]interface{} "Room not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/defenses/rooms/{id}/calendar.ics [get]
+func (h *defenseHandler) GetRoomCalendar(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid room ID"})
+		return
+	}
+
+	calendar, err := h.defenseService.GetRoomCalendar(c.Request.Context(), id)
+	if err != nil {
+		writeDefenseError(c, err)
+		return
+	}
+
+	writeCalendar(c, fmt.Sprintf("room-%d-defenses.ics", id), calendar)
+}
+
+// @Summary List defense slots
+// @Description Lists the time slots defenses of a program can be booked in
+// @Tags Defense
+// @Produce json
+// @Param program_id query int true "Program ID"
+// @Param academic_year query int false "Academic year"
+// @Param semester query int false "Semester"
+// @Success 200 {array} models.DefenseSlot
+// @Failure 400 {object} map[string]interface{} "Invalid query"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/defenses/slots [get]
+func (h *defenseHandler) GetSlots(c *gin.Context) {
+	filter := &dtos.DefenseFilter{}
+	if err := c.ShouldBindQuery(filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	slots, err := h.defenseService.GetSlots(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, slots)
+}
+
+// @Summary Create a defense slot
+// @Description Adds a time slot in which any of the program's rooms can be booked for a defense
+// @Tags Defense
+// @Accept json
+// @Produce json
+// @Param slot body dtos.DefenseSlotRequest true "Slot"
+// @Success 201 {object} models.DefenseSlot
+// @Failure 400 {object} map[string]interface{} "Invalid slot"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/defenses/slots [post]
+func (h *defenseHandler) CreateSlot(c *gin.Context) {
+	req := &dtos.DefenseSlotRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !isProgramStaff(c, req.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	slot, err := h.defenseService.CreateSlot(c.Request.Context(), req)
+	if err != nil {
+		writeDefenseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, slot)
+}
+
+// @Summary Delete a defense slot
+// @Description Deletes a slot that has no defenses booked in it
+// @Tags Defense
+// @Produce json
+// @Param id path int true "Slot ID"
+// @Success 200 {object} map[string]interface{} "Slot deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid slot ID"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Slot not found"
+// @Failure 409 {object} map[string]interface{} "Defenses are booked in the slot"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/defenses/slots/{id} [delete]
+func (h *defenseHandler) DeleteSlot(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid slot ID"})
+		return
+	}
+
+	slot, err := h.defenseService.GetSlot(c.Request.Context(), id)
+	if err != nil {
+		writeDefenseError(c, err)
+		return
+	}
+	if !isProgramStaff(c, slot.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	if err := h.defenseService.DeleteSlot(c.Request.Context(), id); err != nil {
+		writeDefenseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Slot deleted successfully"})
+}
+
+// @Summary List defenses
+// @Description Lists the defenses booked for a program
+// @Tags Defense
+// @Produce json
+// @Param program_id query int true "Program ID"
+// @Param academic_year query int false "Academic year"
+// @Param semester query int false "Semester"
+// @Success 200 {array} models.Defense
+// @Failure 400 {object} map[string]interface{} "Invalid query"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/defenses [get]
+func (h *defenseHandler) GetDefenses(c *gin.Context) {
+	filter := &dtos.DefenseFilter{}
+	if err := c.ShouldBindQuery(filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	defenses, err := h.defenseService.GetDefenses(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, defenses)
+}
+
+// @Summary Get a defense
+// @Description Fetches a defense booking with its project and room
+// @Tags Defense
+// @Produce json
+// @Param id path int true "Defense ID"
+// @Success 200 {object} models.Defense
+// @Failure 400 {object} map[string]interface{} "Invalid defense ID"
+// @Failure 404 {object} map[string]interface{} "Defense not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/defenses/{id} [get]
+func (h *defenseHandler) GetDefense(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid defense ID"})
+		return
+	}
+
+	defense, err := h.defenseService.GetDefense(c.Request.Context(), id)
+	if err != nil {
+		writeDefenseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, defense)
+}
+
+// @Summary Schedule a defense
+// @Description Books a room during a slot for a project's defense, moving an earlier booking of the project. Bookings that double-book a committee member, a student or the room are rejected with the conflicts found.
+// @Tags Defense
+// @Accept json
+// @Produce json
+// @Param defense body dtos.DefenseRequest true "Booking"
+// @Success 200 {object} models.Defense
+// @Failure 400 {object} map[string]interface{} "Invalid booking"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Project, slot or room not found"
+// @Failure 409 {object} map[string]interface{} "Conflicting bookings, listed under conflicts"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/defenses [post]
+func (h *defenseHandler) ScheduleDefense(c *gin.Context) {
+	req := &dtos.DefenseRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := h.projectService.GetProjectByID(c.Request.Context(), req.ProjectID)
+	if err != nil {
+		writeDefenseError(c, err)
+		return
+	}
+	if !isProgramStaff(c, project.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	defense, err := h.defenseService.ScheduleDefense(c.Request.Context(), req)
+	if err != nil {
+		writeDefenseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, defense)
+}
+
+// @Summary Cancel a defense
+// @Description Removes a defense booking
+// @Tags Defense
+// @Produce json
+// @Param id path int true "Defense ID"
+// @Success 200 {object} map[string]interface{} "Defense cancelled successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid defense ID"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Defense not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/defenses/{id} [delete]
+func (h *defenseHandler) CancelDefense(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid defense ID"})
+		return
+	}
+
+	defense, err := h.defenseService.GetDefense(c.Request.Context(), id)
+	if err != nil {
+		writeDefenseError(c, err)
+		return
+	}
+	if !isProgramStaff(c, defense.Project.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	if err := h.defenseService.CancelDefense(c.Request.Context(), id); err != nil {
+		writeDefenseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Defense cancelled successfully"})
+}
+
+// @Summary Propose defense slots
+// @Description Assigns every unscheduled project of the semester that is in progress or in its defense to the earliest conflict-free slot and room. Projects that fit nowhere are returned with the bookings that block them. Set apply to book the proposals.
+// @Tags Defense
+// @Accept json
+// @Produce json
+// @Param proposal body dtos.DefenseProposalRequest true "Proposal request"
+// @Success 200 {object} dtos.DefenseProposalResult
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/defenses/proposals [post]
+func (h *defenseHandler) ProposeDefenses(c *gin.Context) {
+	req := &dtos.DefenseProposalRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !isProgramStaff(c, req.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	result, err := h.defenseService.ProposeDefenses(c.Request.Context(), req)
+	if err != nil {
+		writeDefenseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary Export a staff member's defense calendar
+// @Description Returns every defense of the projects a staff member is on the committee of as an iCalendar file
+// @Tags Defense
+// @Produce text/calendar
+// @Param staff_id path int true "Staff ID"
+// @Success 200 {string} string "iCalendar file"
+// @Failure 400 {object} map[string]interface{} "Invalid staff ID"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/defenses/staffs/{staff_id}/calendar.ics [get]
+func (h *defenseHandler) GetStaffCalendar(c *gin.Context) {
+	staffId, err := strconv.Atoi(c.Param("staff_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid staff ID"})
+		return
+	}
+
+	calendar, err := h.defenseService.GetStaffCalendar(c.Request.Context(), staffId)
+	if err != nil {
+		writeDefenseError(c, err)
+		return
+	}
+
+	writeCalendar(c, fmt.Sprintf("staff-%d-defenses.ics", staffId), calendar)
+}
+
+func writeCalendar(c *gin.Context, fileName string, calendar []byte) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar)
+}
+
+func writeDefenseError(c *gin.Context, err error) {
+	var conflictErr *services.DefenseConflictError
+	switch {
+	case errors.As(err, &conflictErr):
+		c.JSON(http.StatusConflict, gin.H{"error": services.ErrDefenseConflict.Error(), "conflicts": conflictErr.Conflicts})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, services.ErrInvalidDefense):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrProjectNotSchedulable), errors.Is(err, repositories.ErrDefenseResourceInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/dtos"
+	"github.com/project-box/services"
+	"gorm.io/gorm"
+)
+
+type EvaluationHandler interface {
+	SubmitEvaluation(c *gin.Context)
+	GetProjectScore(c *gin.Context)
+	GetRubricScores(c *gin.Context)
+}
+
+type evaluationHandler struct {
+	evaluationService services.EvaluationService
+	rubricService     services.RubricService
+}
+
+func NewEvaluationHandler(evaluationService services.EvaluationService, rubricService services.RubricService) EvaluationHandler {
+	return &evaluationHandler{
+		evaluationService: evaluationService,
+		rubricService:     rubricService,
+	}
+}
+
+// @Summary Submit an evaluation
+// @Description Records the caller's scores and comments for every criterion of a rubric. The caller must be on the project's committee and the project must be in its defense. Submitting again replaces the previous evaluation.
+// @Tags Evaluation
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param evaluation body dtos.EvaluationRequest true "Evaluation"
+// @Success 200 {object} models.ProjectEvaluation
+// @Failure 400 {object} map[string]interface{} "Invalid evaluation"
+// @Failure 403 {object} map[string]interface{} "Not on the project's committee"
+// @Failure 404 {object} map[string]interface{} "Project or rubric not found"
+// @Failure 409 {object} map[string]interface{} "Project is not in its defense"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/evaluations [put]
+func (h *evaluationHandler) SubmitEvaluation(c *gin.Context) {
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	req := &dtos.EvaluationRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	evaluation, err := h.evaluationService.SubmitEvaluation(c.Request.Context(), projectId, req)
+	if err != nil {
+		writeEvaluationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, evaluation)
+}
+
+// @Summary Get a project's score breakdown
+// @Description Returns the per-criterion, per-evaluator and per-role scores of a project and its final grade under a rubric
+// @Tags Evaluation
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param rubric_id query int true "Rubric ID"
+// @Success 200 {object} dtos.ProjectScore
+// @Failure 400 {object} map[string]interface{} "Invalid project or rubric ID"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Project or rubric not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/scores [get]
+func (h *evaluationHandler) GetProjectScore(c *gin.Context) {
+	projectId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	rubricId, err := strconv.Atoi(c.Query("rubric_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rubric ID"})
+		return
+	}
+
+	if !h.canViewScores(c, rubricId) {
+		return
+	}
+
+	score, err := h.evaluationService.GetProjectScore(c.Request.Context(), projectId, rubricId)
+	if err != nil {
+		writeEvaluationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, score)
+}
+
+// @Summary Get score breakdowns for a rubric
+// @Description Returns the score breakdown of every project in the rubric's program that has reached its defense
+// @Tags Evaluation
+// @Produce json
+// @Param id path int true "Rubric ID"
+// @Param academic_year query int false "Academic year"
+// @Param semester query int false "Semester"
+// @Success 200 {array} dtos.ProjectScore
+// @Failure 400 {object} map[string]interface{} "Invalid rubric ID or query"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Rubric not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/rubrics/{id}/scores [get]
+func (h *evaluationHandler) GetRubricScores(c *gin.Context) {
+	rubricId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rubric ID"})
+		return
+	}
+
+	filter := &dtos.ProjectScoreFilter{}
+	if err := c.ShouldBindQuery(filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !h.canViewScores(c, rubricId) {
+		return
+	}
+
+	scores, err := h.evaluationService.GetRubricScores(c.Request.Context(), rubricId, filter)
+	if err != nil {
+		writeEvaluationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, scores)
+}
+
+// canViewScores restricts score breakdowns to program staff of the rubric's
+// program and writes the error response when the caller is not allowed.
+func (h *evaluationHandler) canViewScores(c *gin.Context, rubricId int) bool {
+	rubric, err := h.rubricService.GetRubricByID(c.Request.Context(), rubricId)
+	if err != nil {
+		writeEvaluationError(c, err)
+		return false
+	}
+	if !isProgramStaff(c, rubric.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return false
+	}
+	return true
+}
+
+func writeEvaluationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project or rubric not found"})
+	case errors.Is(err, services.ErrInvalidEvaluation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrNotProjectCommittee):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrProjectNotInDefense):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/dtos"
+	"github.com/project-box/services"
+)
+
+type HealthHandler interface {
+	Healthz(c *gin.Context)
+	Readyz(c *gin.Context)
+}
+
+type healthHandler struct {
+	healthService services.HealthService
+}
+
+func NewHealthHandler(healthService services.HealthService) HealthHandler {
+	return &healthHandler{
+		healthService: healthService,
+	}
+}
+
+// Healthz is the liveness probe. It reports that the process is serving HTTP
+// and deliberately checks no dependency, so an outage elsewhere does not get
+// the pod restarted. It sits outside /api and is not part of the API docs.
+func (h *healthHandler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": dtos.HealthStatusOK})
+}
+
+// Readyz is the readiness probe. It answers 503 with the same report while
+// any dependency is unreachable.
+func (h *healthHandler) Readyz(c *gin.Context) {
+	report := h.healthService.Readiness(c.Request.Context())
+	if report.Status != dtos.HealthStatusOK {
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/project-box/models"
 	"github.com/project-box/services"
+	"gorm.io/gorm"
 )
 
 type KeywordHandler interface {
@@ -33,6 +36,7 @@ func NewKeywordHandler(service services.KeywordService) KeywordHandler {
 // @Produce  json
 // @Success 200 {array} models.Keyword
 // @Failure 500 {object} map[string]string
+// @Security BearerAuth
 // @Router /v1/keywords/all [get]
 func (h *keywordHandler) GetAllKeywords(c *gin.Context) {
 	keywords, err := h.service.GetAllKeywords(c.Request.Context())
@@ -52,6 +56,7 @@ func (h *keywordHandler) GetAllKeywords(c *gin.Context) {
 // @Param program_id query string true "Program ID"
 // @Success 200 {array} models.Keyword
 // @Failure 500 {object} map[string]string
+// @Security BearerAuth
 // @Router /v1/keywords [get]
 func (h *keywordHandler) GetKeywords(c *gin.Context) {
 	programID := c.Query("program_id")
@@ -72,6 +77,7 @@ func (h *keywordHandler) GetKeywords(c *gin.Context) {
 // @Param id path string true "Keyword ID"
 // @Success 200 {object} models.Keyword
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /v1/keywords/{id} [get]
 func (h *keywordHandler) GetKeyword(c *gin.Context) {
 	id := c.Param("id")
@@ -92,7 +98,10 @@ func (h *keywordHandler) GetKeyword(c *gin.Context) {
 // @Param keyword body models.Keyword true "Keyword"
 // @Success 201 {object} models.Keyword
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Insufficient permissions"
+// @Failure 409 {object} map[string]string "The program already has this keyword"
 // @Failure 500 {object} map[string]string
+// @Security BearerAuth
 // @Router /v1/keywords [post]
 func (h *keywordHandler) CreateKeyword(c *gin.Context) {
 	var keyword models.Keyword
@@ -100,8 +109,12 @@ func (h *keywordHandler) CreateKeyword(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if !isProgramStaff(c, keyword.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
 	if err := h.service.CreateKeyword(c.Request.Context(), &keyword); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeKeywordError(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, keyword)
@@ -116,8 +129,11 @@ func (h *keywordHandler) CreateKeyword(c *gin.Context) {
 // @Param keyword body models.Keyword true "Keyword"
 // @Success 200 {object} models.Keyword
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Insufficient permissions"
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "The program already has this keyword"
 // @Failure 500 {object} map[string]string
+// @Security BearerAuth
 // @Router /v1/keywords [put]
 func (h *keywordHandler) UpdateKeyword(c *gin.Context) {
 	var keyword models.Keyword
@@ -125,8 +141,21 @@ func (h *keywordHandler) UpdateKeyword(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if !isProgramStaff(c, keyword.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+	existing, err := h.service.GetKeyword(c.Request.Context(), strconv.Itoa(keyword.ID))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		writeKeywordError(c, err)
+		return
+	}
+	if err == nil && !isProgramStaff(c, existing.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
 	if err := h.service.UpdateKeyword(c.Request.Context(), &keyword); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeKeywordError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, keyword)
@@ -140,13 +169,33 @@ func (h *keywordHandler) UpdateKeyword(c *gin.Context) {
 // @Produce  json
 // @Param id path string true "Keyword ID"
 // @Success 204
+// @Failure 403 {object} map[string]string "Insufficient permissions"
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Security BearerAuth
 // @Router /v1/keywords/{id} [delete]
 func (h *keywordHandler) DeleteKeyword(c *gin.Context) {
 	id := c.Param("id")
+	keyword, err := h.service.GetKeyword(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Keyword not found"})
+		return
+	}
+	if !isProgramStaff(c, keyword.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
 	if err := h.service.DeleteKeyword(c.Request.Context(), id); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusNoContent, nil)
 }
+
+func writeKeywordError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		c.JSON(http.StatusConflict, gin.H{"error": "The program already has this keyword"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
+	"github.com/project-box/middlewares"
+)
+
+// isProgramStaff reports whether the caller may manage data that belongs to
+// programId.
+func isProgramStaff(c *gin.Context, programId int) bool {
+	principal, ok := middlewares.GetPrincipal(c)
+	return ok && principal.HasRole(programId, auth.RoleProgramStaff)
+}
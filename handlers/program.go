@@ -31,9 +31,10 @@ func NewProgramHandler(programService services.ProgramService) ProgramHandler {
 // @Produce json
 // @Success 200 {array} models.Program "Successfully fetched programs"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/programs [get]
 func (h *programHandler) GetPrograms(c *gin.Context) {
-	programs, err := h.programService.GetPrograms(c)
+	programs, err := h.programService.GetPrograms(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -50,6 +51,7 @@ func (h *programHandler) GetPrograms(c *gin.Context) {
 // @Success 201 {object} models.Program "Successfully created program"
 // @Failure 400 {object} map[string]interface{} "Invalid request body"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/programs [post]
 func (h *programHandler) CreateProgram(c *gin.Context) {
 	var program dtos.CreateProgramRequest
@@ -58,7 +60,7 @@ func (h *programHandler) CreateProgram(c *gin.Context) {
 		return
 	}
 
-	if err := h.programService.CreateProgram(c, &program); err != nil {
+	if err := h.programService.CreateProgram(c.Request.Context(), &program); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -75,6 +77,7 @@ func (h *programHandler) CreateProgram(c *gin.Context) {
 // @Success 200 {object} map[string]interface{} "Successfully updated program"
 // @Failure 400 {object} map[string]interface{} "Invalid request body or parameters"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/programs [put]
 func (h *programHandler) UpdateProgram(c *gin.Context) {
 	var program models.Program
@@ -1,18 +1,39 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/project-box/dtos"
+	"github.com/project-box/models"
+	"github.com/project-box/repositories"
 	"github.com/project-box/services"
+	"gorm.io/gorm"
 )
 
 type ProjectHandler interface {
+	GetProjectByID(c *gin.Context)
+	GetProjects(c *gin.Context)
 	CreateProject(c *gin.Context)
 	UpdateProject(c *gin.Context)
 	DeleteProject(c *gin.Context)
+	ApproveProject(c *gin.Context)
+	RejectProject(c *gin.Context)
+	TransitionProjectStatus(c *gin.Context)
+	GetProjectStatusHistory(c *gin.Context)
+	PatchProject(c *gin.Context)
+	AddProjectMember(c *gin.Context)
+	RemoveProjectMember(c *gin.Context)
+	AddProjectStaff(c *gin.Context)
+	RemoveProjectStaff(c *gin.Context)
+	AddProjectKeyword(c *gin.Context)
+	RemoveProjectKeyword(c *gin.Context)
+	AddProjectResource(c *gin.Context)
+	RetireProjectResource(c *gin.Context)
 }
 
 type projectHandler struct {
@@ -25,6 +46,82 @@ func NewProjectHandler(projectService services.ProjectService) ProjectHandler {
 	}
 }
 
+// @Summary Get a project by ID
+// @Description Fetches a project with its program, staffs, members, keywords and resources
+// @Tags Project
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Success 200 {object} dtos.ProjectData "Successfully retrieved project"
+// @Header 200 {string} ETag "Project version"
+// @Failure 400 {object} map[string]interface{} "Invalid project ID"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id} [get]
+func (h *projectHandler) GetProjectByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	project, err := h.projectService.GetProjectByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeProject(c, http.StatusOK, project)
+}
+
+// @Summary List projects
+// @Description Lists projects matching the given filters. Pages are selected either by offset or by the next_cursor returned from a previous page.
+// @Tags Project
+// @Produce  json
+// @Param program_id query int false "Program ID"
+// @Param academic_year query int false "Academic year"
+// @Param semester query int false "Semester"
+// @Param section_id query string false "Section ID"
+// @Param staff_id query int false "Staff ID"
+// @Param student_id query string false "Student ID"
+// @Param keyword_id query int false "Keyword ID"
+// @Param keyword query string false "Keyword text (case-insensitive, partial match)"
+// @Param is_public query bool false "Public projects only"
+// @Param status query string false "Lifecycle status (proposal, rejected, in_progress, defense, completed, archived)"
+// @Param sort_by query string false "Sort field" Enums(id, project_no, title_th, title_en, academic_year, created_at, updated_at)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Offset"
+// @Param cursor query string false "Cursor from the previous page"
+// @Success 200 {object} dtos.ProjectPage "Successfully retrieved projects"
+// @Failure 400 {object} map[string]interface{} "Invalid query"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects [get]
+func (h *projectHandler) GetProjects(c *gin.Context) {
+	filter := &dtos.ProjectFilter{}
+	if err := c.ShouldBindQuery(filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.projectService.GetProjects(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidProjectFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
 // @Summary Create a new project
 // @Description Creates a new project with the provided data
 // @Tags Project
@@ -35,8 +132,11 @@ func NewProjectHandler(projectService services.ProjectService) ProjectHandler {
 // @Param files formData file false "Upload Files"
 // @Param project formData string true "Project Data"
 // @Success 201 {object} models.Project "Successfully created project"
+// @Header 201 {string} ETag "Project version"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "No role in the project's program"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/projects [post]
 func (h *projectHandler) CreateProject(c *gin.Context) {
 	req := &dtos.CreateProjectRequest{}
@@ -45,17 +145,21 @@ func (h *projectHandler) CreateProject(c *gin.Context) {
 		return
 	}
 
-	project, err := h.projectService.CreateProjectWithFiles(c, req.Project, req.ProjectResources, req.Files)
+	project, err := h.projectService.CreateProjectWithFiles(c.Request.Context(), req.Project, req.ProjectResources, req.Files)
+	if errors.Is(err, services.ErrProjectForbidden) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, *project)
+	writeProject(c, http.StatusCreated, project)
 }
 
 // @Summary Update an existing project
-// @Description Updates a project by its ID with the provided data
+// @Description Replaces a project with the provided data; members, staff and keywords are set to exactly those in the request. Use PATCH /v1/projects/{id} and the sub-resource endpoints to change only part of a project. A resource entry with the id of a current resource keeps it; an uploaded file whose title matches a current resource becomes its new version. Current resources left out of the request are no longer current but keep their version history. Changing the staff, academic year or semester is reserved for program staff and the project's advisors; a project moved to another program, academic year or semester gets a new project number.
 // @Tags Project
 // @Accept  multipart/form-data
 // @Produce  json
@@ -63,35 +167,53 @@ func (h *projectHandler) CreateProject(c *gin.Context) {
 // @Param titles formData string false "List of Titles"
 // @Param files formData file false "Upload Files"
 // @Param project formData string true "Project Data"
+// @Param If-Match header string true "ETag of the project as last read, or *"
 // @Success 200 {object} models.Project "Successfully updated project"
+// @Header 200 {string} ETag "Project version"
 // @Failure 400 {object} map[string]interface{} "Invalid project ID or request"
+// @Failure 403 {object} map[string]interface{} "Not program staff or a member of the project, or not allowed to change its staff or term"
+// @Failure 409 {object} map[string]interface{} "is_public set before the project is completed"
+// @Failure 412 {object} map[string]interface{} "Project changed since it was read; the body holds the current project"
+// @Failure 428 {object} map[string]interface{} "Missing If-Match header"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/projects/{id} [put]
 func (h *projectHandler) UpdateProject(c *gin.Context) {
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	req := &dtos.UpdateProjectRequest{}
 	if err := c.ShouldBind(req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	project, err := h.projectService.UpdateProjectWithFiles(c, req.Project, req.ProjectResources, req.Files)
+	project, err := h.projectService.UpdateProjectWithFiles(c.Request.Context(), req.Project, version, req.ProjectResources, req.Files)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeProjectEditError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, project)
+	writeProject(c, http.StatusOK, project)
 }
 
 // DeleteProject deletes a project by its ID
 // @Summary Delete a project by ID
-// @Description Deletes the specified project using its ID
+// @Description Moves the project to the trash. It is hidden from every listing and from search, and can be restored by program staff until the trash retention period has passed; after that it is purged together with its files.
 // @Tags Project
 // @Produce  json
 // @Param id path int true "Project ID"
+// @Param If-Match header string true "ETag of the project as last read, or *"
 // @Success 200 {object} map[string]interface{} "Project deleted successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid project ID"
+// @Failure 403 {object} map[string]interface{} "Not program staff of the project's program"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 412 {object} map[string]interface{} "Project changed since it was read; the body holds the current project"
+// @Failure 428 {object} map[string]interface{} "Missing If-Match header"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/projects/{id} [delete]
 func (h *projectHandler) DeleteProject(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -100,10 +222,621 @@ func (h *projectHandler) DeleteProject(c *gin.Context) {
 		return
 	}
 
-	if err := h.projectService.DeleteProject(c, id); err != nil {
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if err := h.projectService.DeleteProject(c.Request.Context(), id, version); err != nil {
+		if errors.Is(err, repositories.ErrProjectVersionMismatch) || errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, services.ErrProjectForbidden) {
+			writeProjectEditError(c, err)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete project"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
 }
+
+// @Summary Approve a project proposal
+// @Description Approves a proposal and moves the project to in_progress. Only an advisor of the project may approve it.
+// @Tags Project
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param review body dtos.ProjectReviewRequest false "Review comment"
+// @Success 200 {object} models.ProjectStatusHistory "Status change"
+// @Failure 400 {object} map[string]interface{} "Invalid project ID or request"
+// @Failure 403 {object} map[string]interface{} "Not an advisor of the project"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 409 {object} map[string]interface{} "Project is not a proposal"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/approve [post]
+func (h *projectHandler) ApproveProject(c *gin.Context) {
+	h.reviewProject(c, h.projectService.ApproveProject)
+}
+
+// @Summary Reject a project proposal
+// @Description Rejects a proposal with a comment. Only an advisor of the project may reject it.
+// @Tags Project
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param review body dtos.ProjectReviewRequest true "Review comment"
+// @Success 200 {object} models.ProjectStatusHistory "Status change"
+// @Failure 400 {object} map[string]interface{} "Invalid project ID or missing comment"
+// @Failure 403 {object} map[string]interface{} "Not an advisor of the project"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 409 {object} map[string]interface{} "Project is not a proposal"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/reject [post]
+func (h *projectHandler) RejectProject(c *gin.Context) {
+	h.reviewProject(c, h.projectService.RejectProject)
+}
+
+func (h *projectHandler) reviewProject(c *gin.Context, review func(ctx context.Context, id int, comment *string) (*models.ProjectStatusHistory, error)) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	req := &dtos.ProjectReviewRequest{}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	history, err := review(c.Request.Context(), id, req.Comment)
+	if err != nil {
+		writeProjectStatusError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// @Summary Change project status
+// @Description Moves a project along its lifecycle (proposal, rejected, in_progress, defense, completed, archived). Decisions on a proposal are reserved for the project's advisors, and rejecting a proposal requires a comment.
+// @Tags Project
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param status body dtos.ProjectStatusRequest true "Target status"
+// @Success 200 {object} models.ProjectStatusHistory "Status change"
+// @Failure 400 {object} map[string]interface{} "Invalid project ID or status, or a rejection without a comment"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 409 {object} map[string]interface{} "Transition not allowed from the current status"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/status [post]
+func (h *projectHandler) TransitionProjectStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	req := &dtos.ProjectStatusRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	history, err := h.projectService.TransitionProjectStatus(c.Request.Context(), id, models.ProjectStatus(req.Status), req.Comment)
+	if err != nil {
+		writeProjectStatusError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// @Summary Get project status history
+// @Description Lists the status changes of a project, oldest first
+// @Tags Project
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Success 200 {array} models.ProjectStatusHistory "Status history"
+// @Failure 400 {object} map[string]interface{} "Invalid project ID"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/status-history [get]
+func (h *projectHandler) GetProjectStatusHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	history, err := h.projectService.GetProjectStatusHistory(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+func writeProjectStatusError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	case errors.Is(err, services.ErrInvalidProjectStatus), errors.Is(err, services.ErrCommentRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrProjectStatusForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrInvalidStatusTransition):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// @Summary Partially update a project
+// @Description Changes only the fields present in the body. Members, staff, keywords and resources are left untouched; use their sub-resource endpoints to change them. Changing academic_year or semester is reserved for program staff and the project's advisors and gives the project a new project number.
+// @Tags Project
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param project body dtos.PatchProjectRequest true "Fields to change"
+// @Param If-Match header string true "ETag of the project as last read, or *"
+// @Success 200 {object} dtos.ProjectData "Updated project"
+// @Header 200 {string} ETag "Project version"
+// @Failure 400 {object} map[string]interface{} "Invalid project ID or request"
+// @Failure 403 {object} map[string]interface{} "Not program staff or a member of the project, or not allowed to move it to another term"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 409 {object} map[string]interface{} "is_public set before the project is completed"
+// @Failure 412 {object} map[string]interface{} "Project changed since it was read; the body holds the current project"
+// @Failure 428 {object} map[string]interface{} "Missing If-Match header"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id} [patch]
+func (h *projectHandler) PatchProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	req := &dtos.PatchProjectRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := h.projectService.PatchProject(c.Request.Context(), id, version, req)
+	if err != nil {
+		writeProjectEditError(c, err)
+		return
+	}
+
+	writeProject(c, http.StatusOK, project)
+}
+
+// @Summary Add a member to a project
+// @Description Adds a student of the project's program without changing the other members
+// @Tags Project
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param member body dtos.ProjectMemberRequest true "Student to add"
+// @Param If-Match header string true "ETag of the project as last read, or *"
+// @Success 200 {object} dtos.ProjectData "Updated project"
+// @Header 200 {string} ETag "Project version"
+// @Failure 400 {object} map[string]interface{} "Invalid request or student of another program"
+// @Failure 403 {object} map[string]interface{} "Not program staff or a member of the project"
+// @Failure 404 {object} map[string]interface{} "Project or student not found"
+// @Failure 409 {object} map[string]interface{} "Student is already a member"
+// @Failure 412 {object} map[string]interface{} "Project changed since it was read; the body holds the current project"
+// @Failure 428 {object} map[string]interface{} "Missing If-Match header"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/members [post]
+func (h *projectHandler) AddProjectMember(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	req := &dtos.ProjectMemberRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := h.projectService.AddProjectMember(c.Request.Context(), id, version, req.StudentID)
+	if err != nil {
+		writeProjectEditError(c, err)
+		return
+	}
+
+	writeProject(c, http.StatusOK, project)
+}
+
+// @Summary Remove a member from a project
+// @Description Removes a single student from the project
+// @Tags Project
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param student_id path int true "Student ID (students.id)"
+// @Param If-Match header string true "ETag of the project as last read, or *"
+// @Success 200 {object} dtos.ProjectData "Updated project"
+// @Header 200 {string} ETag "Project version"
+// @Failure 400 {object} map[string]interface{} "Invalid ID"
+// @Failure 403 {object} map[string]interface{} "Not program staff or a member of the project"
+// @Failure 404 {object} map[string]interface{} "Project not found or student is not a member"
+// @Failure 412 {object} map[string]interface{} "Project changed since it was read; the body holds the current project"
+// @Failure 428 {object} map[string]interface{} "Missing If-Match header"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/members/{student_id} [delete]
+func (h *projectHandler) RemoveProjectMember(c *gin.Context) {
+	id, studentId, ok := projectSubresourceIDs(c, "student_id", "Invalid student ID")
+	if !ok {
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	project, err := h.projectService.RemoveProjectMember(c.Request.Context(), id, version, studentId)
+	if err != nil {
+		writeProjectEditError(c, err)
+		return
+	}
+
+	writeProject(c, http.StatusOK, project)
+}
+
+// @Summary Assign staff to a project
+// @Description Assigns a staff member of the project's program in the given project role. A staff member may hold several roles on one project. Only program staff and the project's advisors may change its staff.
+// @Tags Project
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param staff body dtos.ProjectStaffRequest true "Staff assignment"
+// @Param If-Match header string true "ETag of the project as last read, or *"
+// @Success 200 {object} dtos.ProjectData "Updated project"
+// @Header 200 {string} ETag "Project version"
+// @Failure 400 {object} map[string]interface{} "Invalid request or staff/role of another program"
+// @Failure 403 {object} map[string]interface{} "Not program staff or an advisor of the project"
+// @Failure 404 {object} map[string]interface{} "Project, staff or project role not found"
+// @Failure 409 {object} map[string]interface{} "Staff already holds this role"
+// @Failure 412 {object} map[string]interface{} "Project changed since it was read; the body holds the current project"
+// @Failure 428 {object} map[string]interface{} "Missing If-Match header"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/staffs [post]
+func (h *projectHandler) AddProjectStaff(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	req := &dtos.ProjectStaffRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := h.projectService.AddProjectStaff(c.Request.Context(), id, version, req)
+	if err != nil {
+		writeProjectEditError(c, err)
+		return
+	}
+
+	writeProject(c, http.StatusOK, project)
+}
+
+// @Summary Remove staff from a project
+// @Description Removes every assignment of the staff member to the project, or only the one in project_role_id when it is given. Only program staff and the project's advisors may change its staff.
+// @Tags Project
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param staff_id path int true "Staff ID"
+// @Param project_role_id query int false "Only remove this role"
+// @Param If-Match header string true "ETag of the project as last read, or *"
+// @Success 200 {object} dtos.ProjectData "Updated project"
+// @Header 200 {string} ETag "Project version"
+// @Failure 400 {object} map[string]interface{} "Invalid ID"
+// @Failure 403 {object} map[string]interface{} "Not program staff or an advisor of the project"
+// @Failure 404 {object} map[string]interface{} "Project not found or staff is not assigned"
+// @Failure 412 {object} map[string]interface{} "Project changed since it was read; the body holds the current project"
+// @Failure 428 {object} map[string]interface{} "Missing If-Match header"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/staffs/{staff_id} [delete]
+func (h *projectHandler) RemoveProjectStaff(c *gin.Context) {
+	id, staffId, ok := projectSubresourceIDs(c, "staff_id", "Invalid staff ID")
+	if !ok {
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var projectRoleId *int
+	if value := c.Query("project_role_id"); value != "" {
+		roleId, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project role ID"})
+			return
+		}
+		projectRoleId = &roleId
+	}
+
+	project, err := h.projectService.RemoveProjectStaff(c.Request.Context(), id, version, staffId, projectRoleId)
+	if err != nil {
+		writeProjectEditError(c, err)
+		return
+	}
+
+	writeProject(c, http.StatusOK, project)
+}
+
+// @Summary Add a keyword to a project
+// @Description Tags the project with a keyword of its program without changing the other keywords
+// @Tags Project
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param keyword body dtos.ProjectKeywordRequest true "Keyword to add"
+// @Param If-Match header string true "ETag of the project as last read, or *"
+// @Success 200 {object} dtos.ProjectData "Updated project"
+// @Header 200 {string} ETag "Project version"
+// @Failure 400 {object} map[string]interface{} "Invalid request or keyword of another program"
+// @Failure 403 {object} map[string]interface{} "Not program staff or a member of the project"
+// @Failure 404 {object} map[string]interface{} "Project or keyword not found"
+// @Failure 409 {object} map[string]interface{} "Project already has the keyword"
+// @Failure 412 {object} map[string]interface{} "Project changed since it was read; the body holds the current project"
+// @Failure 428 {object} map[string]interface{} "Missing If-Match header"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/keywords [post]
+func (h *projectHandler) AddProjectKeyword(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	req := &dtos.ProjectKeywordRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := h.projectService.AddProjectKeyword(c.Request.Context(), id, version, req.KeywordID)
+	if err != nil {
+		writeProjectEditError(c, err)
+		return
+	}
+
+	writeProject(c, http.StatusOK, project)
+}
+
+// @Summary Remove a keyword from a project
+// @Description Removes a single keyword from the project
+// @Tags Project
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param keyword_id path int true "Keyword ID"
+// @Param If-Match header string true "ETag of the project as last read, or *"
+// @Success 200 {object} dtos.ProjectData "Updated project"
+// @Header 200 {string} ETag "Project version"
+// @Failure 400 {object} map[string]interface{} "Invalid ID"
+// @Failure 403 {object} map[string]interface{} "Not program staff or a member of the project"
+// @Failure 404 {object} map[string]interface{} "Project not found or keyword is not on the project"
+// @Failure 412 {object} map[string]interface{} "Project changed since it was read; the body holds the current project"
+// @Failure 428 {object} map[string]interface{} "Missing If-Match header"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/keywords/{keyword_id} [delete]
+func (h *projectHandler) RemoveProjectKeyword(c *gin.Context) {
+	id, keywordId, ok := projectSubresourceIDs(c, "keyword_id", "Invalid keyword ID")
+	if !ok {
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	project, err := h.projectService.RemoveProjectKeyword(c.Request.Context(), id, version, keywordId)
+	if err != nil {
+		writeProjectEditError(c, err)
+		return
+	}
+
+	writeProject(c, http.StatusOK, project)
+}
+
+// @Summary Add a resource to a project
+// @Description Uploads a single file or adds a single link. When the title matches a current resource the new one becomes its next version; other resources are left untouched.
+// @Tags Project
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param title formData string true "Resource title"
+// @Param url formData string false "Link, when not uploading a file"
+// @Param file formData file false "File, when not adding a link"
+// @Param If-Match header string true "ETag of the project as last read, or *"
+// @Success 200 {object} dtos.ProjectData "Updated project"
+// @Header 200 {string} ETag "Project version"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Not program staff or a member of the project"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 412 {object} map[string]interface{} "Project changed since it was read; the body holds the current project"
+// @Failure 428 {object} map[string]interface{} "Missing If-Match header"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/resources [post]
+func (h *projectHandler) AddProjectResource(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	req := &dtos.AddProjectResourceRequest{}
+	if err := c.ShouldBind(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := h.projectService.AddProjectResource(c.Request.Context(), id, version, req)
+	if err != nil {
+		writeProjectEditError(c, err)
+		return
+	}
+
+	writeProject(c, http.StatusOK, project)
+}
+
+// @Summary Remove a resource from a project
+// @Description Takes a current resource off the project. Its files and version history are kept and it can be brought back with the restore endpoint.
+// @Tags Project
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Param resource_id path int true "Project resource ID"
+// @Param If-Match header string true "ETag of the project as last read, or *"
+// @Success 200 {object} dtos.ProjectData "Updated project"
+// @Header 200 {string} ETag "Project version"
+// @Failure 400 {object} map[string]interface{} "Invalid ID"
+// @Failure 403 {object} map[string]interface{} "Not program staff or a member of the project"
+// @Failure 404 {object} map[string]interface{} "Project not found or resource is not a current resource of it"
+// @Failure 412 {object} map[string]interface{} "Project changed since it was read; the body holds the current project"
+// @Failure 428 {object} map[string]interface{} "Missing If-Match header"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/resources/{resource_id} [delete]
+func (h *projectHandler) RetireProjectResource(c *gin.Context) {
+	id, resourceId, ok := projectSubresourceIDs(c, "resource_id", "Invalid resource ID")
+	if !ok {
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	project, err := h.projectService.RetireProjectResource(c.Request.Context(), id, version, resourceId)
+	if err != nil {
+		writeProjectEditError(c, err)
+		return
+	}
+
+	writeProject(c, http.StatusOK, project)
+}
+
+// projectSubresourceIDs parses the project ID and the ID of the sub-resource
+// named by param, writing a 400 response when either is invalid.
+func projectSubresourceIDs(c *gin.Context, param, invalidMessage string) (int, int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return 0, 0, false
+	}
+	subId, err := strconv.Atoi(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidMessage})
+		return 0, 0, false
+	}
+	return id, subId, true
+}
+
+// projectETag formats a project version as a strong entity tag.
+func projectETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+func writeProject(c *gin.Context, status int, project *dtos.ProjectData) {
+	c.Header("ETag", projectETag(project.Version))
+	c.JSON(status, project)
+}
+
+// ifMatchVersion reads the project version the client last saw from the
+// If-Match header; "*" matches any version and is returned as 0. It writes
+// the error response itself when the header is missing or malformed.
+func ifMatchVersion(c *gin.Context) (int, bool) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header with the project ETag is required"})
+		return 0, false
+	}
+	if value == "*" {
+		return 0, true
+	}
+
+	tag := strings.TrimPrefix(value, "W/")
+	if len(tag) >= 2 && tag[0] == '"' && tag[len(tag)-1] == '"' {
+		tag = tag[1 : len(tag)-1]
+	}
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "If-Match must be a single project ETag"})
+		return 0, false
+	}
+	return version, true
+}
+
+func writeProjectEditError(c *gin.Context, err error) {
+	var conflictErr *services.ProjectVersionConflictError
+	switch {
+	case errors.As(err, &conflictErr):
+		c.Header("ETag", projectETag(conflictErr.Current.Version))
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error(), "project": conflictErr.Current})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	case errors.Is(err, repositories.ErrReferenceNotFound),
+		errors.Is(err, repositories.ErrNotAssociated),
+		errors.Is(err, repositories.ErrUnknownProjectResource):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrEmptyProjectPatch),
+		errors.Is(err, services.ErrInvalidProjectResource),
+		errors.Is(err, repositories.ErrProgramMismatch):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrProjectForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrAlreadyAssociated), errors.Is(err, repositories.ErrProjectNotCompleted):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
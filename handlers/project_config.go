@@ -1,12 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/project-box/dtos"
 	"github.com/project-box/services"
+	"gorm.io/gorm"
 )
 
 type ProjectConfigHandler interface {
@@ -33,6 +35,7 @@ func NewProjectConfigHandler(projectConfigService services.ProjectConfigService)
 // @Failure 400 {object} map[string]interface{} "Invalid program ID"
 // @Failure 404 {object} map[string]interface{} "Program not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/projectConfigs/program/{program_id} [get]
 func (h *projectConfigHandler) GetProjectConfigByProgramId(c *gin.Context) {
 	programId, err := strconv.Atoi(c.Param("program_id"))
@@ -63,8 +66,10 @@ func (h *projectConfigHandler) GetProjectConfigByProgramId(c *gin.Context) {
 // @Param configs body []dtos.ProjectConfigUpsertRequest true "Configurations"
 // @Success 200 {object} map[string]interface{} "Successfully updated config"
 // @Failure 400 {object} map[string]interface{} "Invalid program ID"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
 // @Failure 404 {object} map[string]interface{} "Program not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/projectConfigs [put]
 func (h *projectConfigHandler) UpsertProjectConfig(c *gin.Context) {
 	var configs []dtos.ProjectConfigUpsertRequest
@@ -72,8 +77,26 @@ func (h *projectConfigHandler) UpsertProjectConfig(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	for _, config := range configs {
+		if !isProgramStaff(c, config.ProgramID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			return
+		}
+		if config.ID == 0 {
+			continue
+		}
+		existing, err := h.projectConfigService.GetProjectConfigByID(c.Request.Context(), config.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if existing != nil && !isProgramStaff(c, existing.ProgramID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			return
+		}
+	}
 
-	err := h.projectConfigService.UpsertProjectConfig(configs)
+	err := h.projectConfigService.UpsertProjectConfig(c.Request.Context(), configs)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/project-box/dtos"
 	"github.com/project-box/models"
 	"github.com/project-box/services"
+	"gorm.io/gorm"
 )
 
 type ProjectResourceConfigHandler interface {
@@ -35,6 +37,7 @@ func NewProjectResourceConfigHandler(projectResourceConfigService services.Proje
 // @Failure 400 {object} map[string]interface{} "Invalid program ID"
 // @Failure 404 {object} map[string]interface{} "No configurations found for the given program ID"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/projectResourceConfigs/program/{program_id} [get]
 func (h *projectResourceConfigHandler) GetProjectResourceConfigsByProgramId(c *gin.Context) {
 	programId, err := strconv.Atoi(c.Param("program_id"))
@@ -65,7 +68,9 @@ func (h *projectResourceConfigHandler) GetProjectResourceConfigsByProgramId(c *g
 // @Param configs body models.ProjectResourceConfig true "configuration to upsert"
 // @Success 200 {object} map[string]interface{} "Successfully upsert configurations"
 // @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/projectResourceConfigs [put]
 func (h *projectResourceConfigHandler) UpsertProjectResourceConfig(c *gin.Context) {
 	var projectResourceConfig models.ProjectResourceConfig
@@ -73,8 +78,11 @@ func (h *projectResourceConfigHandler) UpsertProjectResourceConfig(c *gin.Contex
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if !h.canWriteConfig(c, &projectResourceConfig) {
+		return
+	}
 
-	err := h.projectResourceConfigService.UpsertResourceProjectConfig(&projectResourceConfig)
+	err := h.projectResourceConfigService.UpsertResourceProjectConfig(c.Request.Context(), &projectResourceConfig)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -92,7 +100,9 @@ func (h *projectResourceConfigHandler) UpsertProjectResourceConfig(c *gin.Contex
 // @Param icon formData file false "Icon file"
 // @Success 200 {object} map[string]interface{} "Successfully upsert configurations"
 // @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v2/projectResourceConfigs [put]
 func (h *projectResourceConfigHandler) UpsertProjectResourceConfigV2(c *gin.Context) {
 	var req dtos.CreateProjectResourceConfigRequest
@@ -100,6 +110,13 @@ func (h *projectResourceConfigHandler) UpsertProjectResourceConfigV2(c *gin.Cont
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if req.ProjectResourceConfig == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if !h.canWriteConfig(c, req.ProjectResourceConfig) {
+		return
+	}
 
 	err := h.projectResourceConfigService.UpsertResourceProjectConfigV2(c.Request.Context(), req)
 	if err != nil {
@@ -109,3 +126,34 @@ func (h *projectResourceConfigHandler) UpsertProjectResourceConfigV2(c *gin.Cont
 
 	c.JSON(http.StatusOK, gin.H{"message": "Successfully upsert configurations"})
 }
+
+// canWriteConfig restricts an upsert to program staff of both the program in
+// the request and, when updating, the program the config belongs to. Configs
+// without a program are shared, so only admins may write them. It writes the
+// error response when the caller is not allowed.
+func (h *projectResourceConfigHandler) canWriteConfig(c *gin.Context, config *models.ProjectResourceConfig) bool {
+	if !isProgramStaff(c, resourceConfigProgramId(config)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return false
+	}
+	if config.ID == 0 {
+		return true
+	}
+	existing, err := h.projectResourceConfigService.GetProjectResourceConfigByID(c.Request.Context(), config.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if existing != nil && !isProgramStaff(c, resourceConfigProgramId(existing)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return false
+	}
+	return true
+}
+
+func resourceConfigProgramId(config *models.ProjectResourceConfig) int {
+	if config.ProgramID == nil {
+		return 0
+	}
+	return *config.ProgramID
+}
@@ -30,6 +30,7 @@ func NewProjectRoleHandler(projectRoleService services.ProjectRoleService) Proje
 // @Success 200 {array} models.ProjectRole "Successfully retrieved project roles"
 // @Failure 400 {object} map[string]interface{} "Invalid program ID"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/projectRoles/program/{program_id} [get]
 func (h *projectRoleHandler) GetByProgram(c *gin.Context) {
 	programIdStr := c.Param("program_id")
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/repositories"
+	"github.com/project-box/services"
+	"gorm.io/gorm"
+)
+
+type ProjectTrashHandler interface {
+	GetTrash(c *gin.Context)
+	RestoreProject(c *gin.Context)
+}
+
+type projectTrashHandler struct {
+	projectTrashService services.ProjectTrashService
+}
+
+func NewProjectTrashHandler(projectTrashService services.ProjectTrashService) ProjectTrashHandler {
+	return &projectTrashHandler{
+		projectTrashService: projectTrashService,
+	}
+}
+
+// @Summary List a program's deleted projects
+// @Description Lists the projects of the program that are in the trash, most recently deleted first, with the time each one will be purged for good
+// @Tags Project
+// @Produce  json
+// @Param id path int true "Program ID"
+// @Success 200 {array} dtos.TrashedProject "Deleted projects"
+// @Failure 400 {object} map[string]interface{} "Invalid program ID"
+// @Failure 403 {object} map[string]interface{} "Not program staff of the program"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/programs/{id}/trash [get]
+func (h *projectTrashHandler) GetTrash(c *gin.Context) {
+	programId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid program ID"})
+		return
+	}
+
+	trash, err := h.projectTrashService.GetTrash(c.Request.Context(), programId)
+	if err != nil {
+		writeProjectTrashError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trash)
+}
+
+// @Summary Restore a deleted project
+// @Description Brings a project back from the trash with its members, staff, keywords and resources, and returns it to search results
+// @Tags Project
+// @Produce  json
+// @Param id path int true "Project ID"
+// @Success 200 {object} dtos.ProjectData "Restored project"
+// @Header 200 {string} ETag "Project version"
+// @Failure 400 {object} map[string]interface{} "Invalid project ID"
+// @Failure 403 {object} map[string]interface{} "Not program staff of the project's program"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 409 {object} map[string]interface{} "Project is not in the trash"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/{id}/restore [post]
+func (h *projectTrashHandler) RestoreProject(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	project, err := h.projectTrashService.RestoreProject(c.Request.Context(), id)
+	if err != nil {
+		writeProjectTrashError(c, err)
+		return
+	}
+
+	writeProject(c, http.StatusOK, project)
+}
+
+func writeProjectTrashError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+	case errors.Is(err, services.ErrTrashForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrProjectNotDeleted):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/dtos"
+	"github.com/project-box/repositories"
+	"github.com/project-box/services"
+)
+
+type ProjectUploadHandler interface {
+	RequestUploads(c *gin.Context)
+	FinalizeProject(c *gin.Context)
+	StartResumableUpload(c *gin.Context)
+	GetResumableUpload(c *gin.Context)
+	WriteUploadChunk(c *gin.Context)
+}
+
+type projectUploadHandler struct {
+	projectUploadService services.ProjectUploadService
+}
+
+func NewProjectUploadHandler(projectUploadService services.ProjectUploadService) ProjectUploadHandler {
+	return &projectUploadHandler{
+		projectUploadService: projectUploadService,
+	}
+}
+
+// @Summary Request presigned upload URLs for project files
+// @Description Declares files with their size, content type and SHA-256 checksum and returns one presigned PUT URL per file. Upload each file directly to its URL, then create the project with POST /v1/projects/finalize.
+// @Tags Project
+// @Accept  json
+// @Produce  json
+// @Param request body dtos.ProjectUploadRequest true "Files to upload"
+// @Success 201 {object} dtos.ProjectUploadResponse "Upload URLs"
+// @Failure 400 {object} map[string]interface{} "Invalid request or unsupported file"
+// @Failure 403 {object} map[string]interface{} "Not signed in"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/uploads [post]
+func (h *projectUploadHandler) RequestUploads(c *gin.Context) {
+	req := &dtos.ProjectUploadRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.projectUploadService.RequestUploads(c.Request.Context(), req)
+	if err != nil {
+		writeProjectUploadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Summary Create a project from uploaded files
+// @Description Creates a project whose file resources were uploaded with presigned URLs. Each upload is checked against its declared size, checksum and content type before it is attached.
+// @Tags Project
+// @Accept  json
+// @Produce  json
+// @Param request body dtos.FinalizeProjectRequest true "Project and resources"
+// @Success 201 {object} dtos.ProjectData "Successfully created project"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Not signed in, or no role in the project's program"
+// @Failure 404 {object} map[string]interface{} "Upload not found"
+// @Failure 409 {object} map[string]interface{} "Upload already attached to a project"
+// @Failure 410 {object} map[string]interface{} "Upload expired"
+// @Failure 422 {object} map[string]interface{} "Uploaded file does not match its declaration"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/finalize [post]
+func (h *projectUploadHandler) FinalizeProject(c *gin.Context) {
+	req := &dtos.FinalizeProjectRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := h.projectUploadService.FinalizeProject(c.Request.Context(), req)
+	if err != nil {
+		writeProjectUploadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, project)
+}
+
+// @Summary Start a resumable upload
+// @Description Declares a file that will be sent in chunks with PATCH /v1/projects/uploads/{id}. An interrupted upload is resumed by asking for its status and sending the next chunk from the returned offset. A complete upload is attached with POST /v1/projects/finalize.
+// @Tags Project
+// @Accept  json
+// @Produce  json
+// @Param request body dtos.ProjectUploadFile true "File to upload"
+// @Success 201 {object} dtos.ResumableUploadStatus "Upload status"
+// @Failure 400 {object} map[string]interface{} "Invalid request or unsupported file"
+// @Failure 403 {object} map[string]interface{} "Not signed in"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/uploads/resumable [post]
+func (h *projectUploadHandler) StartResumableUpload(c *gin.Context) {
+	req := &dtos.ProjectUploadFile{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status, err := h.projectUploadService.StartResumableUpload(c.Request.Context(), req)
+	if err != nil {
+		writeProjectUploadError(c, err)
+		return
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(status.Offset, 10))
+	c.JSON(http.StatusCreated, status)
+}
+
+// @Summary Get the status of a resumable upload
+// @Description Returns how many bytes have been received, i.e. the offset the next chunk must start at.
+// @Tags Project
+// @Produce  json
+// @Param id path int true "Upload ID"
+// @Success 200 {object} dtos.ResumableUploadStatus "Upload status"
+// @Failure 400 {object} map[string]interface{} "Invalid upload ID"
+// @Failure 404 {object} map[string]interface{} "Upload not found"
+// @Failure 410 {object} map[string]interface{} "Upload expired"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/uploads/{id} [get]
+func (h *projectUploadHandler) GetResumableUpload(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid upload ID"})
+		return
+	}
+
+	status, err := h.projectUploadService.GetResumableUpload(c.Request.Context(), id)
+	if err != nil {
+		writeProjectUploadError(c, err)
+		return
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(status.Offset, 10))
+	c.JSON(http.StatusOK, status)
+}
+
+// @Summary Upload a chunk of a resumable upload
+// @Description Appends the raw request body at the offset given in the Upload-Offset header, which must equal the number of bytes received so far. The request needs a Content-Length. The final chunk assembles the file.
+// @Tags Project
+// @Accept  application/octet-stream
+// @Produce  json
+// @Param id path int true "Upload ID"
+// @Param Upload-Offset header int true "Offset of the first byte of this chunk"
+// @Param chunk body string true "Chunk bytes"
+// @Success 200 {object} dtos.ResumableUploadStatus "Upload status"
+// @Failure 400 {object} map[string]interface{} "Invalid upload ID, offset or chunk size"
+// @Failure 404 {object} map[string]interface{} "Upload not found"
+// @Failure 409 {object} map[string]interface{} "Offset does not match, or another chunk is being written"
+// @Failure 410 {object} map[string]interface{} "Upload expired"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projects/uploads/{id} [patch]
+func (h *projectUploadHandler) WriteUploadChunk(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid upload ID"})
+		return
+	}
+
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Upload-Offset header"})
+		return
+	}
+	if c.Request.ContentLength < 0 {
+		c.JSON(http.StatusLengthRequired, gin.H{"error": "Content-Length is required"})
+		return
+	}
+
+	status, err := h.projectUploadService.WriteUploadChunk(c.Request.Context(), id, offset, c.Request.Body, c.Request.ContentLength)
+	if err != nil {
+		writeProjectUploadError(c, err)
+		return
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(status.Offset, 10))
+	c.JSON(http.StatusOK, status)
+}
+
+func writeProjectUploadError(c *gin.Context, err error) {
+	var offsetErr *services.UploadOffsetError
+	switch {
+	case errors.As(err, &offsetErr):
+		c.Header("Upload-Offset", strconv.FormatInt(offsetErr.Offset, 10))
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "offset": offsetErr.Offset})
+	case errors.Is(err, services.ErrInvalidUpload), errors.Is(err, repositories.ErrProjectNotCompleted):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrUploadForbidden), errors.Is(err, services.ErrProjectForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrUploadNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrPendingUploadConsumed), errors.Is(err, repositories.ErrPendingUploadBusy):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrUploadExpired):
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrUploadMismatch):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
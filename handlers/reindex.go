@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/dtos"
+	"github.com/project-box/services"
+)
+
+type ReindexHandler interface {
+	StartReindex(c *gin.Context)
+	GetReindexStatus(c *gin.Context)
+}
+
+type reindexHandler struct {
+	reindexService services.ReindexService
+}
+
+func NewReindexHandler(reindexService services.ReindexService) ReindexHandler {
+	return &reindexHandler{
+		reindexService: reindexService,
+	}
+}
+
+// @Summary Start a search reindex
+// @Description Publishes a reindex operation for every project, optionally limited to a program or academic year
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body dtos.ReindexRequest false "Reindex scope and rate"
+// @Success 202 {object} dtos.ReindexStatus
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 409 {object} map[string]interface{} "A reindex is already running"
+// @Security BearerAuth
+// @Router /v1/admin/reindex [post]
+func (h *reindexHandler) StartReindex(c *gin.Context) {
+	var request dtos.ReindexRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if request.RatePerSecond < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "rate_per_second must not be negative"})
+		return
+	}
+
+	status, err := h.reindexService.StartReindex(request)
+	if err != nil {
+		if errors.Is(err, services.ErrReindexRunning) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, status)
+}
+
+// @Summary Get search reindex progress
+// @Description Returns the progress of the latest reindex
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} dtos.ReindexStatus
+// @Failure 404 {object} map[string]interface{} "No reindex has been started"
+// @Security BearerAuth
+// @Router /v1/admin/reindex [get]
+func (h *reindexHandler) GetReindexStatus(c *gin.Context) {
+	status, err := h.reindexService.GetReindexStatus()
+	if err != nil {
+		if errors.Is(err, services.ErrReindexNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
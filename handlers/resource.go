@@ -1,28 +1,36 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"os"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"github.com/minio/minio-go/v7"
+	storage "github.com/project-box/db/storage"
+	"github.com/project-box/dtos"
 	"github.com/project-box/services"
+	"github.com/project-box/utils"
+	"gorm.io/gorm"
 )
 
 type ResourceHandler interface {
 	DeleteProjectResource(c *gin.Context)
+	DownloadProjectResource(c *gin.Context)
+	GetProjectResourceVersions(c *gin.Context)
+	RestoreProjectResourceVersion(c *gin.Context)
 }
 
 type resourceHandler struct {
-	minioClient     *minio.Client
+	storage         storage.ObjectStorage
 	bucketName      string
 	resourceService services.ResourceService
 	projectService  services.ProjectService
 }
 
-func NewResourceHandler(minioClient *minio.Client, resourceService services.ResourceService, projectService services.ProjectService) ResourceHandler {
+func NewResourceHandler(objectStorage storage.ObjectStorage, resourceService services.ResourceService, projectService services.ProjectService) ResourceHandler {
 	return &resourceHandler{
-		minioClient:     minioClient,
+		storage:         objectStorage,
 		bucketName:      os.Getenv("MINIO_BUCKET"),
 		resourceService: resourceService,
 		projectService:  projectService,
@@ -30,33 +38,184 @@ func NewResourceHandler(minioClient *minio.Client, resourceService services.Reso
 }
 
 // @Summary Delete a project resource
-// @Description Deletes a project resource by its ID
+// @Description Deletes one version of a project resource and its file. Deleting the current version makes the newest remaining version current.
 // @Tags Resource
 // @Produce json
-// @Param id path string true "Resource ID"
+// @Param id path int true "Resource ID"
+// @Param If-Match header string true "ETag of the resource's project as last read, or *"
 // @Success 200 {object} map[string]interface{} "Project Resource deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid resource ID"
+// @Failure 403 {object} map[string]interface{} "You do not have access to this resource"
 // @Failure 404 {object} map[string]interface{} "Resource not found"
+// @Failure 412 {object} map[string]interface{} "Project changed since it was read; the body holds the current project"
+// @Failure 428 {object} map[string]interface{} "Missing If-Match header"
 // @Failure 500 {object} map[string]interface{} "Failed to delete resource record"
+// @Security BearerAuth
 // @Router /v1/projectResources/{id} [delete]
 func (h *resourceHandler) DeleteProjectResource(c *gin.Context) {
-	id := c.Param("id")
-	detailedResource, err := h.resourceService.GetDetailedResourceByID(c, id)
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Resource not found"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource ID"})
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
 		return
 	}
 
-	filePath := detailedResource.ProjectResource.Path
-	if err := h.resourceService.DeleteProjectResourceByID(c, id, filePath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete resource record"})
+	if err := h.resourceService.DeleteProjectResourceByID(c.Request.Context(), id, version); err != nil {
+		writeResourceError(c, err)
 		return
 	}
 
-	err = h.projectService.PublishProjectMessageToElasticSearch(c, "update", detailedResource.Project.ID)
+	c.JSON(http.StatusOK, gin.H{"message": "Project Resource deleted successfully"})
+}
+
+// @Summary Download a project resource
+// @Description Redirects to a short-lived signed URL for the resource file, or streams it when mode=stream. Files of private projects are only available to program staff and project members.
+// @Tags Resource
+// @Produce octet-stream
+// @Param id path int true "Resource ID"
+// @Param mode query string false "redirect (default) or stream"
+// @Param inline query bool false "Ask the browser to display the file instead of saving it"
+// @Success 200 {file} file "Resource file"
+// @Success 302 "Redirect to a signed download URL"
+// @Failure 400 {object} map[string]interface{} "Invalid resource ID or mode"
+// @Failure 403 {object} map[string]interface{} "You do not have access to this resource"
+// @Failure 404 {object} map[string]interface{} "Resource not found"
+// @Failure 500 {object} map[string]interface{} "Failed to download resource"
+// @Security BearerAuth
+// @Router /v1/projectResources/{id}/download [get]
+func (h *resourceHandler) DownloadProjectResource(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Failed to publish project message to ElasticSearch"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource ID"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Project Resource deleted successfully"})
+	mode := c.DefaultQuery("mode", "redirect")
+	if mode != "redirect" && mode != "stream" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mode must be redirect or stream"})
+		return
+	}
+	inline := c.Query("inline") == "true"
+
+	download, err := h.resourceService.GetResourceDownload(c.Request.Context(), id)
+	if err != nil {
+		writeResourceError(c, err)
+		return
+	}
+
+	// Signed links must not outlive their TTL in a shared cache.
+	c.Header("Cache-Control", "private, no-store")
+
+	if mode == "redirect" {
+		signedURL, err := h.resourceService.PresignResourceDownload(c.Request.Context(), download, inline)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to download resource"})
+			return
+		}
+		c.Redirect(http.StatusFound, signedURL.String())
+		return
+	}
+
+	object, info, err := h.resourceService.OpenResourceDownload(c.Request.Context(), download)
+	if err != nil {
+		writeResourceError(c, err)
+		return
+	}
+	defer object.Close()
+
+	if info.ContentType != "" {
+		c.Header("Content-Type", info.ContentType)
+	}
+	if info.ETag != "" {
+		c.Header("ETag", strconv.Quote(info.ETag))
+	}
+	c.Header("Content-Disposition", services.ContentDisposition(inline, download.FileName))
+	http.ServeContent(c.Writer, c.Request, download.FileName, info.LastModified, object)
+}
+
+// @Summary List the versions of a project resource
+// @Description Returns every version of the resource's title within its project, newest first. Any version ID may be used.
+// @Tags Resource
+// @Produce json
+// @Param id path int true "Resource ID"
+// @Success 200 {array} dtos.ProjectResource "Versions"
+// @Failure 400 {object} map[string]interface{} "Invalid resource ID"
+// @Failure 403 {object} map[string]interface{} "You do not have access to this resource"
+// @Failure 404 {object} map[string]interface{} "Resource not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projectResources/{id}/versions [get]
+func (h *resourceHandler) GetProjectResourceVersions(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource ID"})
+		return
+	}
+
+	versions, err := h.resourceService.GetResourceVersions(c.Request.Context(), id)
+	if err != nil {
+		writeResourceError(c, err)
+		return
+	}
+
+	response := make([]dtos.ProjectResource, 0, len(versions))
+	for _, version := range versions {
+		response = append(response, utils.SanitizeProjectResource(version))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Restore a version of a project resource
+// @Description Makes the given version the current version of its title.
+// @Tags Resource
+// @Produce json
+// @Param id path int true "Resource version ID"
+// @Param If-Match header string true "ETag of the resource's project as last read, or *"
+// @Success 200 {object} dtos.ProjectResource "Restored version"
+// @Failure 400 {object} map[string]interface{} "Invalid resource ID"
+// @Failure 403 {object} map[string]interface{} "You do not have access to this resource"
+// @Failure 404 {object} map[string]interface{} "Resource not found"
+// @Failure 412 {object} map[string]interface{} "Project changed since it was read; the body holds the current project"
+// @Failure 428 {object} map[string]interface{} "Missing If-Match header"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/projectResources/{id}/restore [post]
+func (h *resourceHandler) RestoreProjectResourceVersion(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource ID"})
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	restored, err := h.resourceService.RestoreResourceVersion(c.Request.Context(), id, version)
+	if err != nil {
+		writeResourceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SanitizeProjectResource(*restored))
+}
+
+func writeResourceError(c *gin.Context, err error) {
+	var conflictErr *services.ProjectVersionConflictError
+	switch {
+	case errors.As(err, &conflictErr):
+		c.Header("ETag", projectETag(conflictErr.Current.Version))
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error(), "project": conflictErr.Current})
+	case errors.Is(err, services.ErrResourceNotFound), errors.Is(err, services.ErrResourceHasNoFile):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrResourceForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Resource not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
 }
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/dtos"
+	"github.com/project-box/services"
+	"gorm.io/gorm"
+)
+
+type RubricHandler interface {
+	GetRubrics(c *gin.Context)
+	GetRubric(c *gin.Context)
+	CreateRubric(c *gin.Context)
+	UpdateRubric(c *gin.Context)
+	DeleteRubric(c *gin.Context)
+}
+
+type rubricHandler struct {
+	rubricService services.RubricService
+}
+
+func NewRubricHandler(rubricService services.RubricService) RubricHandler {
+	return &rubricHandler{
+		rubricService: rubricService,
+	}
+}
+
+// @Summary List rubrics
+// @Description Lists the grading rubrics of a program with their criteria and role weights
+// @Tags Rubric
+// @Produce json
+// @Param program_id query int true "Program ID"
+// @Success 200 {array} models.Rubric
+// @Failure 400 {object} map[string]interface{} "Invalid program ID"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/rubrics [get]
+func (h *rubricHandler) GetRubrics(c *gin.Context) {
+	programId, err := strconv.Atoi(c.Query("program_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid program ID"})
+		return
+	}
+
+	rubrics, err := h.rubricService.GetRubricsByProgramId(c.Request.Context(), programId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rubrics)
+}
+
+// @Summary Get a rubric
+// @Description Fetches a rubric with its criteria and role weights
+// @Tags Rubric
+// @Produce json
+// @Param id path int true "Rubric ID"
+// @Success 200 {object} models.Rubric
+// @Failure 400 {object} map[string]interface{} "Invalid rubric ID"
+// @Failure 404 {object} map[string]interface{} "Rubric not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/rubrics/{id} [get]
+func (h *rubricHandler) GetRubric(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rubric ID"})
+		return
+	}
+
+	rubric, err := h.rubricService.GetRubricByID(c.Request.Context(), id)
+	if err != nil {
+		writeRubricError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rubric)
+}
+
+// @Summary Create a rubric
+// @Description Creates a grading rubric with weighted criteria and per-role weights
+// @Tags Rubric
+// @Accept json
+// @Produce json
+// @Param rubric body dtos.RubricRequest true "Rubric"
+// @Success 201 {object} models.Rubric
+// @Failure 400 {object} map[string]interface{} "Invalid rubric"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/rubrics [post]
+func (h *rubricHandler) CreateRubric(c *gin.Context) {
+	req := &dtos.RubricRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !isProgramStaff(c, req.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	rubric, err := h.rubricService.CreateRubric(c.Request.Context(), req)
+	if err != nil {
+		writeRubricError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rubric)
+}
+
+// @Summary Update a rubric
+// @Description Replaces a rubric's criteria and role weights. Once evaluations exist, criteria can only be renamed or reweighted.
+// @Tags Rubric
+// @Accept json
+// @Produce json
+// @Param id path int true "Rubric ID"
+// @Param rubric body dtos.RubricRequest true "Rubric"
+// @Success 200 {object} models.Rubric
+// @Failure 400 {object} map[string]interface{} "Invalid rubric"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Rubric not found"
+// @Failure 409 {object} map[string]interface{} "Rubric already has evaluations"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/rubrics/{id} [put]
+func (h *rubricHandler) UpdateRubric(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rubric ID"})
+		return
+	}
+
+	req := &dtos.RubricRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	existing, err := h.rubricService.GetRubricByID(c.Request.Context(), id)
+	if err != nil {
+		writeRubricError(c, err)
+		return
+	}
+	if !isProgramStaff(c, existing.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	rubric, err := h.rubricService.UpdateRubric(c.Request.Context(), id, req)
+	if err != nil {
+		writeRubricError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rubric)
+}
+
+// @Summary Delete a rubric
+// @Description Deletes a rubric that has no evaluations
+// @Tags Rubric
+// @Produce json
+// @Param id path int true "Rubric ID"
+// @Success 200 {object} map[string]interface{} "Rubric deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid rubric ID"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Rubric not found"
+// @Failure 409 {object} map[string]interface{} "Rubric already has evaluations"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/rubrics/{id} [delete]
+func (h *rubricHandler) DeleteRubric(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rubric ID"})
+		return
+	}
+
+	existing, err := h.rubricService.GetRubricByID(c.Request.Context(), id)
+	if err != nil {
+		writeRubricError(c, err)
+		return
+	}
+	if !isProgramStaff(c, existing.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	if err := h.rubricService.DeleteRubric(c.Request.Context(), id); err != nil {
+		writeRubricError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Rubric deleted successfully"})
+}
+
+func writeRubricError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Rubric not found"})
+	case errors.Is(err, services.ErrInvalidRubric):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrRubricInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
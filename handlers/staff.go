@@ -38,6 +38,7 @@ func NewStaffHandler(staffService services.StaffService) StaffHandler {
 // @Success 200 {object} dtos.StaffResponse "Successfully retrieved staff"
 // @Failure 400 {object} map[string]interface{} "Invalid email"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/staffs/email/{email} [get]
 func (h *staffHandler) GetStaffByEmail(c *gin.Context) {
 	email := c.Param("email")
@@ -57,6 +58,7 @@ func (h *staffHandler) GetStaffByEmail(c *gin.Context) {
 // @Success 200 {object} dtos.StaffResponse "Successfully retrieved staff"
 // @Failure 400 {object} map[string]interface{} "Invalid staff ID"
 // @Failure 404 {object} map[string]interface{} "Staff not found"
+// @Security BearerAuth
 // @Router /v1/staffs/{id} [get]
 func (h *staffHandler) GetStaffById(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -64,7 +66,7 @@ func (h *staffHandler) GetStaffById(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid staff ID"})
 		return
 	}
-	staff, err := h.staffService.GetStaffById(c, id)
+	staff, err := h.staffService.GetStaffById(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Staff not found"})
 		return
@@ -109,6 +111,7 @@ func groupStaffsByEmail(staffs []dtos.StaffResponse) []dtos.StaffResponseWithPro
 // @Success 200 {object} []dtos.StaffResponse "Successfully retrieved staffs"
 // @Failure 400 {object} map[string]interface{} "Invalid program ID"
 // @Failure 404 {object} map[string]interface{} "Staffs not found"
+// @Security BearerAuth
 // @Router /v1/staffs/program/{program_id} [get]
 func (h *staffHandler) GetStaffByProgramId(c *gin.Context) {
 	programId, err := strconv.Atoi(c.Param("program_id"))
@@ -117,7 +120,7 @@ func (h *staffHandler) GetStaffByProgramId(c *gin.Context) {
 		return
 	}
 
-	staffs, err := h.staffService.GetStaffByProgramId(c, programId)
+	staffs, err := h.staffService.GetStaffByProgramId(c.Request.Context(), programId)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Staffs not found"})
 		return
@@ -135,7 +138,9 @@ func (h *staffHandler) GetStaffByProgramId(c *gin.Context) {
 // @Param staff body dtos.CreateStaffRequest true "Staff Data"
 // @Success 201 {object} dtos.StaffResponse "Successfully created staff"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/staffs [post]
 func (h *staffHandler) CreateStaff(c *gin.Context) {
 	req := &dtos.CreateStaffRequest{}
@@ -143,7 +148,11 @@ func (h *staffHandler) CreateStaff(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	staff, err := h.staffService.CreateStaff(c, req)
+	if !isProgramStaff(c, req.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+	staff, err := h.staffService.CreateStaff(c.Request.Context(), req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -159,7 +168,10 @@ func (h *staffHandler) CreateStaff(c *gin.Context) {
 // @Param staff body dtos.UpdateStaffRequest true "Updated Staff Data"
 // @Success 200 {object} dtos.StaffResponse "Successfully updated staff"
 // @Failure 400 {object} map[string]interface{} "Invalid staff ID or request"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Staff not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/staffs [put]
 func (h *staffHandler) UpdateStaff(c *gin.Context) {
 	staff := &dtos.UpdateStaffRequest{}
@@ -167,8 +179,18 @@ func (h *staffHandler) UpdateStaff(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	existing, err := h.staffService.GetStaffById(c.Request.Context(), staff.ID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Staff not found"})
+		return
+	}
+	// A zero program ID leaves the staff in its current program.
+	if !isProgramStaff(c, existing.ProgramID) || (staff.ProgramID != 0 && !isProgramStaff(c, staff.ProgramID)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
 
-	updatedStaff, err := h.staffService.UpdateStaff(c, staff)
+	updatedStaff, err := h.staffService.UpdateStaff(c.Request.Context(), staff)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -183,9 +205,10 @@ func (h *staffHandler) UpdateStaff(c *gin.Context) {
 // @Produce  json
 // @Success 200 {object} []dtos.StaffResponse "Successfully retrieved staffs"
 // @Failure 404 {object} map[string]interface{} "Staffs not found"
+// @Security BearerAuth
 // @Router /v1/staffs/GetAllStaffs [get]
 func (h *staffHandler) GetAllStaff(c *gin.Context) {
-	staffs, err := h.staffService.GetAllStaff(c)
+	staffs, err := h.staffService.GetAllStaff(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Staffs not found"})
 		return
@@ -202,6 +225,7 @@ func (h *staffHandler) GetAllStaff(c *gin.Context) {
 // @Success 200 {object} []models.Staff "Successfully retrieved staffs"
 // @Failure 400 {object} map[string]interface{} "Invalid program ID"
 // @Failure 404 {object} map[string]interface{} "Staffs not found"
+// @Security BearerAuth
 // @Router /v2/staffs/program/{program_id} [get]
 func (h *staffHandler) GetStaffByProgramIdV2(c *gin.Context) {
 	programIdStr := c.Param("program_id")
@@ -35,6 +35,7 @@ func NewStudentHandler(studentService services.StudentService) StudentHandler {
 // @Success 200 {array} models.Student "Successfully retrieved students"
 // @Failure 400 {object} map[string]interface{} "Invalid program ID"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/students/{student_id} [get]
 func (h *studentHandler) GetStudentByStudentId(c *gin.Context) {
 	studentId := c.Param("student_id")
@@ -55,6 +56,7 @@ func (h *studentHandler) GetStudentByStudentId(c *gin.Context) {
 // @Success 200 {object} map[string]interface{} "Successfully checked permission"
 // @Failure 400 {object} map[string]interface{} "Invalid student ID"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/students/{student_id}/check [get]
 func (h *studentHandler) CheckStudentPermissionForCreateProject(c *gin.Context) {
 	studentId := c.Param("student_id")
@@ -88,6 +90,7 @@ func (h *studentHandler) CheckStudentPermissionForCreateProject(c *gin.Context)
 // @Success 200 {array} models.Student "Successfully retrieved students"
 // @Failure 400 {object} map[string]interface{} "Invalid parameters"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/students/program/{program_id}/current_year [get]
 func (h *studentHandler) GetsStudentByProgramIdOnCurrentYearAndSemester(c *gin.Context) {
 	programIdStr := c.Param("program_id")
@@ -116,6 +119,7 @@ func (h *studentHandler) GetsStudentByProgramIdOnCurrentYearAndSemester(c *gin.C
 // @Success 200 {array} models.Student "Successfully retrieved students"
 // @Failure 400 {object} map[string]interface{} "Invalid parameters"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/students/program/{program_id} [get]
 func (h *studentHandler) GetsStudentByProgramIdOnAcademicYearAndSemester(c *gin.Context) {
 	programIdStr := c.Param("program_id")
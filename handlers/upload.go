@@ -1,124 +1,213 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/project-box/middlewares"
+	"github.com/project-box/models"
+	"github.com/project-box/repositories"
 	"github.com/project-box/services"
+	"gorm.io/gorm"
 )
 
 type UploadHandler interface {
 	UploadStudentEnrollmentFile(c *gin.Context)
 	UploadCreateProjectFile(c *gin.Context)
 	UploadCreateStaffFile(c *gin.Context)
+	GetImportJob(c *gin.Context)
+	RetryImportJob(c *gin.Context)
 }
 
 type uploadHandler struct {
-	uploadService services.UploadService
+	importJobService services.ImportJobService
 }
 
-func NewUploadHandler(uploadService services.UploadService) UploadHandler {
+func NewUploadHandler(importJobService services.ImportJobService) UploadHandler {
 	return &uploadHandler{
-		uploadService: uploadService,
+		importJobService: importJobService,
 	}
 }
 
 // @Summary Upload student enrollment file
-// @Description Uploads and processes a student enrollment file for a given program ID
+// @Description Queues a student enrollment file of a given program ID for background processing
 // @Tags Upload
 // @Accept multipart/form-data
 // @Produce json
 // @Param program_id path int true "Program ID"
 // @Param file formData file true "Student Enrollment File"
-// @Success 200 {object} map[string]interface{} "file processed successfully"
+// @Param dry_run query bool false "Validate the file and return the report without saving"
+// @Success 202 {object} dtos.ImportJob
 // @Failure 400 {object} map[string]interface{} "Invalid program ID or failed to retrieve the file"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
 // @Router /v1/uploads/program/{program_id}/student-enrollment [post]
 func (h *uploadHandler) UploadStudentEnrollmentFile(c *gin.Context) {
-	programId, err := strconv.Atoi(c.Param("program_id"))
+	h.createImportJob(c, models.ImportJobTypeStudentEnrollment)
+}
+
+// @Summary Upload create project file
+// @Description Queues a create project file of a given program ID for background processing
+// @Tags Upload
+// @Accept multipart/form-data
+// @Produce json
+// @Param program_id path int true "Program ID"
+// @Param file formData file true "Create Project File"
+// @Param dry_run query bool false "Validate the file and return the report without saving"
+// @Success 202 {object} dtos.ImportJob
+// @Failure 400 {object} map[string]interface{} "Invalid program ID or failed to retrieve the file"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/uploads/program/{program_id}/create-project [post]
+func (h *uploadHandler) UploadCreateProjectFile(c *gin.Context) {
+	h.createImportJob(c, models.ImportJobTypeCreateProject)
+}
+
+// @Summary Upload create staff file
+// @Description Queues a create staff file of a given program ID for background processing
+// @Tags Upload
+// @Accept multipart/form-data
+// @Produce json
+// @Param program_id path int true "Program ID"
+// @Param file formData file true "Create Staff File"
+// @Param dry_run query bool false "Validate the file and return the report without saving"
+// @Success 202 {object} dtos.ImportJob
+// @Failure 400 {object} map[string]interface{} "Invalid program ID or failed to retrieve the file"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/uploads/program/{program_id}/create-staff [post]
+func (h *uploadHandler) UploadCreateStaffFile(c *gin.Context) {
+	h.createImportJob(c, models.ImportJobTypeCreateStaff)
+}
+
+// @Summary Get import job
+// @Description Returns the status, progress, row errors and created entity IDs of an import job
+// @Tags Upload
+// @Produce json
+// @Param id path int true "Import job ID"
+// @Success 200 {object} dtos.ImportJob
+// @Failure 400 {object} map[string]interface{} "Invalid import job ID"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Import job not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /v1/uploads/jobs/{id} [get]
+func (h *uploadHandler) GetImportJob(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid program ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import job ID"})
 		return
 	}
 
-	file, err := c.FormFile("file")
+	job, err := h.importJobService.GetImportJob(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to retrieve the file"})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Import job not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	err = h.uploadService.ProcessStudentEnrollmentFile(c.Request.Context(), programId, file)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !isProgramStaff(c, job.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "file processed successfully"})
+	c.JSON(http.StatusOK, job)
 }
 
-// @Summary Upload create project file
-// @Description Uploads and processes a create project file for a given program ID
+// @Summary Retry import job
+// @Description Puts a failed import job back in the queue
 // @Tags Upload
-// @Accept multipart/form-data
 // @Produce json
-// @Param program_id path int true "Program ID"
-// @Param file formData file true "Create Project File"
-// @Success 200 {object} map[string]interface{} "file processed successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid program ID or failed to retrieve the file"
+// @Param id path int true "Import job ID"
+// @Success 202 {object} dtos.ImportJob
+// @Failure 400 {object} map[string]interface{} "Invalid import job ID"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Import job not found"
+// @Failure 409 {object} map[string]interface{} "Import job is not failed"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
-// @Router /v1/uploads/program/{program_id}/create-project [post]
-func (h *uploadHandler) UploadCreateProjectFile(c *gin.Context) {
-	programId, err := strconv.Atoi(c.Param("program_id"))
+// @Security BearerAuth
+// @Router /v1/uploads/jobs/{id}/retry [post]
+func (h *uploadHandler) RetryImportJob(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid program ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import job ID"})
 		return
 	}
 
-	file, err := c.FormFile("file")
+	job, err := h.importJobService.GetImportJob(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to retrieve the file"})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Import job not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	err = h.uploadService.ProcessCreateProjectFile(c.Request.Context(), programId, file)
+	if !isProgramStaff(c, job.ProgramID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	job, err = h.importJobService.RetryImportJob(c.Request.Context(), id)
 	if err != nil {
+		if errors.Is(err, repositories.ErrImportJobNotRetryable) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "file processed successfully"})
+	c.JSON(http.StatusAccepted, job)
 }
 
-// @Summary Upload create staff file
-// @Description Uploads and processes a create staff file for a given program ID
-// @Tags Upload
-// @Accept multipart/form-data
-// @Produce json
-// @Param program_id path int true "Program ID"
-// @Param file formData file true "Create Staff File"
-// @Success 200 {object} map[string]interface{} "file processed successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid program ID or failed to retrieve the file"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
-// @Router /v1/uploads/program/{program_id}/create-staff [post]
-func (h *uploadHandler) UploadCreateStaffFile(c *gin.Context) {
+func (h *uploadHandler) createImportJob(c *gin.Context, jobType string) {
 	programId, err := strconv.Atoi(c.Param("program_id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid program ID"})
 		return
 	}
 
+	dryRun, err := parseDryRun(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dry_run value"})
+		return
+	}
+
 	file, err := c.FormFile("file")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to retrieve the file"})
 		return
 	}
 
-	err = h.uploadService.ProcessCreateStaffFile(c.Request.Context(), programId, file)
+	var createdBy string
+	if principal, ok := middlewares.GetPrincipal(c); ok {
+		createdBy = principal.Subject
+	}
+
+	job, err := h.importJobService.CreateImportJob(c.Request.Context(), jobType, programId, file, dryRun, createdBy)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidImportFile) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "file processed successfully"})
+	c.JSON(http.StatusAccepted, job)
+}
+
+func parseDryRun(c *gin.Context) (bool, error) {
+	value := c.Query("dry_run")
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
 }
@@ -0,0 +1,59 @@
+// Package logging builds the structured logger of the service and carries
+// the request ID through context.Context so every log line, query and
+// published message of a request can be correlated.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/project-box/configs"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewLogger builds the logger configured by LOG_LEVEL and LOG_FORMAT and
+// makes it the default, so code without an injected logger and the standard
+// log package write through it too.
+func NewLogger() *slog.Logger {
+	config := configs.GetLogConfig()
+	options := &slog.HandlerOptions{Level: config.Level}
+
+	var handler slog.Handler
+	if config.Format == configs.LogFormatText {
+		handler = slog.NewTextHandler(os.Stdout, options)
+	} else {
+		handler = slog.NewJSONHandler(os.Stdout, options)
+	}
+
+	logger := slog.New(&contextHandler{Handler: handler})
+	slog.SetDefault(logger)
+	return logger
+}
+
+// contextHandler adds the request ID and the trace and span IDs found in the
+// context of each record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestId := RequestIDFromContext(ctx); requestId != "" {
+		record.AddAttrs(slog.String("request_id", requestId))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
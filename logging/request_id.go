@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RequestIDHeader carries the request ID on HTTP requests and responses.
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDMessageHeader carries the request ID on RabbitMQ messages.
+	RequestIDMessageHeader = "x-request-id"
+
+	maxRequestIDLength = 128
+)
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying requestId.
+func WithRequestID(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestId)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or "" if there
+// is none.
+func RequestIDFromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIDKey{}).(string)
+	return requestId
+}
+
+// NewRequestID generates a request ID for requests that did not bring one.
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// ValidRequestID reports whether a client-supplied ID is safe to log and
+// forward: non-empty, bounded in length and printable ASCII only.
+func ValidRequestID(requestId string) bool {
+	if requestId == "" || len(requestId) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestId); i++ {
+		if requestId[i] < 0x21 || requestId[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,8 +13,11 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/project-box/configs"
+	storage "github.com/project-box/db/storage"
 	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
 	"github.com/project-box/routers"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func NewApp(
@@ -26,18 +32,34 @@ func NewApp(
 	programHandler handlers.ProgramHandler,
 	studentHandler handlers.StudentHandler,
 	uploadHandler handlers.UploadHandler,
+	reindexHandler handlers.ReindexHandler,
+	rubricHandler handlers.RubricHandler,
+	evaluationHandler handlers.EvaluationHandler,
+	defenseHandler handlers.DefenseHandler,
+	projectUploadHandler handlers.ProjectUploadHandler,
+	projectTrashHandler handlers.ProjectTrashHandler,
+	auditHandler handlers.AuditHandler,
+	healthHandler handlers.HealthHandler,
+	objectStorage storage.ObjectStorage,
+	authMiddleware middlewares.AuthMiddleware,
+	logger *slog.Logger,
+	tracerProvider trace.TracerProvider,
 ) (*gin.Engine, error) {
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
 
 	r.Use(
-		gin.Logger(),
+		middlewares.RequestID(),
+		middlewares.Tracing(tracerProvider),
+		middlewares.AccessLog(logger),
+		middlewares.Metrics(),
 		gin.Recovery(),
 		cors.New(cors.Config{
 			AllowOrigins:     []string{"https://project-service.kunmhing.me", "http://localhost:3000", "https://pbox.cpe.eng.cmu.ac.th"},
 			AllowCredentials: true,
-			AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-			AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
+			AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+			AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "Upload-Offset", "If-Match", "X-Request-ID", "traceparent", "tracestate"},
+			ExposeHeaders:    []string{"Upload-Offset", "ETag", "X-Request-ID"},
 		}),
 	)
 
@@ -56,6 +78,16 @@ func NewApp(
 		studentHandler,
 		uploadHandler,
 		keywordHandler,
+		reindexHandler,
+		rubricHandler,
+		evaluationHandler,
+		defenseHandler,
+		projectUploadHandler,
+		projectTrashHandler,
+		auditHandler,
+		healthHandler,
+		objectStorage,
+		authMiddleware,
 	)
 
 	return r, nil
@@ -80,22 +112,51 @@ func NewApp(
 func main() {
 	configs.InitialEnv(".env")
 
+	if len(os.Args) > 1 && os.Args[1] == "reindex" {
+		os.Exit(runReindexCommand(os.Args[2:]))
+	}
+
 	app, cleanup, err := InitializeApp()
 	if err != nil {
-		log.Print(err)
+		slog.Error("Failed to start the server", "error", err)
+		os.Exit(1)
 	}
 
-	exitChan := make(chan os.Signal, 1)
-	signal.Notify(exitChan, os.Interrupt, syscall.SIGTERM)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%s", configs.GetPort()),
+		Handler: app,
+	}
+	serverErr := make(chan error, 1)
 	go func() {
-		<-exitChan
-		log.Print("Shutting down the server...")
-		cleanup()
-		os.Exit(0)
+		serverErr <- server.ListenAndServe()
 	}()
 
-	if err := app.Run(fmt.Sprintf(":%s", configs.GetPort())); err != nil {
-		log.Print(err)
+	select {
+	case err = <-serverErr:
+		slog.Error("Server stopped", "error", err)
+	case <-ctx.Done():
+		// A second signal kills the process instead of waiting for the drain.
+		stop()
+		shutdownConfig := configs.GetShutdownConfig()
+		slog.Info("Shutting down the server...", "drain_timeout", shutdownConfig.DrainTimeout)
+
+		// Stop accepting connections and let in-flight requests finish
+		// before the services and connections they use are closed.
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownConfig.DrainTimeout)
+		err = server.Shutdown(shutdownCtx)
+		cancel()
+		if err != nil {
+			slog.Error("Failed to drain in-flight requests", "error", err)
+			server.Close()
+		}
+	}
+
+	cleanup()
+	slog.Info("Server stopped")
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		os.Exit(1)
 	}
 }
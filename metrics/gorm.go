@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const gormStartKey = "metrics:start"
+
+// GormPlugin times every statement GORM runs and records it in
+// DBQueryDuration. Register it with db.Use.
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string {
+	return "metrics"
+}
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register("metrics:before_create", startQueryTimer),
+		callback.Create().After("gorm:create").Register("metrics:after_create", observeQuery("create")),
+		callback.Query().Before("gorm:query").Register("metrics:before_query", startQueryTimer),
+		callback.Query().After("gorm:query").Register("metrics:after_query", observeQuery("query")),
+		callback.Update().Before("gorm:update").Register("metrics:before_update", startQueryTimer),
+		callback.Update().After("gorm:update").Register("metrics:after_update", observeQuery("update")),
+		callback.Delete().Before("gorm:delete").Register("metrics:before_delete", startQueryTimer),
+		callback.Delete().After("gorm:delete").Register("metrics:after_delete", observeQuery("delete")),
+		callback.Row().Before("gorm:row").Register("metrics:before_row", startQueryTimer),
+		callback.Row().After("gorm:row").Register("metrics:after_row", observeQuery("row")),
+		callback.Raw().Before("gorm:raw").Register("metrics:before_raw", startQueryTimer),
+		callback.Raw().After("gorm:raw").Register("metrics:after_raw", observeQuery("raw")),
+	)
+}
+
+func startQueryTimer(db *gorm.DB) {
+	db.InstanceSet(gormStartKey, time.Now())
+}
+
+func observeQuery(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(gormStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		// A lookup that finds nothing is an answer, not a failed query.
+		err := db.Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = nil
+		}
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		DBQueryDuration.WithLabelValues(operation, table, Result(err)).Observe(Since(start))
+	}
+}
@@ -0,0 +1,182 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/project-box/auth"
+	"github.com/project-box/configs"
+)
+
+type AuthMiddleware interface {
+	Authenticate() gin.HandlerFunc
+}
+
+type authMiddleware struct {
+	logger *slog.Logger
+	config *configs.AuthConfig
+	secret []byte
+	jwks   keyfunc.Keyfunc
+	parser *jwt.Parser
+}
+
+// NewAuthMiddleware builds a JWT validator from the JWT_* environment
+// variables. Tokens signed with HMAC are checked against JWT_SECRET, all
+// other algorithms against the keys published at JWT_JWKS_URL.
+func NewAuthMiddleware(logger *slog.Logger) (AuthMiddleware, func(), error) {
+	config := configs.GetAuthConfig()
+	if config.JWKSURL == "" && config.Secret == "" {
+		return nil, nil, errors.New("either JWT_JWKS_URL or JWT_SECRET must be set")
+	}
+
+	m := &authMiddleware{logger: logger, config: config}
+	var validMethods []string
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if config.Secret != "" {
+		m.secret = []byte(config.Secret)
+		validMethods = append(validMethods, "HS256", "HS384", "HS512")
+	}
+
+	if config.JWKSURL != "" {
+		jwks, err := keyfunc.NewDefaultCtx(ctx, []string{config.JWKSURL})
+		if err != nil {
+			cancel()
+			return nil, nil, fmt.Errorf("failed to load JWKS: %w", err)
+		}
+		m.jwks = jwks
+		validMethods = append(validMethods, "RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA")
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(validMethods),
+		jwt.WithExpirationRequired(),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+	m.parser = jwt.NewParser(options...)
+
+	return m, cancel, nil
+}
+
+func (m *authMiddleware) keyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if m.secret == nil {
+			return nil, errors.New("HMAC signed tokens are not accepted")
+		}
+		return m.secret, nil
+	}
+
+	if m.jwks == nil {
+		return nil, errors.New("asymmetric signed tokens are not accepted")
+	}
+	return m.jwks.Keyfunc(token)
+}
+
+// Authenticate rejects requests without a valid bearer token and stores the
+// resulting principal in both the gin context and the request context.
+func (m *authMiddleware) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		tokenString, found := strings.CutPrefix(header, "Bearer ")
+		if !found || tokenString == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing bearer token"})
+			return
+		}
+
+		claims := jwt.MapClaims{}
+		if _, err := m.parser.ParseWithClaims(tokenString, claims, m.keyFunc); err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			return
+		}
+
+		principal := m.principalFromClaims(c.Request.Context(), claims)
+		c.Set(principalKey, principal)
+		c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+func (m *authMiddleware) principalFromClaims(ctx context.Context, claims jwt.MapClaims) *auth.Principal {
+	principal := &auth.Principal{}
+	principal.Subject, _ = claims.GetSubject()
+	principal.Email, _ = claims["email"].(string)
+	principal.StudentID, _ = claims["student_id"].(string)
+
+	var roleValues []string
+	switch roles := claims[m.config.RolesClaim].(type) {
+	case string:
+		roleValues = strings.Fields(roles)
+	case []interface{}:
+		for _, role := range roles {
+			if value, ok := role.(string); ok {
+				roleValues = append(roleValues, value)
+			}
+		}
+	}
+
+	for _, value := range roleValues {
+		binding, err := auth.ParseRoleBinding(value)
+		if err != nil {
+			m.logger.WarnContext(ctx, "Ignoring role claim", "subject", principal.Subject, "error", err)
+			continue
+		}
+		principal.Roles = append(principal.Roles, binding)
+	}
+
+	return principal
+}
+
+const principalKey = "principal"
+
+func GetPrincipal(c *gin.Context) (*auth.Principal, bool) {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return nil, false
+	}
+	principal, ok := value.(*auth.Principal)
+	return principal, ok
+}
+
+// RequireRoles only lets the request through when the authenticated
+// principal holds one of roles. If the route carries a program_id path
+// parameter the role must be granted for that program. Otherwise a grant in
+// some program is enough, and the handler or service must check the program
+// of the data it touches.
+func RequireRoles(roles ...auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := GetPrincipal(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			return
+		}
+
+		allowed := principal.HasRoleInAnyProgram(roles...)
+		if value := c.Param("program_id"); value != "" {
+			programID, err := strconv.Atoi(value)
+			if err != nil || programID <= 0 {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid program ID"})
+				return
+			}
+			allowed = principal.HasRole(programID, roles...)
+		}
+		if !allowed {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			return
+		}
+
+		c.Next()
+	}
+}
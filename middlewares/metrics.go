@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/metrics"
+)
+
+// unmatchedRoute labels requests that matched no route, so scans of random
+// paths cannot blow up the number of series.
+const unmatchedRoute = "unmatched"
+
+// Metrics records the count and latency of every request by method, route
+// template and status code.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		metrics.HTTPRequestsTotal.WithLabelValues(c.Request.Method, route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(metrics.Since(start))
+	}
+}
@@ -0,0 +1,57 @@
+package middlewares
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/logging"
+)
+
+// RequestID gives every request an ID, taken from the X-Request-ID header
+// when the client sent a usable one and generated otherwise. The ID is
+// echoed in the response and stored in the request context, from where it
+// reaches log lines, queries and published messages.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestId := c.GetHeader(logging.RequestIDHeader)
+		if !logging.ValidRequestID(requestId) {
+			requestId = logging.NewRequestID()
+		}
+
+		c.Header(logging.RequestIDHeader, requestId)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), requestId))
+		c.Next()
+	}
+}
+
+// AccessLog writes one structured line per request once it has been handled.
+// It must run after RequestID so the line carries the request ID.
+func AccessLog(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		level := slog.LevelInfo
+		switch status := c.Writer.Status(); {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", c.Writer.Status()),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("size", max(c.Writer.Size(), 0)),
+		}
+		if errs := c.Errors.ByType(gin.ErrorTypePrivate).String(); errs != "" {
+			attrs = append(attrs, slog.String("errors", errs))
+		}
+		logger.LogAttrs(c.Request.Context(), level, "Request handled", attrs...)
+	}
+}
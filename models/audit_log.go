@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+	AuditActionPurge   = "purge"
+)
+
+const (
+	AuditEntityProject               = "project"
+	AuditEntityStaff                 = "staff"
+	AuditEntityStudent               = "student"
+	AuditEntityKeyword               = "keyword"
+	AuditEntityConfig                = "config"
+	AuditEntityProjectConfig         = "project_config"
+	AuditEntityProjectResourceConfig = "project_resource_config"
+	AuditEntityImportJob             = "import_job"
+	AuditEntityProjectResource       = "project_resource"
+)
+
+// AuditLog records one change to a project, project resource, staff member,
+// student, keyword, config or import. Rows are only ever inserted; the table rejects updates and
+// deletes. Changes maps each changed field to its "before" and "after" value.
+// It has no foreign keys so entries outlive the records they describe.
+type AuditLog struct {
+	ID         int             `json:"id" gorm:"primaryKey;autoIncrement"`
+	Actor      string          `json:"actor" gorm:"not null;index"`
+	ActorEmail string          `json:"actor_email"`
+	Action     string          `json:"action" gorm:"type:varchar(20);not null"`
+	EntityType string          `json:"entity_type" gorm:"type:varchar(50);not null;index:idx_audit_logs_entity"`
+	EntityID   string          `json:"entity_id" gorm:"not null;index:idx_audit_logs_entity"`
+	ProgramID  *int            `json:"program_id" gorm:"index"`
+	Changes    json.RawMessage `json:"changes" gorm:"type:jsonb" swaggertype:"object"`
+	CreatedAt  time.Time       `json:"created_at" gorm:"index"`
+}
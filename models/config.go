@@ -1,5 +1,9 @@
 package models
 
+// ConfigProjectNumberTemplate names the per-program config holding the
+// template new project numbers are formatted with, e.g. {abbr}{seq:4}-{sem}/{yy}.
+const ConfigProjectNumberTemplate = "project number template"
+
 type Config struct {
 	ID         int     `json:"id" gorm:"primaryKey;autoIncrement"`
 	ConfigName string  `json:"config_name"`
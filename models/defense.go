@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// DefenseRoom is a room a program can hold project defenses in.
+type DefenseRoom struct {
+	ID        int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex:idx_defense_rooms_program_name"`
+	Location  *string   `json:"location"`
+	Capacity  *int      `json:"capacity"`
+	ProgramID int       `json:"program_id" gorm:"not null;uniqueIndex:idx_defense_rooms_program_name"`
+	Program   Program   `json:"-" gorm:"foreignKey:ProgramID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DefenseSlot is a period during a semester in which defenses can be held.
+// Any of the program's rooms can be booked for a slot.
+type DefenseSlot struct {
+	ID           int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProgramID    int       `json:"program_id" gorm:"not null;index:idx_defense_slots_semester"`
+	Program      Program   `json:"-" gorm:"foreignKey:ProgramID;constraint:OnDelete:CASCADE"`
+	AcademicYear int       `json:"academic_year" gorm:"not null;index:idx_defense_slots_semester"`
+	Semester     int       `json:"semester" gorm:"not null;index:idx_defense_slots_semester"`
+	StartsAt     time.Time `json:"starts_at" gorm:"not null"`
+	EndsAt       time.Time `json:"ends_at" gorm:"not null"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Defense books a room during a slot for a project's defense. StartsAt and
+// EndsAt are copied from the slot so overlap checks need no join.
+type Defense struct {
+	ID        int         `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProjectID int         `json:"project_id" gorm:"not null;uniqueIndex"`
+	Project   Project     `json:"project" gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+	SlotID    int         `json:"slot_id" gorm:"not null;uniqueIndex:idx_defenses_slot_room"`
+	Slot      DefenseSlot `json:"-" gorm:"foreignKey:SlotID;constraint:OnDelete:CASCADE"`
+	RoomID    int         `json:"room_id" gorm:"not null;uniqueIndex:idx_defenses_slot_room"`
+	Room      DefenseRoom `json:"room" gorm:"foreignKey:RoomID;constraint:OnDelete:CASCADE"`
+	StartsAt  time.Time   `json:"starts_at" gorm:"not null;index"`
+	EndsAt    time.Time   `json:"ends_at" gorm:"not null"`
+	CreatedBy string      `json:"created_by"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+const (
+	ImportJobTypeStudentEnrollment = "student-enrollment"
+	ImportJobTypeCreateProject     = "create-project"
+	ImportJobTypeCreateStaff       = "create-staff"
+)
+
+const (
+	ImportJobStatusPending   = "pending"
+	ImportJobStatusRunning   = "running"
+	ImportJobStatusSucceeded = "succeeded"
+	ImportJobStatusFailed    = "failed"
+)
+
+// ImportJob is an uploaded Excel sheet waiting for, or processed by, the
+// background import worker. The sheet is kept in FileData so pending jobs
+// survive a restart.
+type ImportJob struct {
+	ID            int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	Type          string     `json:"type" gorm:"type:varchar(50);not null"`
+	Status        string     `json:"status" gorm:"type:varchar(20);not null;index"`
+	DryRun        bool       `json:"dry_run"`
+	FileName      string     `json:"file_name"`
+	FileData      []byte     `json:"-" gorm:"type:bytea"`
+	TotalRows     int        `json:"total_rows"`
+	ProcessedRows int        `json:"processed_rows"`
+	Attempts      int        `json:"attempts"`
+	Error         *string    `json:"error"`
+	Report        []byte     `json:"-" gorm:"type:jsonb"`
+	CreatedBy     string     `json:"created_by"`
+	LockedUntil   *time.Time `json:"-"`
+	ProgramID     int        `json:"program_id"`
+	Program       Program    `json:"program" gorm:"foreignKey:ProgramID;constraint:OnDelete:CASCADE" swaggerignore:"true"`
+	StartedAt     *time.Time `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
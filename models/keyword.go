@@ -2,7 +2,7 @@ package models
 
 type Keyword struct {
 	ID        int     `json:"id" gorm:"primaryKey"`
-	Keyword   string  `json:"keyword"`
-	ProgramID int     `json:"program_id"`
+	Keyword   string  `json:"keyword" gorm:"uniqueIndex:idx_keywords_program_keyword"`
+	ProgramID int     `json:"program_id" gorm:"uniqueIndex:idx_keywords_program_keyword"`
 	Program   Program `json:"program" gorm:"foreignKey:ProgramID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusDelivered = "delivered"
+	// OutboxStatusDead marks an event the relay gave up on after too many
+	// failed attempts. It is kept for inspection and no longer holds back
+	// later events of its project.
+	OutboxStatusDead = "dead"
+)
+
+// OutboxEvent is a search index change recorded in the same transaction as
+// the project write that caused it. The outbox relay publishes pending events
+// to RabbitMQ and marks them delivered once the broker confirms them. While
+// the relay holds an event, NextAttemptAt is the end of its lease.
+// RequestID is the request that caused the change and TraceParent the W3C
+// trace context of its span, both forwarded in the message headers.
+type OutboxEvent struct {
+	ID            int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	AggregateType string     `json:"aggregate_type" gorm:"type:varchar(50);not null"`
+	AggregateID   int        `json:"aggregate_id" gorm:"not null"`
+	Operation     string     `json:"operation" gorm:"type:varchar(20);not null"`
+	RequestID     string     `json:"request_id" gorm:"type:varchar(128)"`
+	TraceParent   string     `json:"trace_parent" gorm:"type:varchar(64)"`
+	Status        string     `json:"status" gorm:"type:varchar(20);not null;index:idx_outbox_events_status_next_attempt"`
+	Attempts      int        `json:"attempts"`
+	LastError     *string    `json:"last_error"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"index:idx_outbox_events_status_next_attempt"`
+	DeliveredAt   *time.Time `json:"delivered_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
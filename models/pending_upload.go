@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// PendingUpload is a file the client has been given a presigned URL for, or is
+// sending in chunks, but that is not yet attached to a project. The declared
+// size, type and checksum are checked against the stored object when the
+// project is finalized. For resumable uploads ReceivedBytes is the offset the
+// next chunk must start at, and ChunkLeaseUntil is set while a request is
+// storing that chunk.
+type PendingUpload struct {
+	ID              int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	Bucket          string     `json:"-" gorm:"not null"`
+	ObjectKey       string     `json:"-" gorm:"not null"`
+	FileName        string     `json:"file_name" gorm:"not null"`
+	ContentType     string     `json:"content_type" gorm:"not null"`
+	Size            int64      `json:"size" gorm:"not null"`
+	ChecksumSHA256  string     `json:"checksum_sha256" gorm:"type:varchar(64);not null"`
+	Resumable       bool       `json:"resumable" gorm:"not null;default:false"`
+	ReceivedBytes   int64      `json:"received_bytes" gorm:"not null;default:0"`
+	ChunkLeaseUntil *time.Time `json:"-"`
+	CreatedBy       string     `json:"created_by" gorm:"not null;index"`
+	ExpiresAt       time.Time  `json:"expires_at" gorm:"not null;index"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
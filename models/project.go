@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Project struct {
 	ID               int               `json:"id" gorm:"primaryKey;autoIncrement"`
@@ -17,9 +21,13 @@ type Project struct {
 	Members          []Student         `json:"members" gorm:"many2many:project_students;constraint:OnDelete:CASCADE;"`
 	ProjectResources []ProjectResource `json:"project_resources"`
 	Keywords         []Keyword         `json:"keywords" gorm:"many2many:project_keywords;constraint:OnDelete:CASCADE;"`
+	Status           ProjectStatus     `json:"status" gorm:"type:varchar(20);not null;default:'proposal'"`
 	IsPublic         bool              `json:"is_public"`
+	Version          int               `json:"version" gorm:"not null;default:1"`
 	CreatedAt        *time.Time        `json:"created_at" gorm:"default:CURRENT_DATE"`
 	UpdatedAt        *time.Time        `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt    `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string"`
+	DeletedBy        *string           `json:"deleted_by,omitempty"`
 }
 
 type ProjectRequest struct {
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// ProjectEvaluation is one committee member's assessment of a project against
+// a rubric. A staff member has at most one evaluation per project and rubric;
+// resubmitting replaces the scores.
+type ProjectEvaluation struct {
+	ID            int               `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProjectID     int               `json:"project_id" gorm:"not null;uniqueIndex:idx_project_evaluations_evaluator"`
+	Project       Project           `json:"-" gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+	RubricID      int               `json:"rubric_id" gorm:"not null;uniqueIndex:idx_project_evaluations_evaluator"`
+	Rubric        Rubric            `json:"-" gorm:"foreignKey:RubricID;constraint:OnDelete:CASCADE"`
+	StaffID       int               `json:"staff_id" gorm:"not null;uniqueIndex:idx_project_evaluations_evaluator"`
+	Staff         Staff             `json:"-" gorm:"foreignKey:StaffID;constraint:OnDelete:CASCADE"`
+	ProjectRoleID int               `json:"project_role_id"`
+	Comment       *string           `json:"comment"`
+	Scores        []EvaluationScore `json:"scores" gorm:"foreignKey:EvaluationID;constraint:OnDelete:CASCADE"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+type EvaluationScore struct {
+	ID           int             `json:"id" gorm:"primaryKey;autoIncrement"`
+	EvaluationID int             `json:"evaluation_id" gorm:"not null;uniqueIndex:idx_evaluation_scores_criterion"`
+	CriterionID  int             `json:"criterion_id" gorm:"not null;uniqueIndex:idx_evaluation_scores_criterion"`
+	Criterion    RubricCriterion `json:"-" gorm:"foreignKey:CriterionID;constraint:OnDelete:CASCADE"`
+	Score        float64         `json:"score"`
+	Comment      *string         `json:"comment"`
+}
@@ -3,8 +3,8 @@ package models
 type ProjectNumberCounter struct {
 	ID           int     `json:"id" gorm:"primaryKey;autoIncrement"`
 	Number       int     `json:"number" gorm:"default:1"`
-	AcademicYear int     `json:"academic_year"`
-	Semester     int     `gorm:"default:0"`
-	ProgramID    *int    `json:"program_id"`
+	AcademicYear int     `json:"academic_year" gorm:"uniqueIndex:idx_project_number_counters_scope"`
+	Semester     int     `gorm:"default:0;uniqueIndex:idx_project_number_counters_scope"`
+	ProgramID    *int    `json:"program_id" gorm:"uniqueIndex:idx_project_number_counters_scope"`
 	Program      Program `json:"program" gorm:"foreignKey:ProgramID;constraint:OnDelete:CASCADE"`
 }
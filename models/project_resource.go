@@ -2,9 +2,12 @@ package models
 
 import "time"
 
+// ProjectResource is one version of a project file or link. Resources of a
+// project that share a Title form a version history; exactly one version of
+// each title is current, unless the title was removed from the project.
 type ProjectResource struct {
 	ID              int           `json:"id" gorm:"primaryKey;autoIncrement"`
-	Title           *string       `json:"title"`
+	Title           *string       `json:"title" gorm:"index:idx_project_resource_title,priority:2"`
 	ResourceName    *string       `json:"resource_name"`
 	Path            *string       `json:"path"`
 	URL             *string       `json:"url"`
@@ -13,7 +16,9 @@ type ProjectResource struct {
 	ResourceType    ResourceType  `json:"resource_type" gorm:"foreignKey:ResourceTypeID;constraint:OnDelete:CASCADE"`
 	FileExtensionID *int          `json:"file_extension_id"`
 	FileExtension   FileExtension `json:"file_extension" gorm:"foreignKey:FileExtensionID;constraint:OnDelete:CASCADE"`
-	ProjectID       int           `json:"project_id"`
+	ProjectID       int           `json:"project_id" gorm:"index:idx_project_resource_title,priority:1"`
+	Version         int           `json:"version" gorm:"not null;default:1"`
+	IsCurrent       bool          `json:"is_current" gorm:"not null;default:true"`
 	Project         Project       `json:"project" gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" swaggerignore:"true"`
 	CreatedAt       *time.Time    `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 }
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+type ProjectStatus string
+
+const (
+	ProjectStatusProposal   ProjectStatus = "proposal"
+	ProjectStatusRejected   ProjectStatus = "rejected"
+	ProjectStatusInProgress ProjectStatus = "in_progress"
+	ProjectStatusDefense    ProjectStatus = "defense"
+	ProjectStatusCompleted  ProjectStatus = "completed"
+	ProjectStatusArchived   ProjectStatus = "archived"
+)
+
+// projectStatusTransitions lists the statuses a project may move to from each
+// status. A proposal is approved into in_progress or rejected by its advisor;
+// a rejected proposal can be resubmitted. A failed defense goes back to
+// in_progress.
+var projectStatusTransitions = map[ProjectStatus][]ProjectStatus{
+	ProjectStatusProposal:   {ProjectStatusInProgress, ProjectStatusRejected},
+	ProjectStatusRejected:   {ProjectStatusProposal},
+	ProjectStatusInProgress: {ProjectStatusDefense},
+	ProjectStatusDefense:    {ProjectStatusCompleted, ProjectStatusInProgress},
+	ProjectStatusCompleted:  {ProjectStatusArchived},
+	ProjectStatusArchived:   {},
+}
+
+func (s ProjectStatus) IsValid() bool {
+	_, ok := projectStatusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether a project in status s may move to next.
+func (s ProjectStatus) CanTransitionTo(next ProjectStatus) bool {
+	for _, status := range projectStatusTransitions[s] {
+		if status == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectStatusHistory records one status change of a project.
+type ProjectStatusHistory struct {
+	ID         int           `json:"id" gorm:"primaryKey;autoIncrement"`
+	ProjectID  int           `json:"project_id" gorm:"not null;index"`
+	Project    Project       `json:"-" gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+	FromStatus ProjectStatus `json:"from_status" gorm:"type:varchar(20);not null"`
+	ToStatus   ProjectStatus `json:"to_status" gorm:"type:varchar(20);not null"`
+	Comment    *string       `json:"comment"`
+	ChangedBy  string        `json:"changed_by"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Rubric is a program's grading scheme for project evaluations. Criteria are
+// scored within their own range and combined by weight; RoleWeights decide how
+// much each committee role counts towards the final grade.
+type Rubric struct {
+	ID          int                `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string             `json:"name" gorm:"not null"`
+	Description *string            `json:"description"`
+	ProgramID   int                `json:"program_id" gorm:"index"`
+	Program     Program            `json:"program" gorm:"foreignKey:ProgramID;constraint:OnDelete:CASCADE"`
+	Criteria    []RubricCriterion  `json:"criteria" gorm:"constraint:OnDelete:CASCADE"`
+	RoleWeights []RubricRoleWeight `json:"role_weights" gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+type RubricCriterion struct {
+	ID          int     `json:"id" gorm:"primaryKey;autoIncrement"`
+	RubricID    int     `json:"rubric_id" gorm:"not null;index"`
+	Name        string  `json:"name" gorm:"not null"`
+	Description *string `json:"description"`
+	Weight      float64 `json:"weight" gorm:"not null"`
+	MinScore    float64 `json:"min_score" gorm:"not null"`
+	MaxScore    float64 `json:"max_score" gorm:"not null"`
+	SortOrder   int     `json:"sort_order"`
+}
+
+type RubricRoleWeight struct {
+	ID            int         `json:"id" gorm:"primaryKey;autoIncrement"`
+	RubricID      int         `json:"rubric_id" gorm:"not null;uniqueIndex:idx_rubric_role_weights_role"`
+	ProjectRoleID int         `json:"project_role_id" gorm:"not null;uniqueIndex:idx_rubric_role_weights_role"`
+	ProjectRole   ProjectRole `json:"project_role" gorm:"foreignKey:ProjectRoleID;constraint:OnDelete:CASCADE"`
+	Weight        float64     `json:"weight" gorm:"not null"`
+}
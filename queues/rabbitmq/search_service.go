@@ -1,9 +1,10 @@
 package queues
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 
+	rabbitMQ "github.com/project-box/db/rabbitmq"
 	rabbitmq "github.com/rabbitmq/amqp091-go"
 )
 
@@ -13,7 +14,9 @@ type Message struct {
 	Data      interface{} `json:"data"`      // The actual data related to the operation
 }
 
-func PublishMessageFromRabbitMQToElasticSearch(channel *rabbitmq.Channel, operation string, data interface{}) error {
+// PublishMessageFromRabbitMQToElasticSearch publishes a persistent message to
+// the search exchange and waits for the broker to confirm it.
+func PublishMessageFromRabbitMQToElasticSearch(ctx context.Context, publisher rabbitMQ.Publisher, operation string, data interface{}) error {
 	message := Message{
 		Operation: operation,
 		Data:      data,
@@ -24,21 +27,9 @@ func PublishMessageFromRabbitMQToElasticSearch(channel *rabbitmq.Channel, operat
 		return err
 	}
 
-	err = channel.Publish(
-		"project_service.search",      // exchange
-		"project_service.events.crud", // routing key
-		false,                         // mandatory
-		false,                         // immediate
-		rabbitmq.Publishing{
-			ContentType: "application/json",
-			Body:        body,
-		},
-	)
-	if err != nil {
-		return err
-	} else {
-		fmt.Println("Message published successfully.")
-	}
-
-	return err
+	return publisher.Publish(ctx, rabbitMQ.SearchExchange, rabbitMQ.SearchRoutingKey, rabbitmq.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: rabbitmq.Persistent,
+		Body:         body,
+	})
 }
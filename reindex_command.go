@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/project-box/dtos"
+	"github.com/project-box/services"
+)
+
+const reindexProgressInterval = time.Second
+
+// runReindexCommand implements `project-service reindex`, which republishes
+// projects to the search index from the command line.
+func runReindexCommand(args []string) int {
+	flags := flag.NewFlagSet("reindex", flag.ContinueOnError)
+	programID := flags.Int("program-id", 0, "only reindex projects of this program")
+	academicYear := flags.Int("academic-year", 0, "only reindex projects of this academic year")
+	ratePerSecond := flags.Int("rate", 0, "projects published per second (defaults to REINDEX_RATE_PER_SECOND)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	request := dtos.ReindexRequest{RatePerSecond: *ratePerSecond}
+	if *programID > 0 {
+		request.ProgramID = programID
+	}
+	if *academicYear > 0 {
+		request.AcademicYear = academicYear
+	}
+
+	reindexService, cleanup, err := InitializeReindexService()
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+	defer cleanup()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var lastReport time.Time
+	status, err := reindexService.Reindex(ctx, request, func(status dtos.ReindexStatus) {
+		if status.Status == services.ReindexStatusRunning && time.Since(lastReport) < reindexProgressInterval {
+			return
+		}
+		lastReport = time.Now()
+		fmt.Printf("reindex %s: %d/%d processed, %d published, %d failed\n", status.Status, status.Processed, status.Total, status.Published, status.Failed)
+	})
+	if err != nil {
+		log.Printf("Reindex %s: %v", status.Status, err)
+		return 1
+	}
+	if status.Failed > 0 {
+		log.Printf("Reindex finished with %d failed projects: %v", status.Failed, status.FailedIDs)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/project-box/dtos"
+	"github.com/project-box/models"
+	"gorm.io/gorm"
+)
+
+const auditLogBatchSize = 500
+
+// AuditLogRepository only appends to and reads the audit log; entries are
+// never changed once written.
+type AuditLogRepository interface {
+	CreateAuditLogs(ctx context.Context, logs []models.AuditLog) error
+	GetAuditLogs(ctx context.Context, filter *dtos.AuditLogFilter) (*dtos.AuditLogPage, error)
+	// EachAuditLog calls fn for every entry matching filter, oldest first,
+	// loading them in batches. Limit and Offset are ignored.
+	EachAuditLog(ctx context.Context, filter *dtos.AuditLogFilter, fn func(*models.AuditLog) error) error
+}
+
+type auditLogRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepositoryImpl{db: db}
+}
+
+func (r *auditLogRepositoryImpl) CreateAuditLogs(ctx context.Context, logs []models.AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(logs, auditLogBatchSize).Error
+}
+
+func (r *auditLogRepositoryImpl) filterAuditLogs(ctx context.Context, filter *dtos.AuditLogFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models.AuditLog{})
+	if filter.ProgramID != nil {
+		query = query.Where("program_id = ?", *filter.ProgramID)
+	}
+	if filter.EntityType != nil {
+		query = query.Where("entity_type = ?", *filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.Actor != nil {
+		query = query.Where("actor = ? OR actor_email = ?", *filter.Actor, *filter.Actor)
+	}
+	if filter.Action != nil {
+		query = query.Where("action = ?", *filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	return query
+}
+
+func (r *auditLogRepositoryImpl) GetAuditLogs(ctx context.Context, filter *dtos.AuditLogFilter) (*dtos.AuditLogPage, error) {
+	var total int64
+	if err := r.filterAuditLogs(ctx, filter).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	logs := []models.AuditLog{}
+	if err := r.filterAuditLogs(ctx, filter).
+		Order("id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&logs).Error; err != nil {
+		return nil, err
+	}
+
+	return &dtos.AuditLogPage{
+		Data:   logs,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+func (r *auditLogRepositoryImpl) EachAuditLog(ctx context.Context, filter *dtos.AuditLogFilter, fn func(*models.AuditLog) error) error {
+	var batch []models.AuditLog
+	return r.filterAuditLogs(ctx, filter).FindInBatches(&batch, auditLogBatchSize, func(tx *gorm.DB, _ int) error {
+		for i := range batch {
+			if err := fn(&batch[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
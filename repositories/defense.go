@@ -0,0 +1,341 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/project-box/dtos"
+	"github.com/project-box/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	DefenseConflictStaff   = "staff"
+	DefenseConflictStudent = "student"
+	DefenseConflictRoom    = "room"
+)
+
+// defenseScheduleLockKey serializes defense bookings so two concurrent
+// requests cannot both pass the conflict check for the same person or room.
+const defenseScheduleLockKey = 7_160_451_117
+
+var ErrDefenseResourceInUse = errors.New("defenses are booked against it")
+
+type DefenseRepository interface {
+	GetRoomsByProgramId(ctx context.Context, programId int) ([]models.DefenseRoom, error)
+	GetRoomByID(ctx context.Context, id int) (*models.DefenseRoom, error)
+	CreateRoom(ctx context.Context, room *models.DefenseRoom) error
+	DeleteRoom(ctx context.Context, id int) error
+	GetSlots(ctx context.Context, filter *dtos.DefenseFilter) ([]models.DefenseSlot, error)
+	GetSlotByID(ctx context.Context, id int) (*models.DefenseSlot, error)
+	CreateSlot(ctx context.Context, slot *models.DefenseSlot) error
+	DeleteSlot(ctx context.Context, id int) error
+	GetDefenses(ctx context.Context, filter *dtos.DefenseFilter) ([]models.Defense, error)
+	GetDefenseByID(ctx context.Context, id int) (*models.Defense, error)
+	GetDefensesByStaffId(ctx context.Context, staffId int) ([]models.Defense, error)
+	GetDefensesByRoomId(ctx context.Context, roomId int) ([]models.Defense, error)
+	GetDefensesBetween(ctx context.Context, from, to time.Time) ([]models.Defense, error)
+	GetProjectStudents(ctx context.Context, projectIds []int) (map[int][]models.Student, error)
+	GetUnscheduledProjects(ctx context.Context, programId, academicYear, semester int, projectIds []int) ([]models.Project, error)
+	ScheduleDefense(ctx context.Context, defense *models.Defense) ([]dtos.DefenseConflict, error)
+	DeleteDefense(ctx context.Context, id int) error
+}
+
+type defenseRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewDefenseRepository(db *gorm.DB) DefenseRepository {
+	return &defenseRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *defenseRepositoryImpl) GetRoomsByProgramId(ctx context.Context, programId int) ([]models.DefenseRoom, error) {
+	var rooms []models.DefenseRoom
+	if err := r.db.WithContext(ctx).Where("program_id = ?", programId).Order("name").Find(&rooms).Error; err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+func (r *defenseRepositoryImpl) GetRoomByID(ctx context.Context, id int) (*models.DefenseRoom, error) {
+	room := &models.DefenseRoom{}
+	if err := r.db.WithContext(ctx).First(room, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+func (r *defenseRepositoryImpl) CreateRoom(ctx context.Context, room *models.DefenseRoom) error {
+	return r.db.WithContext(ctx).Omit("Program").Create(room).Error
+}
+
+func (r *defenseRepositoryImpl) DeleteRoom(ctx context.Context, id int) error {
+	return r.deleteUnbooked(ctx, &models.DefenseRoom{}, "room_id", id)
+}
+
+func (r *defenseRepositoryImpl) GetSlots(ctx context.Context, filter *dtos.DefenseFilter) ([]models.DefenseSlot, error) {
+	query := r.db.WithContext(ctx).Where("program_id = ?", filter.ProgramID)
+	if filter.AcademicYear != nil {
+		query = query.Where("academic_year = ?", *filter.AcademicYear)
+	}
+	if filter.Semester != nil {
+		query = query.Where("semester = ?", *filter.Semester)
+	}
+
+	var slots []models.DefenseSlot
+	if err := query.Order("starts_at, id").Find(&slots).Error; err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
+func (r *defenseRepositoryImpl) GetSlotByID(ctx context.Context, id int) (*models.DefenseSlot, error) {
+	slot := &models.DefenseSlot{}
+	if err := r.db.WithContext(ctx).First(slot, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return slot, nil
+}
+
+func (r *defenseRepositoryImpl) CreateSlot(ctx context.Context, slot *models.DefenseSlot) error {
+	return r.db.WithContext(ctx).Omit("Program").Create(slot).Error
+}
+
+func (r *defenseRepositoryImpl) DeleteSlot(ctx context.Context, id int) error {
+	return r.deleteUnbooked(ctx, &models.DefenseSlot{}, "slot_id", id)
+}
+
+// deleteUnbooked deletes a room or slot unless a defense is booked against
+// it; cascading the delete would silently cancel those defenses.
+func (r *defenseRepositoryImpl) deleteUnbooked(ctx context.Context, model interface{}, column string, id int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(model, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.Defense{}).Where(column+" = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrDefenseResourceInUse
+		}
+
+		return tx.Delete(model).Error
+	})
+}
+
+func (r *defenseRepositoryImpl) preloadDefense(db *gorm.DB) *gorm.DB {
+	// The inner join hides defenses of projects that are in the trash.
+	return db.InnerJoins("Project").Preload("Room")
+}
+
+func (r *defenseRepositoryImpl) GetDefenses(ctx context.Context, filter *dtos.DefenseFilter) ([]models.Defense, error) {
+	query := r.preloadDefense(r.db.WithContext(ctx)).
+		Joins("JOIN defense_slots ON defense_slots.id = defenses.slot_id").
+		Where("defense_slots.program_id = ?", filter.ProgramID)
+	if filter.AcademicYear != nil {
+		query = query.Where("defense_slots.academic_year = ?", *filter.AcademicYear)
+	}
+	if filter.Semester != nil {
+		query = query.Where("defense_slots.semester = ?", *filter.Semester)
+	}
+
+	var defenses []models.Defense
+	if err := query.Order("defenses.starts_at, defenses.id").Find(&defenses).Error; err != nil {
+		return nil, err
+	}
+	return defenses, nil
+}
+
+func (r *defenseRepositoryImpl) GetDefenseByID(ctx context.Context, id int) (*models.Defense, error) {
+	defense := &models.Defense{}
+	if err := r.preloadDefense(r.db.WithContext(ctx)).First(defense, "defenses.id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return defense, nil
+}
+
+func (r *defenseRepositoryImpl) GetDefensesByStaffId(ctx context.Context, staffId int) ([]models.Defense, error) {
+	var defenses []models.Defense
+	if err := r.preloadDefense(r.db.WithContext(ctx)).
+		Where("defenses.project_id IN (?)", r.db.
+			Table("project_staffs").
+			Select("project_id").
+			Where("staff_id = ?", staffId)).
+		Order("defenses.starts_at, defenses.id").
+		Find(&defenses).Error; err != nil {
+		return nil, err
+	}
+	return defenses, nil
+}
+
+func (r *defenseRepositoryImpl) GetDefensesByRoomId(ctx context.Context, roomId int) ([]models.Defense, error) {
+	var defenses []models.Defense
+	if err := r.preloadDefense(r.db.WithContext(ctx)).
+		Where("defenses.room_id = ?", roomId).
+		Order("defenses.starts_at, defenses.id").
+		Find(&defenses).Error; err != nil {
+		return nil, err
+	}
+	return defenses, nil
+}
+
+func (r *defenseRepositoryImpl) GetDefensesBetween(ctx context.Context, from, to time.Time) ([]models.Defense, error) {
+	var defenses []models.Defense
+	if err := r.preloadDefense(r.db.WithContext(ctx)).
+		Where("defenses.starts_at < ? AND defenses.ends_at > ?", to, from).
+		Order("defenses.starts_at, defenses.id").
+		Find(&defenses).Error; err != nil {
+		return nil, err
+	}
+	return defenses, nil
+}
+
+// GetProjectStudents returns the members of each project keyed by project ID.
+func (r *defenseRepositoryImpl) GetProjectStudents(ctx context.Context, projectIds []int) (map[int][]models.Student, error) {
+	members := map[int][]models.Student{}
+	if len(projectIds) == 0 {
+		return members, nil
+	}
+
+	var rows []struct {
+		ProjectID int
+		models.Student
+	}
+	if err := r.db.WithContext(ctx).
+		Table("project_students").
+		Select("project_students.project_id, students.*").
+		Joins("JOIN students ON students.id = project_students.student_id").
+		Where("project_students.project_id IN ?", projectIds).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		members[row.ProjectID] = append(members[row.ProjectID], row.Student)
+	}
+	return members, nil
+}
+
+// GetUnscheduledProjects lists the program's projects for the semester that
+// are in progress or in their defense and have no defense booked yet. A
+// non-empty projectIds limits the result to those projects.
+func (r *defenseRepositoryImpl) GetUnscheduledProjects(ctx context.Context, programId, academicYear, semester int, projectIds []int) ([]models.Project, error) {
+	query := r.db.WithContext(ctx).
+		Where("program_id = ? AND academic_year = ? AND semester = ?", programId, academicYear, semester).
+		Where("status IN ?", []models.ProjectStatus{models.ProjectStatusInProgress, models.ProjectStatusDefense}).
+		Where("NOT EXISTS (SELECT 1 FROM defenses WHERE defenses.project_id = projects.id)")
+	if len(projectIds) > 0 {
+		query = query.Where("id IN ?", projectIds)
+	}
+
+	var projects []models.Project
+	if err := query.Order("project_no, id").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// ScheduleDefense books the defense, replacing any earlier booking of the same
+// project, unless it would double-book one of the project's staff, one of its
+// students or the room. In that case nothing is written and the conflicting
+// bookings are returned.
+func (r *defenseRepositoryImpl) ScheduleDefense(ctx context.Context, defense *models.Defense) ([]dtos.DefenseConflict, error) {
+	var conflicts []dtos.DefenseConflict
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", defenseScheduleLockKey).Error; err != nil {
+			return err
+		}
+
+		var err error
+		conflicts, err = r.findConflicts(tx, defense)
+		if err != nil || len(conflicts) > 0 {
+			return err
+		}
+
+		existing := &models.Defense{}
+		err = tx.Where("project_id = ?", defense.ProjectID).First(existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Omit("Project", "Slot", "Room").Create(defense).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		defense.ID = existing.ID
+		defense.CreatedAt = existing.CreatedAt
+		defense.UpdatedAt = time.Now()
+		return tx.Model(existing).
+			Select("SlotID", "RoomID", "StartsAt", "EndsAt", "CreatedBy", "UpdatedAt").
+			Updates(defense).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+func (r *defenseRepositoryImpl) findConflicts(tx *gorm.DB, defense *models.Defense) ([]dtos.DefenseConflict, error) {
+	overlapping := func(conflictType, idColumn, labelColumn string) *gorm.DB {
+		return tx.Table("defenses").
+			Select("? AS type, "+idColumn+" AS id, "+labelColumn+" AS label, defenses.id AS defense_id, defenses.project_id, projects.project_no, defenses.starts_at, defenses.ends_at", conflictType).
+			Joins("JOIN projects ON projects.id = defenses.project_id AND projects.deleted_at IS NULL").
+			Where("defenses.starts_at < ? AND defenses.ends_at > ?", defense.EndsAt, defense.StartsAt).
+			Where("defenses.project_id <> ?", defense.ProjectID)
+	}
+
+	var conflicts []dtos.DefenseConflict
+
+	var roomConflicts []dtos.DefenseConflict
+	if err := overlapping(DefenseConflictRoom, "defense_rooms.id", "defense_rooms.name").
+		Joins("JOIN defense_rooms ON defense_rooms.id = defenses.room_id").
+		Where("defenses.room_id = ?", defense.RoomID).
+		Scan(&roomConflicts).Error; err != nil {
+		return nil, err
+	}
+	conflicts = append(conflicts, roomConflicts...)
+
+	var staffConflicts []dtos.DefenseConflict
+	if err := overlapping(DefenseConflictStaff, "staffs.id", "staffs.email").
+		Joins("JOIN project_staffs ON project_staffs.project_id = defenses.project_id").
+		Joins("JOIN staffs ON staffs.id = project_staffs.staff_id").
+		Where("project_staffs.staff_id IN (?)", tx.
+			Table("project_staffs").
+			Select("staff_id").
+			Where("project_id = ?", defense.ProjectID)).
+		Scan(&staffConflicts).Error; err != nil {
+		return nil, err
+	}
+	conflicts = append(conflicts, staffConflicts...)
+
+	var studentConflicts []dtos.DefenseConflict
+	if err := overlapping(DefenseConflictStudent, "students.id", "students.student_id").
+		Joins("JOIN project_students ON project_students.project_id = defenses.project_id").
+		Joins("JOIN students ON students.id = project_students.student_id").
+		Where("students.student_id IN (?)", tx.
+			Table("project_students").
+			Select("students.student_id").
+			Joins("JOIN students ON students.id = project_students.student_id").
+			Where("project_students.project_id = ?", defense.ProjectID)).
+		Scan(&studentConflicts).Error; err != nil {
+		return nil, err
+	}
+	conflicts = append(conflicts, studentConflicts...)
+
+	return conflicts, nil
+}
+
+func (r *defenseRepositoryImpl) DeleteDefense(ctx context.Context, id int) error {
+	result := r.db.WithContext(ctx).Delete(&models.Defense{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
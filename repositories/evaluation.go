@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/project-box/dtos"
+	"github.com/project-box/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EvaluationRepository interface {
+	UpsertEvaluation(ctx context.Context, evaluation *models.ProjectEvaluation) error
+	GetEvaluations(ctx context.Context, rubricId int, projectIds []int) ([]models.ProjectEvaluation, error)
+	GetScoringProjects(ctx context.Context, programId int, filter *dtos.ProjectScoreFilter) ([]models.Project, error)
+}
+
+type evaluationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewEvaluationRepository(db *gorm.DB) EvaluationRepository {
+	return &evaluationRepositoryImpl{
+		db: db,
+	}
+}
+
+// UpsertEvaluation stores a staff member's evaluation of a project. An
+// existing evaluation for the same project, rubric and staff member is
+// updated and its scores are replaced.
+func (r *evaluationRepositoryImpl) UpsertEvaluation(ctx context.Context, evaluation *models.ProjectEvaluation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existing := &models.ProjectEvaluation{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("project_id = ? AND rubric_id = ? AND staff_id = ?", evaluation.ProjectID, evaluation.RubricID, evaluation.StaffID).
+			First(existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Omit("Project", "Rubric", "Staff", "Scores.Criterion").Create(evaluation).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		evaluation.ID = existing.ID
+		evaluation.CreatedAt = existing.CreatedAt
+		evaluation.UpdatedAt = time.Now()
+		if err := tx.Model(existing).Updates(map[string]interface{}{
+			"project_role_id": evaluation.ProjectRoleID,
+			"comment":         evaluation.Comment,
+			"updated_at":      evaluation.UpdatedAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("evaluation_id = ?", existing.ID).Delete(&models.EvaluationScore{}).Error; err != nil {
+			return err
+		}
+		for i := range evaluation.Scores {
+			evaluation.Scores[i].ID = 0
+			evaluation.Scores[i].EvaluationID = existing.ID
+		}
+		if len(evaluation.Scores) == 0 {
+			return nil
+		}
+		return tx.Omit("Criterion").Create(&evaluation.Scores).Error
+	})
+}
+
+func (r *evaluationRepositoryImpl) GetEvaluations(ctx context.Context, rubricId int, projectIds []int) ([]models.ProjectEvaluation, error) {
+	var evaluations []models.ProjectEvaluation
+	if len(projectIds) == 0 {
+		return evaluations, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Preload("Staff").
+		Preload("Scores").
+		Where("rubric_id = ? AND project_id IN ?", rubricId, projectIds).
+		Order("id").
+		Find(&evaluations).Error; err != nil {
+		return nil, err
+	}
+	return evaluations, nil
+}
+
+// GetScoringProjects lists the program's projects that have reached their
+// defense, together with their committee.
+func (r *evaluationRepositoryImpl) GetScoringProjects(ctx context.Context, programId int, filter *dtos.ProjectScoreFilter) ([]models.Project, error) {
+	query := r.db.WithContext(ctx).
+		Where("program_id = ?", programId).
+		Where("status IN ?", []models.ProjectStatus{models.ProjectStatusDefense, models.ProjectStatusCompleted, models.ProjectStatusArchived})
+	if filter != nil && filter.AcademicYear != nil {
+		query = query.Where("academic_year = ?", *filter.AcademicYear)
+	}
+	if filter != nil && filter.Semester != nil {
+		query = query.Where("semester = ?", *filter.Semester)
+	}
+
+	var projects []models.Project
+	if err := query.Order("project_no, id").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
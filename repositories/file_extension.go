@@ -12,6 +12,7 @@ import (
 type FileExtensionRepository interface {
 	repository[models.FileExtension]
 	GetFileExtension(ctx context.Context, tx *gorm.DB, file *multipart.FileHeader) (*models.FileExtension, error)
+	GetFileExtensionByMimeType(ctx context.Context, tx *gorm.DB, mimeType string) (*models.FileExtension, error)
 }
 
 type fileExtensionRepositoryImpl struct {
@@ -27,12 +28,18 @@ func NewFileExtensionRepository(db *gorm.DB) FileExtensionRepository {
 }
 
 func (r *fileExtensionRepositoryImpl) GetFileExtension(ctx context.Context, tx *gorm.DB, file *multipart.FileHeader) (*models.FileExtension, error) {
-	fileType := file.Header.Get("Content-Type")
-	if fileType == "" {
-		fileType = "unknown"
+	return r.GetFileExtensionByMimeType(ctx, tx, file.Header.Get("Content-Type"))
+}
+
+func (r *fileExtensionRepositoryImpl) GetFileExtensionByMimeType(ctx context.Context, tx *gorm.DB, mimeType string) (*models.FileExtension, error) {
+	if tx == nil {
+		tx = r.db
+	}
+	if mimeType == "" {
+		mimeType = "unknown"
 	}
 	fileExtension := &models.FileExtension{}
-	if err := tx.WithContext(ctx).Where("mime_type = ?", fileType).First(fileExtension).Error; err != nil {
+	if err := tx.WithContext(ctx).Where("mime_type = ?", mimeType).First(fileExtension).Error; err != nil {
 		return nil, fmt.Errorf("file type not found: %w", err)
 	}
 
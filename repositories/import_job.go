@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/project-box/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ImportJobRepository interface {
+	repository[models.ImportJob]
+	ClaimNext(ctx context.Context, lease time.Duration) (*models.ImportJob, error)
+	UpdateProgress(ctx context.Context, id int, processed, total int, lease time.Duration) error
+	Finish(ctx context.Context, id int, status string, report []byte, errMessage *string) error
+	Requeue(ctx context.Context, id int, report []byte, errMessage string) error
+	Retry(ctx context.Context, id int) (*models.ImportJob, error)
+}
+
+var ErrImportJobNotRetryable = errors.New("only failed import jobs can be retried")
+
+type importJobRepositoryImpl struct {
+	db *gorm.DB
+	*repositoryImpl[models.ImportJob]
+}
+
+func NewImportJobRepository(db *gorm.DB) ImportJobRepository {
+	return &importJobRepositoryImpl{
+		db:             db,
+		repositoryImpl: newRepository[models.ImportJob](db),
+	}
+}
+
+// ClaimNext locks the oldest pending job, or a running job whose worker lease
+// expired (for example because the service restarted mid-import), and marks
+// it as running. It returns gorm.ErrRecordNotFound when there is nothing to do.
+func (r *importJobRepositoryImpl) ClaimNext(ctx context.Context, lease time.Duration) (*models.ImportJob, error) {
+	var job models.ImportJob
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND locked_until < ?)", models.ImportJobStatusPending, models.ImportJobStatusRunning, now).
+			Order("id").
+			First(&job).Error; err != nil {
+			return err
+		}
+
+		lockedUntil := now.Add(lease)
+		job.Status = models.ImportJobStatusRunning
+		job.Attempts++
+		job.LockedUntil = &lockedUntil
+		job.StartedAt = &now
+		job.ProcessedRows = 0
+		return tx.Model(&job).Updates(map[string]interface{}{
+			"status":         job.Status,
+			"attempts":       job.Attempts,
+			"locked_until":   job.LockedUntil,
+			"started_at":     job.StartedAt,
+			"processed_rows": job.ProcessedRows,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// UpdateProgress stores the number of processed rows and extends the lease of
+// the worker that owns the job.
+func (r *importJobRepositoryImpl) UpdateProgress(ctx context.Context, id int, processed, total int, lease time.Duration) error {
+	return r.db.WithContext(ctx).Model(&models.ImportJob{}).
+		Where("id = ? AND status = ?", id, models.ImportJobStatusRunning).
+		Updates(map[string]interface{}{
+			"processed_rows": processed,
+			"total_rows":     total,
+			"locked_until":   time.Now().Add(lease),
+		}).Error
+}
+
+func (r *importJobRepositoryImpl) Finish(ctx context.Context, id int, status string, report []byte, errMessage *string) error {
+	return r.db.WithContext(ctx).Model(&models.ImportJob{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"report":       report,
+			"error":        errMessage,
+			"locked_until": nil,
+			"finished_at":  time.Now(),
+		}).Error
+}
+
+// Requeue puts a job that failed with a transient error back in the queue.
+func (r *importJobRepositoryImpl) Requeue(ctx context.Context, id int, report []byte, errMessage string) error {
+	return r.db.WithContext(ctx).Model(&models.ImportJob{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       models.ImportJobStatusPending,
+			"report":       report,
+			"error":        errMessage,
+			"locked_until": nil,
+		}).Error
+}
+
+// Retry moves a failed job back to pending so a worker picks it up again.
+func (r *importJobRepositoryImpl) Retry(ctx context.Context, id int) (*models.ImportJob, error) {
+	var job models.ImportJob
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&job, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if job.Status != models.ImportJobStatusFailed {
+			return ErrImportJobNotRetryable
+		}
+
+		job.Status = models.ImportJobStatusPending
+		job.Attempts = 0
+		job.ProcessedRows = 0
+		job.Error = nil
+		job.Report = nil
+		job.StartedAt = nil
+		job.FinishedAt = nil
+		return tx.Model(&job).Updates(map[string]interface{}{
+			"status":         job.Status,
+			"attempts":       job.Attempts,
+			"processed_rows": job.ProcessedRows,
+			"error":          nil,
+			"report":         nil,
+			"started_at":     nil,
+			"finished_at":    nil,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/project-box/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type KeywordRepository interface {
@@ -13,6 +14,10 @@ type KeywordRepository interface {
 	FindByID(ctx context.Context, id string) (*models.Keyword, error)
 	FindByKeywordAndProgramId(ctx context.Context, keyword string, programId int) (*models.Keyword, error)
 	Create(ctx context.Context, keyword *models.Keyword) error
+	// FindOrCreateInTransaction loads the program's keyword with the same
+	// name into keyword within tx, creating it if there is none. It reports
+	// whether the keyword was created.
+	FindOrCreateInTransaction(ctx context.Context, tx *gorm.DB, keyword *models.Keyword) (bool, error)
 	Update(ctx context.Context, keyword *models.Keyword) error
 	Delete(ctx context.Context, id string) error
 }
@@ -53,6 +58,23 @@ func (r *keywordRepository) Create(ctx context.Context, keyword *models.Keyword)
 	return r.DB.WithContext(ctx).Create(keyword).Error
 }
 
+func (r *keywordRepository) FindOrCreateInTransaction(ctx context.Context, tx *gorm.DB, keyword *models.Keyword) (bool, error) {
+	db := tx.WithContext(ctx)
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "keyword"}, {Name: "program_id"}},
+		DoNothing: true,
+	}).Create(keyword)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	// Someone else created it first.
+	return false, db.Where("keyword = ? AND program_id = ?", keyword.Keyword, keyword.ProgramID).First(keyword).Error
+}
+
 func (r *keywordRepository) Update(ctx context.Context, keyword *models.Keyword) error {
 	return r.DB.WithContext(ctx).Save(keyword).Error
 }
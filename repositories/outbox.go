@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/project-box/logging"
+	"github.com/project-box/models"
+	"github.com/project-box/tracing"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const outboxAggregateProject = "project"
+
+type OutboxRepository interface {
+	AddProjectEvent(ctx context.Context, tx *gorm.DB, operation string, projectID int) error
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error)
+	MarkDelivered(ctx context.Context, id int) error
+	MarkFailed(ctx context.Context, id int, nextAttemptAt time.Time, errMessage string) error
+	MarkDead(ctx context.Context, id int, errMessage string) error
+}
+
+type outboxRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepositoryImpl{
+		db: db,
+	}
+}
+
+// AddProjectEvent records a search index change for a project. Pass the
+// transaction of the project write so both commit or roll back together.
+func (r *outboxRepositoryImpl) AddProjectEvent(ctx context.Context, tx *gorm.DB, operation string, projectID int) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return tx.WithContext(ctx).Create(&models.OutboxEvent{
+		AggregateType: outboxAggregateProject,
+		AggregateID:   projectID,
+		Operation:     operation,
+		RequestID:     logging.RequestIDFromContext(ctx),
+		TraceParent:   tracing.TraceParent(ctx),
+		Status:        models.OutboxStatusPending,
+		NextAttemptAt: time.Now(),
+	}).Error
+}
+
+// ClaimPending leases up to limit due events in insertion order and returns
+// them once the lease is committed, so no lock is held while they are
+// published. Only the oldest pending event of each project is due, so events
+// of the same project go out one after another in the order they were
+// written, even with several relays. An event whose lease runs out before it
+// is marked is claimed again.
+func (r *outboxRepositoryImpl) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.OutboxStatusPending, now).
+			Where(`NOT EXISTS (
+				SELECT 1 FROM outbox_events older
+				WHERE older.aggregate_type = outbox_events.aggregate_type
+					AND older.aggregate_id = outbox_events.aggregate_id
+					AND older.status = ?
+					AND older.id < outbox_events.id
+			)`, models.OutboxStatusPending).
+			Order("id").
+			Limit(limit).
+			Find(&events).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := make([]int, len(events))
+		for i := range events {
+			ids[i] = events[i].ID
+		}
+		return tx.Model(&models.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *outboxRepositoryImpl) MarkDelivered(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("id = ? AND status = ?", id, models.OutboxStatusPending).
+		Updates(map[string]interface{}{
+			"status":       models.OutboxStatusDelivered,
+			"attempts":     gorm.Expr("attempts + 1"),
+			"last_error":   nil,
+			"delivered_at": time.Now(),
+		}).Error
+}
+
+func (r *outboxRepositoryImpl) MarkFailed(ctx context.Context, id int, nextAttemptAt time.Time, errMessage string) error {
+	return r.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("id = ? AND status = ?", id, models.OutboxStatusPending).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      errMessage,
+			"next_attempt_at": nextAttemptAt,
+		}).Error
+}
+
+func (r *outboxRepositoryImpl) MarkDead(ctx context.Context, id int, errMessage string) error {
+	return r.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("id = ? AND status = ?", id, models.OutboxStatusPending).
+		Updates(map[string]interface{}{
+			"status":     models.OutboxStatusDead,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": errMessage,
+		}).Error
+}
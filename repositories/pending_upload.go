@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/project-box/models"
+	"gorm.io/gorm"
+)
+
+type PendingUploadRepository interface {
+	CreatePendingUploads(ctx context.Context, uploads []models.PendingUpload) error
+	GetPendingUploadsByIds(ctx context.Context, ids []int) ([]models.PendingUpload, error)
+	GetPendingUploadByID(ctx context.Context, id int) (*models.PendingUpload, error)
+	ReserveUploadChunk(ctx context.Context, id int, offset int64, leaseUntil time.Time) (bool, error)
+	AdvanceUpload(ctx context.Context, id int, offset, length int64, leaseUntil time.Time) (bool, error)
+	ReleaseUploadChunk(ctx context.Context, id int, leaseUntil time.Time) error
+	GetExpiredPendingUploads(ctx context.Context, expiredBefore time.Time, limit int) ([]models.PendingUpload, error)
+	DeleteExpiredPendingUpload(ctx context.Context, id int, expiredBefore time.Time) (bool, error)
+}
+
+var ErrPendingUploadBusy = errors.New("another request is writing to this upload")
+
+type pendingUploadRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPendingUploadRepository(db *gorm.DB) PendingUploadRepository {
+	return &pendingUploadRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *pendingUploadRepositoryImpl) CreatePendingUploads(ctx context.Context, uploads []models.PendingUpload) error {
+	return r.db.WithContext(ctx).Create(&uploads).Error
+}
+
+func (r *pendingUploadRepositoryImpl) GetPendingUploadsByIds(ctx context.Context, ids []int) ([]models.PendingUpload, error) {
+	var uploads []models.PendingUpload
+	if len(ids) == 0 {
+		return uploads, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&uploads).Error; err != nil {
+		return nil, err
+	}
+	return uploads, nil
+}
+
+func (r *pendingUploadRepositoryImpl) GetPendingUploadByID(ctx context.Context, id int) (*models.PendingUpload, error) {
+	upload := &models.PendingUpload{}
+	if err := r.db.WithContext(ctx).First(upload, id).Error; err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// ReserveUploadChunk leases the chunk at offset until leaseUntil. It reports
+// false when the upload has moved past offset or another request holds an
+// unexpired lease, so two chunks for the same offset are never stored at once.
+func (r *pendingUploadRepositoryImpl) ReserveUploadChunk(ctx context.Context, id int, offset int64, leaseUntil time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.PendingUpload{}).
+		Where("id = ? AND received_bytes = ?", id, offset).
+		Where("chunk_lease_until IS NULL OR chunk_lease_until < ?", time.Now()).
+		Update("chunk_lease_until", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// AdvanceUpload accepts the chunk stored under the lease ending at leaseUntil
+// and moves the upload's offset past it. It reports false when the lease was
+// lost to another request in the meantime.
+func (r *pendingUploadRepositoryImpl) AdvanceUpload(ctx context.Context, id int, offset, length int64, leaseUntil time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.PendingUpload{}).
+		Where("id = ? AND received_bytes = ? AND chunk_lease_until = ?", id, offset, leaseUntil).
+		Updates(map[string]interface{}{
+			"received_bytes":    offset + length,
+			"chunk_lease_until": nil,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReleaseUploadChunk gives up the lease ending at leaseUntil, if still held.
+func (r *pendingUploadRepositoryImpl) ReleaseUploadChunk(ctx context.Context, id int, leaseUntil time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.PendingUpload{}).
+		Where("id = ? AND chunk_lease_until = ?", id, leaseUntil).
+		Update("chunk_lease_until", nil).Error
+}
+
+func (r *pendingUploadRepositoryImpl) GetExpiredPendingUploads(ctx context.Context, expiredBefore time.Time, limit int) ([]models.PendingUpload, error) {
+	var uploads []models.PendingUpload
+	if err := r.db.WithContext(ctx).
+		Where("expires_at < ?", expiredBefore).
+		Order("id").
+		Limit(limit).
+		Find(&uploads).Error; err != nil {
+		return nil, err
+	}
+	return uploads, nil
+}
+
+// DeleteExpiredPendingUpload removes the upload if it is still unattached and
+// expired before expiredBefore. It reports false when a project claimed the
+// upload in the meantime, in which case its objects must be kept.
+func (r *pendingUploadRepositoryImpl) DeleteExpiredPendingUpload(ctx context.Context, id int, expiredBefore time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND expires_at < ?", id, expiredBefore).
+		Delete(&models.PendingUpload{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
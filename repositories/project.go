@@ -2,18 +2,20 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"mime/multipart"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/minio/minio-go/v7"
 	"github.com/project-box/dtos"
 	"github.com/project-box/models"
 	"github.com/project-box/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProjectRepository interface {
@@ -23,13 +25,56 @@ type ProjectRepository interface {
 	GetProjectByID(ctx context.Context, id int) (*dtos.ProjectData, error)
 	GetProjectWithPDFByID(ctx context.Context, id int) (*dtos.ProjectData, error)
 	GetProjectsByStudentId(ctx context.Context, studentId string) ([]models.Project, error)
+	GetProjects(ctx context.Context, filter *dtos.ProjectFilter) (*dtos.ProjectPage, error)
+	CountProjects(ctx context.Context, filter *dtos.ProjectFilter) (int64, error)
+	GetProjectIDs(ctx context.Context, filter *dtos.ProjectFilter, afterID int, limit int) ([]int, error)
 	CheckDuplicateProjectByTitleAndSemester(ctx context.Context, titleTH, titleEN string, academicYear, semester int) (bool, error)
-	CreateProjects(ctx context.Context, projectReq []models.ProjectRequest) ([]*dtos.ProjectData, error)
+	// CreateProjects creates every project in one transaction. prepare, if
+	// not nil, runs first in the same transaction so the rows the projects
+	// refer to are written, or rolled back, together with them.
+	CreateProjects(ctx context.Context, projectReq []models.ProjectRequest, prepare func(tx *gorm.DB) error) ([]*dtos.ProjectData, error)
 	CreateProjectWithFiles(ctx context.Context, tx *gorm.DB, project *models.ProjectRequest, projectResources []*models.ProjectResource, files []*multipart.FileHeader) (*dtos.ProjectData, error)
-	UpdateProjectWithFiles(ctx context.Context, tx *gorm.DB, project *models.ProjectRequest, projectResources []*models.ProjectResource, files []*multipart.FileHeader) (*dtos.ProjectData, error)
+	CreateProjectWithStagedFiles(ctx context.Context, projectReq *models.ProjectRequest, projectResources []*models.ProjectResource, uploadIds []int) (*dtos.ProjectData, error)
+	UpdateProjectWithFiles(ctx context.Context, tx *gorm.DB, project *models.ProjectRequest, version int, projectResources []*models.ProjectResource, files []*multipart.FileHeader) (*dtos.ProjectData, error)
+	DeleteProject(ctx context.Context, id int, version int, deletedBy string) error
+	GetDeletedProjects(ctx context.Context, programId int) ([]models.Project, error)
+	GetDeletedProject(ctx context.Context, id int) (*models.Project, error)
+	RestoreProject(ctx context.Context, id int) error
+	GetPurgeableProjectIDs(ctx context.Context, deletedBefore time.Time, limit int) ([]int, error)
+	PurgeProject(ctx context.Context, id int, deletedBefore time.Time) ([]string, error)
 	CreateProjectNumber(ctx context.Context, tx *gorm.DB, project *models.ProjectRequest) (*models.ProjectRequest, error)
+	TransitionProjectStatus(ctx context.Context, id int, status models.ProjectStatus, comment *string, changedBy string) (*models.ProjectStatusHistory, error)
+	GetProjectStatusHistory(ctx context.Context, id int) ([]models.ProjectStatusHistory, error)
+	IsProjectMember(ctx context.Context, id int, email, studentId string) (bool, error)
+	PatchProject(ctx context.Context, id int, version int, patch *dtos.PatchProjectRequest) error
+	AddProjectMember(ctx context.Context, id int, version int, studentId int) error
+	RemoveProjectMember(ctx context.Context, id int, version int, studentId int) error
+	AddProjectStaff(ctx context.Context, version int, projectStaff *models.ProjectStaff) error
+	RemoveProjectStaff(ctx context.Context, id int, version int, staffId int, projectRoleId *int) error
+	AddProjectKeyword(ctx context.Context, id int, version int, keywordId int) error
+	RemoveProjectKeyword(ctx context.Context, id int, version int, keywordId int) error
+	AddProjectResource(ctx context.Context, id int, version int, projectResource *models.ProjectResource, file *multipart.FileHeader) error
+	RetireProjectResource(ctx context.Context, id int, version int, resourceId int) error
+	DeleteProjectResource(ctx context.Context, id int, version int, resourceId int) error
+	RestoreProjectResourceVersion(ctx context.Context, id int, version int, resourceId int) error
 }
 
+var (
+	ErrInvalidStatusTransition = errors.New("invalid project status transition")
+	ErrProjectNotCompleted     = errors.New("is_public can only be set once the project is completed")
+	ErrPendingUploadConsumed   = errors.New("upload has already been attached to a project")
+	ErrUnknownProjectResource  = errors.New("resource is not a current resource of this project")
+	ErrReferenceNotFound       = errors.New("referenced record not found")
+	ErrProgramMismatch         = errors.New("record belongs to a different program than the project")
+	ErrAlreadyAssociated       = errors.New("already associated with this project")
+	ErrNotAssociated           = errors.New("not associated with this project")
+	ErrProjectVersionMismatch  = errors.New("project has been changed since it was read")
+	ErrProjectNotDeleted       = errors.New("project is not in the trash")
+	// ErrProjectsNotLoaded means the projects were created but could not be
+	// read back afterwards.
+	ErrProjectsNotLoaded = errors.New("projects were created but could not be loaded")
+)
+
 type projectRepositoryImpl struct {
 	db                       *gorm.DB
 	projectBucketName        string
@@ -39,11 +84,13 @@ type projectRepositoryImpl struct {
 	resourceTypeRepo         ResourceTypeRepository
 	uploadRepo               UploadRepository
 	projectNumberCounterRepo ProjectNumberCounterRepository
+	configRepo               ConfigRepository
+	outboxRepo               OutboxRepository
 
 	*repositoryImpl[models.Project]
 }
 
-func NewProjectRepository(db *gorm.DB, fileExtensionRepo FileExtensionRepository, projectStaffRepo ProjectStaffRepository, projectNumberCounterRepo ProjectNumberCounterRepository, resourceRepo ResourceRepository, resourceTypeRepo ResourceTypeRepository, uploadRepo UploadRepository) ProjectRepository {
+func NewProjectRepository(db *gorm.DB, fileExtensionRepo FileExtensionRepository, projectStaffRepo ProjectStaffRepository, projectNumberCounterRepo ProjectNumberCounterRepository, resourceRepo ResourceRepository, resourceTypeRepo ResourceTypeRepository, uploadRepo UploadRepository, configRepo ConfigRepository, outboxRepo OutboxRepository) ProjectRepository {
 	return &projectRepositoryImpl{
 		db:                       db,
 		projectBucketName:        os.Getenv("MINIO_PROJECT_BUCKET"),
@@ -53,6 +100,8 @@ func NewProjectRepository(db *gorm.DB, fileExtensionRepo FileExtensionRepository
 		resourceTypeRepo:         resourceTypeRepo,
 		uploadRepo:               uploadRepo,
 		projectNumberCounterRepo: projectNumberCounterRepo,
+		configRepo:               configRepo,
+		outboxRepo:               outboxRepo,
 		repositoryImpl:           newRepository[models.Project](db),
 	}
 }
@@ -61,18 +110,74 @@ func (r *projectRepositoryImpl) GetTransaction() *gorm.DB {
 	return r.db.Begin()
 }
 
+// currentProjectResources limits a ProjectResources preload to the current
+// version of each resource.
+func currentProjectResources(db *gorm.DB) *gorm.DB {
+	return db.Where("project_resources.is_current = ?", true)
+}
+
 func isPDFFile(fileType string) bool { return fileType == "pdf" || fileType == "application/pdf" }
 
 func (r *projectRepositoryImpl) CreateProjectNumber(ctx context.Context, tx *gorm.DB, project *models.ProjectRequest) (*models.ProjectRequest, error) {
-	nextProjectNumber, err := r.projectNumberCounterRepo.GetNextProjectNumber(ctx, tx, project.AcademicYear, project.Semester)
+	template, err := r.getProjectNumberTemplate(ctx, project.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+
+	var program models.Program
+	if err := tx.WithContext(ctx).First(&program, "id = ?", project.ProgramID).Error; err != nil {
+		return nil, err
+	}
+
+	// Numbers without the program abbreviation, like those of the default
+	// template, would repeat across programs, so they share one counter.
+	var counterProgramId *int
+	if utils.ProjectNumberTemplateUsesAbbreviation(template) {
+		counterProgramId = &project.ProgramID
+	}
+	nextProjectNumber, err := r.projectNumberCounterRepo.GetNextProjectNumber(ctx, tx, counterProgramId, project.AcademicYear, project.Semester)
+	if err != nil {
+		return nil, err
+	}
+
+	projectNumber, err := utils.FormatProjectNumber(template, utils.ProjectNumberParts{
+		Abbreviation: program.Abbreviation,
+		Sequence:     nextProjectNumber,
+		Semester:     project.Semester,
+		AcademicYear: project.AcademicYear,
+	})
 	if err != nil {
 		return nil, err
 	}
-	projectNumber := utils.FormatProjectID(nextProjectNumber)
 	project.ProjectNo = projectNumber
 	return project, nil
 }
 
+// renumberProject returns the next project number of the program, academic
+// year and semester a project moves to, so its number keeps matching them.
+func (r *projectRepositoryImpl) renumberProject(ctx context.Context, tx *gorm.DB, programId, academicYear, semester int) (string, error) {
+	projectReq, err := r.CreateProjectNumber(ctx, tx, &models.ProjectRequest{
+		ProgramID:    programId,
+		AcademicYear: academicYear,
+		Semester:     semester,
+	})
+	if err != nil {
+		return "", err
+	}
+	return projectReq.ProjectNo, nil
+}
+
+func (r *projectRepositoryImpl) getProjectNumberTemplate(ctx context.Context, programId int) (string, error) {
+	config, err := r.configRepo.GetByNameAndProgramId(ctx, models.ConfigProjectNumberTemplate, programId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.DefaultProjectNumberTemplate, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return config.Value, nil
+}
+
 func (r *projectRepositoryImpl) GetProjectMessageByID(ctx context.Context, id int) (*dtos.ProjectData, error) {
 	projectData, err := r.GetProjectWithPDFByID(ctx, id)
 	if err != nil {
@@ -88,6 +193,7 @@ func (r *projectRepositoryImpl) GetProjectByID(ctx context.Context, id int) (*dt
 		Preload("Staffs.Program").
 		Preload("Members.Program").
 		Preload("Keywords.Program").
+		Preload("ProjectResources", currentProjectResources).
 		Preload("ProjectResources.ResourceType").
 		Preload("ProjectResources.FileExtension").
 		First(project, "projects.id = ?", id).Error; err != nil {
@@ -104,6 +210,7 @@ func (r *projectRepositoryImpl) GetProjectWithPDFByID(ctx context.Context, id in
 		Preload("Staffs.Program").
 		Preload("Members.Program").
 		Preload("Keywords.Program").
+		Preload("ProjectResources", currentProjectResources).
 		Preload("ProjectResources.ResourceType").
 		Preload("ProjectResources.FileExtension").
 		Preload("ProjectResources.PDF.Pages").
@@ -158,6 +265,7 @@ func (r *projectRepositoryImpl) GetProjectsByStudentId(ctx context.Context, stud
 		Preload("Program").
 		Preload("Staffs.Program").
 		Preload("Members.Program").
+		Preload("ProjectResources", currentProjectResources).
 		Preload("ProjectResources.ResourceType").
 		Preload("ProjectResources.FileExtension").
 		Find(&projects).Error; err != nil {
@@ -167,6 +275,182 @@ func (r *projectRepositoryImpl) GetProjectsByStudentId(ctx context.Context, stud
 	return projects, nil
 }
 
+// ProjectSortColumns maps the sort_by values accepted by GetProjects to the
+// SQL expression used for ordering and keyset comparison.
+var ProjectSortColumns = map[string]string{
+	"id":            "projects.id",
+	"project_no":    "projects.project_no",
+	"title_th":      "COALESCE(projects.title_th, '')",
+	"title_en":      "COALESCE(projects.title_en, '')",
+	"academic_year": "projects.academic_year",
+	"created_at":    "projects.created_at",
+	"updated_at":    "projects.updated_at",
+}
+
+func (r *projectRepositoryImpl) GetProjects(ctx context.Context, filter *dtos.ProjectFilter) (*dtos.ProjectPage, error) {
+	var total int64
+	if err := r.filterProjects(ctx, filter).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	sortColumn := ProjectSortColumns[filter.SortBy]
+	direction, operator := "ASC", ">"
+	if filter.Order == "desc" {
+		direction, operator = "DESC", "<"
+	}
+
+	query := r.filterProjects(ctx, filter)
+	if filter.Cursor != "" {
+		cursor, err := utils.DecodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(fmt.Sprintf("(%s, projects.id) %s (?, ?)", sortColumn, operator), cursor.Value, cursor.ID)
+	} else {
+		query = query.Offset(filter.Offset)
+	}
+
+	var projects []models.Project
+	if err := query.
+		Preload("Program").
+		Preload("Staffs.Program").
+		Preload("Members.Program").
+		Preload("Keywords.Program").
+		Preload("ProjectResources", currentProjectResources).
+		Preload("ProjectResources.ResourceType").
+		Preload("ProjectResources.FileExtension").
+		Order(fmt.Sprintf("%s %s, projects.id %s", sortColumn, direction, direction)).
+		Limit(filter.Limit + 1).
+		Find(&projects).Error; err != nil {
+		return nil, err
+	}
+
+	page := &dtos.ProjectPage{
+		Data:   []dtos.ProjectData{},
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+
+	if len(projects) > filter.Limit {
+		projects = projects[:filter.Limit]
+		last := projects[len(projects)-1]
+		nextCursor := utils.EncodeCursor(projectSortValue(&last, filter.SortBy), last.ID)
+		page.NextCursor = &nextCursor
+	}
+
+	for i := range projects {
+		projectData, err := r.buildProjectData(ctx, &projects[i])
+		if err != nil {
+			return nil, err
+		}
+		page.Data = append(page.Data, *projectData)
+	}
+
+	return page, nil
+}
+
+func (r *projectRepositoryImpl) CountProjects(ctx context.Context, filter *dtos.ProjectFilter) (int64, error) {
+	var total int64
+	if err := r.filterProjects(ctx, filter).Count(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// GetProjectIDs returns up to limit IDs of matching projects greater than
+// afterID in ascending order, so callers can walk every project in batches.
+func (r *projectRepositoryImpl) GetProjectIDs(ctx context.Context, filter *dtos.ProjectFilter, afterID int, limit int) ([]int, error) {
+	var ids []int
+	if err := r.filterProjects(ctx, filter).
+		Where("projects.id > ?", afterID).
+		Order("projects.id").
+		Limit(limit).
+		Pluck("projects.id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *projectRepositoryImpl) filterProjects(ctx context.Context, filter *dtos.ProjectFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models.Project{})
+
+	if filter.ProgramID != nil {
+		query = query.Where("projects.program_id = ?", *filter.ProgramID)
+	}
+	if filter.AcademicYear != nil {
+		query = query.Where("projects.academic_year = ?", *filter.AcademicYear)
+	}
+	if filter.Semester != nil {
+		query = query.Where("projects.semester = ?", *filter.Semester)
+	}
+	if filter.SectionID != nil {
+		query = query.Where("projects.section_id = ?", *filter.SectionID)
+	}
+	if filter.IsPublic != nil {
+		query = query.Where("projects.is_public = ?", *filter.IsPublic)
+	}
+	if filter.Status != nil {
+		query = query.Where("projects.status = ?", *filter.Status)
+	}
+	if filter.StaffID != nil {
+		query = query.Where("projects.id IN (?)", r.db.
+			Table("project_staffs").
+			Select("project_id").
+			Where("staff_id = ?", *filter.StaffID))
+	}
+	if filter.StudentID != nil {
+		query = query.Where("projects.id IN (?)", r.db.
+			Table("project_students").
+			Select("project_students.project_id").
+			Joins("JOIN students ON students.id = project_students.student_id").
+			Where("students.student_id = ?", *filter.StudentID))
+	}
+	if filter.KeywordID != nil {
+		query = query.Where("projects.id IN (?)", r.db.
+			Table("project_keywords").
+			Select("project_id").
+			Where("keyword_id = ?", *filter.KeywordID))
+	}
+	if filter.Keyword != nil {
+		query = query.Where("projects.id IN (?)", r.db.
+			Table("project_keywords").
+			Select("project_keywords.project_id").
+			Joins("JOIN keywords ON keywords.id = project_keywords.keyword_id").
+			Where("keywords.keyword ILIKE ?", "%"+*filter.Keyword+"%"))
+	}
+
+	return query
+}
+
+func projectSortValue(project *models.Project, sortBy string) string {
+	switch sortBy {
+	case "project_no":
+		return project.ProjectNo
+	case "title_th":
+		if project.TitleTH != nil {
+			return *project.TitleTH
+		}
+	case "title_en":
+		if project.TitleEN != nil {
+			return *project.TitleEN
+		}
+	case "academic_year":
+		return strconv.Itoa(project.AcademicYear)
+	case "created_at":
+		if project.CreatedAt != nil {
+			return project.CreatedAt.Format(time.RFC3339Nano)
+		}
+	case "updated_at":
+		if project.UpdatedAt != nil {
+			return project.UpdatedAt.Format(time.RFC3339Nano)
+		}
+	default:
+		return strconv.Itoa(project.ID)
+	}
+	return ""
+}
+
 func (r *projectRepositoryImpl) CreateProjectWithFiles(ctx context.Context, tx *gorm.DB, projectReq *models.ProjectRequest, projectResources []*models.ProjectResource, files []*multipart.FileHeader) (*dtos.ProjectData, error) {
 	if tx == nil {
 		tx = r.db.Begin()
@@ -183,20 +467,26 @@ func (r *projectRepositoryImpl) CreateProjectWithFiles(ctx context.Context, tx *
 
 	uploadedFilePaths, err := r.handleCreateProjectResources(ctx, tx, project, projectResources, files)
 	if err != nil {
-		r.uploadRepo.DeleteUploadedFiles(ctx, r.projectBucketName, uploadedFilePaths, minio.RemoveObjectOptions{})
+		r.uploadRepo.DeleteUploadedFiles(ctx, r.projectBucketName, uploadedFilePaths)
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := r.outboxRepo.AddProjectEvent(ctx, tx, "create", project.ID); err != nil {
+		r.uploadRepo.DeleteUploadedFiles(ctx, r.projectBucketName, uploadedFilePaths)
 		tx.Rollback()
 		return nil, err
 	}
 
 	if err := tx.Commit().Error; err != nil {
-		r.uploadRepo.DeleteUploadedFiles(ctx, r.projectBucketName, uploadedFilePaths, minio.RemoveObjectOptions{})
+		r.uploadRepo.DeleteUploadedFiles(ctx, r.projectBucketName, uploadedFilePaths)
 		tx.Rollback()
 		return nil, err
 	}
 
 	projectData, err := r.GetProjectByID(ctx, project.ID)
 	if err != nil {
-		r.uploadRepo.DeleteUploadedFiles(ctx, r.projectBucketName, uploadedFilePaths, minio.RemoveObjectOptions{})
+		r.uploadRepo.DeleteUploadedFiles(ctx, r.projectBucketName, uploadedFilePaths)
 		tx.Rollback()
 		return nil, err
 	}
@@ -204,7 +494,51 @@ func (r *projectRepositoryImpl) CreateProjectWithFiles(ctx context.Context, tx *
 	return projectData, nil
 }
 
-func (r *projectRepositoryImpl) UpdateProjectWithFiles(ctx context.Context, tx *gorm.DB, projectReq *models.ProjectRequest, projectResources []*models.ProjectResource, files []*multipart.FileHeader) (*dtos.ProjectData, error) {
+// CreateProjectWithStagedFiles creates a project whose files were uploaded to
+// storage beforehand. File resources arrive with Path, ResourceName,
+// FileExtensionID and PDF already set; the pending uploads they came from are
+// consumed in the same transaction so each can be attached only once.
+func (r *projectRepositoryImpl) CreateProjectWithStagedFiles(ctx context.Context, projectReq *models.ProjectRequest, projectResources []*models.ProjectResource, uploadIds []int) (*dtos.ProjectData, error) {
+	var projectID int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		project, err := r.createProject(ctx, tx, projectReq)
+		if err != nil {
+			return err
+		}
+		projectID = project.ID
+
+		if len(uploadIds) > 0 {
+			result := tx.Where("id IN ?", uploadIds).Delete(&models.PendingUpload{})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected != int64(len(uploadIds)) {
+				return ErrPendingUploadConsumed
+			}
+		}
+
+		for _, projectResource := range projectResources {
+			if projectResource.Path == nil {
+				if err := r.processURL(ctx, tx, project, projectResource); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := r.processStagedFile(ctx, tx, project, projectResource); err != nil {
+				return err
+			}
+		}
+
+		return r.outboxRepo.AddProjectEvent(ctx, tx, "create", project.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetProjectByID(ctx, projectID)
+}
+
+func (r *projectRepositoryImpl) UpdateProjectWithFiles(ctx context.Context, tx *gorm.DB, projectReq *models.ProjectRequest, version int, projectResources []*models.ProjectResource, files []*multipart.FileHeader) (*dtos.ProjectData, error) {
 	if tx == nil {
 		tx = r.db.Begin()
 		if tx.Error != nil {
@@ -217,38 +551,36 @@ func (r *projectRepositoryImpl) UpdateProjectWithFiles(ctx context.Context, tx *
 		return nil, err
 	}
 
-	project, err := r.updateProject(ctx, tx, projectReq)
+	project, err := r.updateProject(ctx, tx, projectReq, version)
 	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
 
-	uploadedFilePaths, err := r.handleCreateProjectResources(ctx, tx, project, projectResources, files)
+	// Files stored before a failure are removed on a best-effort basis; the
+	// error that caused the rollback is the one returned.
+	uploadedFilePaths, err := r.syncProjectResources(ctx, tx, project, projectResources, files)
 	if err != nil {
-		err := r.uploadRepo.DeleteUploadedFiles(ctx, r.projectBucketName, uploadedFilePaths, minio.RemoveObjectOptions{})
-		if err != nil {
-			return nil, err
-		}
 		tx.Rollback()
+		_ = r.uploadRepo.DeleteUploadedFiles(ctx, r.projectBucketName, uploadedFilePaths)
 		return nil, err
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		err := r.uploadRepo.DeleteUploadedFiles(ctx, r.projectBucketName, uploadedFilePaths, minio.RemoveObjectOptions{})
-		if err != nil {
-			return nil, err
-		}
+	if err := r.outboxRepo.AddProjectEvent(ctx, tx, "update", project.ID); err != nil {
 		tx.Rollback()
+		_ = r.uploadRepo.DeleteUploadedFiles(ctx, r.projectBucketName, uploadedFilePaths)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		_ = r.uploadRepo.DeleteUploadedFiles(ctx, r.projectBucketName, uploadedFilePaths)
 		return nil, err
 	}
 
+	// The update is committed and its files are referenced, so they stay even
+	// if reading the project back fails.
 	projectData, err := r.GetProjectByID(ctx, project.ID)
 	if err != nil {
-		err := r.uploadRepo.DeleteUploadedFiles(ctx, r.projectBucketName, uploadedFilePaths, minio.RemoveObjectOptions{})
-		if err != nil {
-			return nil, err
-		}
-		tx.Rollback()
 		return nil, err
 	}
 
@@ -264,10 +596,6 @@ func (r *projectRepositoryImpl) deleteProjectAssociations(ctx context.Context, t
 		return err
 	}
 
-	if err := r.deleteProjectResources(ctx, tx, projectID); err != nil {
-		return err
-	}
-
 	if err := r.deleteProjectKeyword(ctx, tx, projectID); err != nil {
 		return err
 	}
@@ -283,28 +611,6 @@ func (r *projectRepositoryImpl) deleteProjectKeyword(ctx context.Context, tx *go
 	return nil
 }
 
-func (r *projectRepositoryImpl) deleteProjectResources(ctx context.Context, tx *gorm.DB, projectID int) error {
-	var projectResources []models.ProjectResource
-	if err := tx.WithContext(ctx).Where("project_id = ?", projectID).Find(&projectResources).Error; err != nil {
-		return err
-	}
-
-	for _, projectResource := range projectResources {
-		if projectResource.Path != nil {
-			// Trim the bucket name from the path
-			objectPath := strings.TrimPrefix(*projectResource.Path, r.projectBucketName+"/")
-			if err := r.uploadRepo.DeleteUploadedFile(ctx, r.projectBucketName, objectPath, minio.RemoveObjectOptions{}); err != nil {
-				return err
-			}
-		}
-
-		if err := tx.WithContext(ctx).Delete(&projectResource).Error; err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
 func (r *projectRepositoryImpl) deleteProjectStudents(ctx context.Context, tx *gorm.DB, projectID int) error {
 	if err := tx.WithContext(ctx).Where("project_id = ?", projectID).Delete(&models.ProjectStudent{}).Error; err != nil {
 		return err
@@ -319,26 +625,28 @@ func (r *projectRepositoryImpl) deleteProjectStaffs(ctx context.Context, tx *gor
 	return nil
 }
 
-func (r *projectRepositoryImpl) CreateProjects(ctx context.Context, projectReqs []models.ProjectRequest) ([]*dtos.ProjectData, error) {
-	tx := r.db.Begin()
-	if tx.Error != nil {
-		return nil, tx.Error
-	}
+func (r *projectRepositoryImpl) CreateProjects(ctx context.Context, projectReqs []models.ProjectRequest, prepare func(tx *gorm.DB) error) ([]*dtos.ProjectData, error) {
+	var projects []*models.Project
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if prepare != nil {
+			if err := prepare(tx); err != nil {
+				return err
+			}
+		}
 
-	projects, err := r.createProjectsInTransaction(ctx, tx, projectReqs)
+		var err error
+		projects, err = r.createProjectsInTransaction(ctx, tx, projectReqs)
+		return err
+	})
 	if err != nil {
-		tx.Rollback()
-		return nil, err
-	}
-
-	if err := tx.Commit().Error; err != nil {
-		tx.Rollback()
 		return nil, err
 	}
 
-	projectMessages, err := r.getProjectMessages(ctx, projects)
+	// The projects exist now; reading them back must not fail just because
+	// the caller has gone away.
+	projectMessages, err := r.getProjectMessages(context.WithoutCancel(ctx), projects)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrProjectsNotLoaded, err)
 	}
 
 	return projectMessages, nil
@@ -351,11 +659,140 @@ func (r *projectRepositoryImpl) createProjectsInTransaction(ctx context.Context,
 		if err != nil {
 			return nil, err
 		}
+		if err := r.outboxRepo.AddProjectEvent(ctx, tx, "create", project.ID); err != nil {
+			return nil, err
+		}
 		projects = append(projects, project)
 	}
 	return projects, nil
 }
 
+// DeleteProject moves the project to the trash. It disappears from every
+// query and from the search index, but its rows and files are kept until
+// PurgeProject removes them.
+func (r *projectRepositoryImpl) DeleteProject(ctx context.Context, id int, version int, deletedBy string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		project := &models.Project{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "version").
+			First(project, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := checkProjectVersion(project, version); err != nil {
+			return err
+		}
+
+		if err := tx.Model(project).Updates(map[string]interface{}{
+			"deleted_at": time.Now(),
+			"deleted_by": deletedBy,
+			"version":    gorm.Expr("version + 1"),
+		}).Error; err != nil {
+			return err
+		}
+		return r.outboxRepo.AddProjectEvent(ctx, tx, "delete", id)
+	})
+}
+
+// GetDeletedProjects lists the program's projects in the trash, most recently
+// deleted first.
+func (r *projectRepositoryImpl) GetDeletedProjects(ctx context.Context, programId int) ([]models.Project, error) {
+	var projects []models.Project
+	if err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("program_id = ? AND deleted_at IS NOT NULL", programId).
+		Order("deleted_at DESC, id").
+		Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+func (r *projectRepositoryImpl) GetDeletedProject(ctx context.Context, id int) (*models.Project, error) {
+	project := &models.Project{}
+	if err := r.db.WithContext(ctx).Unscoped().First(project, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	if !project.DeletedAt.Valid {
+		return nil, ErrProjectNotDeleted
+	}
+	return project, nil
+}
+
+// RestoreProject takes the project out of the trash and puts it back in the
+// search index.
+func (r *projectRepositoryImpl) RestoreProject(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		project := &models.Project{}
+		if err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "deleted_at").
+			First(project, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if !project.DeletedAt.Valid {
+			return ErrProjectNotDeleted
+		}
+
+		if err := tx.Unscoped().Model(project).Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"deleted_by": nil,
+			"version":    gorm.Expr("version + 1"),
+		}).Error; err != nil {
+			return err
+		}
+		return r.outboxRepo.AddProjectEvent(ctx, tx, "create", id)
+	})
+}
+
+// GetPurgeableProjectIDs returns projects that were moved to the trash before
+// deletedBefore.
+func (r *projectRepositoryImpl) GetPurgeableProjectIDs(ctx context.Context, deletedBefore time.Time, limit int) ([]int, error) {
+	var ids []int
+	if err := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&models.Project{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Order("deleted_at").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// PurgeProject permanently deletes a project that has been in the trash since
+// before deletedBefore, together with every version of its resources. It
+// returns the storage paths of the files that are no longer referenced, which
+// the caller removes once the transaction has committed. A project restored
+// in the meantime is left alone and reported as gorm.ErrRecordNotFound.
+func (r *projectRepositoryImpl) PurgeProject(ctx context.Context, id int, deletedBefore time.Time) ([]string, error) {
+	var paths []string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		project := &models.Project{}
+		if err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+			First(project, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.ProjectResource{}).
+			Where("project_id = ? AND path IS NOT NULL AND path <> ''", id).
+			Pluck("path", &paths).Error; err != nil {
+			return err
+		}
+
+		// Resources, members, staff, keywords, defenses and evaluations go
+		// with the project through their ON DELETE CASCADE constraints.
+		return tx.Unscoped().Delete(project).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
 func (r *projectRepositoryImpl) getProjectMessages(ctx context.Context, projects []*models.Project) ([]*dtos.ProjectData, error) {
 	var projectMessages []*dtos.ProjectData
 	for _, project := range projects {
@@ -374,7 +811,12 @@ func (r *projectRepositoryImpl) createProject(ctx context.Context, tx *gorm.DB,
 		return nil, err
 	}
 
+	if projectReq.IsPublic {
+		return nil, ErrProjectNotCompleted
+	}
+
 	project := &models.Project{
+		Status:       models.ProjectStatusProposal,
 		ProjectNo:    projectReq.ProjectNo,
 		TitleTH:      projectReq.TitleTH,
 		TitleEN:      projectReq.TitleEN,
@@ -406,7 +848,28 @@ func (r *projectRepositoryImpl) createProject(ctx context.Context, tx *gorm.DB,
 	return project, nil
 }
 
-func (r *projectRepositoryImpl) updateProject(ctx context.Context, tx *gorm.DB, projectReq *models.ProjectRequest) (*models.Project, error) {
+func (r *projectRepositoryImpl) updateProject(ctx context.Context, tx *gorm.DB, projectReq *models.ProjectRequest, version int) (*models.Project, error) {
+	current := &models.Project{}
+	if err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "program_id", "academic_year", "semester", "status", "is_public", "version").
+		First(current, "id = ?", projectReq.ID).Error; err != nil {
+		return nil, err
+	}
+	if err := checkProjectVersion(current, version); err != nil {
+		return nil, err
+	}
+	if projectReq.IsPublic && !current.IsPublic && current.Status != models.ProjectStatusCompleted {
+		return nil, ErrProjectNotCompleted
+	}
+	if projectReq.ProgramID != current.ProgramID || projectReq.AcademicYear != current.AcademicYear || projectReq.Semester != current.Semester {
+		projectNo, err := r.renumberProject(ctx, tx, projectReq.ProgramID, projectReq.AcademicYear, projectReq.Semester)
+		if err != nil {
+			return nil, err
+		}
+		projectReq.ProjectNo = projectNo
+	}
+
 	project := &models.Project{
 		ID:           projectReq.ID,
 		ProjectNo:    projectReq.ProjectNo,
@@ -418,11 +881,12 @@ func (r *projectRepositoryImpl) updateProject(ctx context.Context, tx *gorm.DB,
 		IsPublic:     projectReq.IsPublic,
 		Semester:     projectReq.Semester,
 		ProgramID:    projectReq.ProgramID,
+		Version:      current.Version + 1,
 		Members:      projectReq.Members,
 		Keywords:     projectReq.Keywords,
 	}
 
-	if err := tx.WithContext(ctx).Save(project).Error; err != nil {
+	if err := tx.WithContext(ctx).Omit("Status").Save(project).Error; err != nil {
 		return nil, err
 	}
 
@@ -455,6 +919,68 @@ func buildObjectName(programName string, academiceYear, semester int, projectNo
 		programName, academiceYear, semester, projectNo, title, uniqueFileName)
 }
 
+// syncProjectResources applies the resource list of an update request.
+// Entries carrying the ID of a current resource keep it unchanged; a link
+// whose title and URL match the current version is kept as well. Every other
+// entry is stored as a new version of its title. Current resources the
+// request leaves out stop being current but keep their files, so they can
+// still be downloaded and restored.
+func (r *projectRepositoryImpl) syncProjectResources(ctx context.Context, tx *gorm.DB, project *models.Project, projectResources []*models.ProjectResource, files []*multipart.FileHeader) ([]string, error) {
+	var current []models.ProjectResource
+	if err := tx.WithContext(ctx).
+		Where("project_id = ? AND is_current = ?", project.ID, true).
+		Find(&current).Error; err != nil {
+		return nil, err
+	}
+
+	currentByID := make(map[int]*models.ProjectResource, len(current))
+	currentByTitle := make(map[string]*models.ProjectResource, len(current))
+	for i := range current {
+		currentByID[current[i].ID] = &current[i]
+		if current[i].Title != nil {
+			currentByTitle[*current[i].Title] = &current[i]
+		}
+	}
+
+	kept := map[int]bool{}
+	var added []*models.ProjectResource
+	for _, projectResource := range projectResources {
+		if projectResource.ID != 0 && projectResource.URL == nil {
+			if _, ok := currentByID[projectResource.ID]; !ok {
+				return nil, fmt.Errorf("%w: %d", ErrUnknownProjectResource, projectResource.ID)
+			}
+			kept[projectResource.ID] = true
+			continue
+		}
+		if projectResource.URL != nil && projectResource.Title != nil {
+			existing, ok := currentByTitle[*projectResource.Title]
+			if ok && existing.URL != nil && *existing.URL == *projectResource.URL {
+				kept[existing.ID] = true
+				continue
+			}
+		}
+		projectResource.ID = 0
+		added = append(added, projectResource)
+	}
+
+	var removed []int
+	for _, projectResource := range current {
+		if !kept[projectResource.ID] {
+			removed = append(removed, projectResource.ID)
+		}
+	}
+	if len(removed) > 0 {
+		if err := tx.WithContext(ctx).
+			Model(&models.ProjectResource{}).
+			Where("id IN ?", removed).
+			Update("is_current", false).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return r.handleCreateProjectResources(ctx, tx, project, added, files)
+}
+
 func (r *projectRepositoryImpl) handleCreateProjectResources(ctx context.Context, tx *gorm.DB, project *models.Project, projectResources []*models.ProjectResource, files []*multipart.FileHeader) ([]string, error) {
 	var uploadedObjectNames []string
 
@@ -486,7 +1012,7 @@ func (r *projectRepositoryImpl) processFile(ctx context.Context, tx *gorm.DB, pr
 	objectName := buildObjectName(project.Program.ProgramNameTH, project.AcademicYear, project.Semester, project.ProjectNo, *title, uniqueFileName)
 	filePath := buildFilePath(r.projectBucketName, project.Program.ProgramNameTH, project.AcademicYear, project.Semester, project.ProjectNo, *title, uniqueFileName)
 
-	if err := r.uploadRepo.UploadFile(ctx, r.projectBucketName, objectName, file, minio.PutObjectOptions{}); err != nil {
+	if err := r.uploadRepo.UploadFile(ctx, r.projectBucketName, objectName, file); err != nil {
 		return err
 	}
 	*uploadedObjectNames = append(*uploadedObjectNames, objectName)
@@ -502,7 +1028,7 @@ func (r *projectRepositoryImpl) processFile(ctx context.Context, tx *gorm.DB, pr
 	}
 	var pdf *models.PDF
 	if isPDFFile(fileExtension.MimeType) {
-		pdf, err = utils.ReadPdf(file)
+		pdf, err = utils.ReadPdf(ctx, file)
 		if err != nil {
 			return err
 		}
@@ -521,6 +1047,22 @@ func (r *projectRepositoryImpl) processFile(ctx context.Context, tx *gorm.DB, pr
 	return nil
 }
 
+func (r *projectRepositoryImpl) processStagedFile(ctx context.Context, tx *gorm.DB, project *models.Project, projectResource *models.ProjectResource) error {
+	if projectResource.Title == nil {
+		return fmt.Errorf("title is required")
+	}
+
+	resourceType, err := r.resourceTypeRepo.GetResourceTypeByName(ctx, tx, "file")
+	if err != nil {
+		return err
+	}
+
+	projectResource.ResourceTypeID = resourceType.ID
+	projectResource.ProjectID = project.ID
+
+	return r.resourceRepo.CreateProjectResource(ctx, tx, projectResource)
+}
+
 func (r *projectRepositoryImpl) processURL(ctx context.Context, tx *gorm.DB, project *models.Project, projectResource *models.ProjectResource) error {
 	resourceType, err := r.resourceTypeRepo.GetResourceTypeByName(ctx, tx, "url")
 	if err != nil {
@@ -553,3 +1095,357 @@ func (r *projectRepositoryImpl) CheckDuplicateProjectByTitleAndSemester(ctx cont
 
 	return true, nil
 }
+
+// TransitionProjectStatus moves a project to status and records the change in
+// its status history. The project row is locked for the duration of the
+// transaction so concurrent transitions are applied one after the other.
+func (r *projectRepositoryImpl) TransitionProjectStatus(ctx context.Context, id int, status models.ProjectStatus, comment *string, changedBy string) (*models.ProjectStatusHistory, error) {
+	history := &models.ProjectStatusHistory{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		project := &models.Project{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "status").
+			First(project, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if !project.Status.CanTransitionTo(status) {
+			return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, project.Status, status)
+		}
+
+		if err := tx.Model(project).Updates(map[string]interface{}{
+			"status":  status,
+			"version": gorm.Expr("version + 1"),
+		}).Error; err != nil {
+			return err
+		}
+
+		history = &models.ProjectStatusHistory{
+			ProjectID:  id,
+			FromStatus: project.Status,
+			ToStatus:   status,
+			Comment:    comment,
+			ChangedBy:  changedBy,
+		}
+		if err := tx.Create(history).Error; err != nil {
+			return err
+		}
+
+		return r.outboxRepo.AddProjectEvent(ctx, tx, "update", id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func (r *projectRepositoryImpl) GetProjectStatusHistory(ctx context.Context, id int) ([]models.ProjectStatusHistory, error) {
+	if err := r.db.WithContext(ctx).Select("id").First(&models.Project{}, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	var history []models.ProjectStatusHistory
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ?", id).
+		Order("created_at, id").
+		Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// IsProjectMember reports whether the caller identified by email or student
+// ID is assigned to the project, either as staff or as a student.
+func (r *projectRepositoryImpl) IsProjectMember(ctx context.Context, id int, email, studentId string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			(SELECT COUNT(*) FROM project_staffs
+				JOIN staffs ON staffs.id = project_staffs.staff_id
+				WHERE project_staffs.project_id = @id AND @email <> '' AND LOWER(staffs.email) = LOWER(@email))
+			+
+			(SELECT COUNT(*) FROM project_students
+				JOIN students ON students.id = project_students.student_id
+				WHERE project_students.project_id = @id AND @student_id <> '' AND students.student_id = @student_id)`,
+		sql.Named("id", id), sql.Named("email", email), sql.Named("student_id", studentId)).
+		Scan(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// checkProjectVersion compares the version the caller last read with the
+// current one. A version of 0 (If-Match: *) skips the check.
+func checkProjectVersion(project *models.Project, version int) error {
+	if version != 0 && version != project.Version {
+		return ErrProjectVersionMismatch
+	}
+	return nil
+}
+
+// bumpProjectVersion marks the project as changed so clients holding an older
+// ETag have to re-read it before their next edit.
+func bumpProjectVersion(tx *gorm.DB, projectId int) error {
+	return tx.Model(&models.Project{}).
+		Where("id = ?", projectId).
+		Update("version", gorm.Expr("version + 1")).Error
+}
+
+// modifyProject runs change while holding the project row lock, provided the
+// project is still at version, then bumps the version and queues a search
+// index update in the same transaction. The project passed to change only has
+// its id, program, status, visibility and version loaded.
+func (r *projectRepositoryImpl) modifyProject(ctx context.Context, id int, version int, change func(tx *gorm.DB, project *models.Project) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		project := &models.Project{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "program_id", "status", "is_public", "version").
+			First(project, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := checkProjectVersion(project, version); err != nil {
+			return err
+		}
+
+		if err := change(tx, project); err != nil {
+			return err
+		}
+		if err := bumpProjectVersion(tx, id); err != nil {
+			return err
+		}
+		return r.outboxRepo.AddProjectEvent(ctx, tx, "update", id)
+	})
+}
+
+// checkSameProgram makes sure the referenced record exists and belongs to the
+// project's program.
+func checkSameProgram(tx *gorm.DB, model interface{}, label string, id int, programId int) error {
+	var programIds []int
+	if err := tx.Model(model).Where("id = ?", id).Pluck("program_id", &programIds).Error; err != nil {
+		return err
+	}
+	if len(programIds) == 0 {
+		return fmt.Errorf("%w: %s %d", ErrReferenceNotFound, label, id)
+	}
+	if programIds[0] != programId {
+		return fmt.Errorf("%w: %s %d", ErrProgramMismatch, label, id)
+	}
+	return nil
+}
+
+// PatchProject updates only the fields present in patch and leaves members,
+// staff, keywords and resources untouched.
+func (r *projectRepositoryImpl) PatchProject(ctx context.Context, id int, version int, patch *dtos.PatchProjectRequest) error {
+	return r.modifyProject(ctx, id, version, func(tx *gorm.DB, project *models.Project) error {
+		if err := tx.Select("academic_year", "semester").First(project, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		if patch.TitleTH != nil {
+			updates["title_th"] = *patch.TitleTH
+		}
+		if patch.TitleEN != nil {
+			updates["title_en"] = *patch.TitleEN
+		}
+		if patch.AbstractText != nil {
+			updates["abstract_text"] = *patch.AbstractText
+		}
+		if patch.AcademicYear != nil {
+			updates["academic_year"] = *patch.AcademicYear
+		}
+		if patch.Semester != nil {
+			updates["semester"] = *patch.Semester
+		}
+		if patch.SectionID != nil {
+			updates["section_id"] = *patch.SectionID
+		}
+		if patch.IsPublic != nil {
+			if *patch.IsPublic && !project.IsPublic && project.Status != models.ProjectStatusCompleted {
+				return ErrProjectNotCompleted
+			}
+			updates["is_public"] = *patch.IsPublic
+		}
+		if (patch.AcademicYear != nil && *patch.AcademicYear != project.AcademicYear) ||
+			(patch.Semester != nil && *patch.Semester != project.Semester) {
+			academicYear, semester := project.AcademicYear, project.Semester
+			if patch.AcademicYear != nil {
+				academicYear = *patch.AcademicYear
+			}
+			if patch.Semester != nil {
+				semester = *patch.Semester
+			}
+			projectNo, err := r.renumberProject(ctx, tx, project.ProgramID, academicYear, semester)
+			if err != nil {
+				return err
+			}
+			updates["project_no"] = projectNo
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+
+		return tx.Model(project).Updates(updates).Error
+	})
+}
+
+func (r *projectRepositoryImpl) AddProjectMember(ctx context.Context, id int, version int, studentId int) error {
+	return r.modifyProject(ctx, id, version, func(tx *gorm.DB, project *models.Project) error {
+		if err := checkSameProgram(tx, &models.Student{}, "student", studentId, project.ProgramID); err != nil {
+			return err
+		}
+
+		result := tx.Exec("INSERT INTO project_students (project_id, student_id) VALUES (?, ?) ON CONFLICT DO NOTHING", id, studentId)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: student %d", ErrAlreadyAssociated, studentId)
+		}
+		return nil
+	})
+}
+
+func (r *projectRepositoryImpl) RemoveProjectMember(ctx context.Context, id int, version int, studentId int) error {
+	return r.modifyProject(ctx, id, version, func(tx *gorm.DB, project *models.Project) error {
+		result := tx.Where("project_id = ? AND student_id = ?", id, studentId).Delete(&models.ProjectStudent{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: student %d", ErrNotAssociated, studentId)
+		}
+		return nil
+	})
+}
+
+// AddProjectStaff assigns a staff member to the project in the given role. The
+// same staff member may hold several roles on one project.
+func (r *projectRepositoryImpl) AddProjectStaff(ctx context.Context, version int, projectStaff *models.ProjectStaff) error {
+	return r.modifyProject(ctx, projectStaff.ProjectID, version, func(tx *gorm.DB, project *models.Project) error {
+		if err := checkSameProgram(tx, &models.Staff{}, "staff", projectStaff.StaffID, project.ProgramID); err != nil {
+			return err
+		}
+		if err := checkSameProgram(tx, &models.ProjectRole{}, "project role", projectStaff.ProjectRoleID, project.ProgramID); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.ProjectStaff{}).
+			Where("project_id = ? AND staff_id = ? AND project_role_id = ?", projectStaff.ProjectID, projectStaff.StaffID, projectStaff.ProjectRoleID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: staff %d", ErrAlreadyAssociated, projectStaff.StaffID)
+		}
+
+		return r.projectStaffRepo.CreateProjectStaff(ctx, tx, projectStaff)
+	})
+}
+
+// RemoveProjectStaff removes the staff member's assignments to the project,
+// or only the one in projectRoleId when it is given.
+func (r *projectRepositoryImpl) RemoveProjectStaff(ctx context.Context, id int, version int, staffId int, projectRoleId *int) error {
+	return r.modifyProject(ctx, id, version, func(tx *gorm.DB, project *models.Project) error {
+		query := tx.Where("project_id = ? AND staff_id = ?", id, staffId)
+		if projectRoleId != nil {
+			query = query.Where("project_role_id = ?", *projectRoleId)
+		}
+
+		result := query.Delete(&models.ProjectStaff{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: staff %d", ErrNotAssociated, staffId)
+		}
+		return nil
+	})
+}
+
+func (r *projectRepositoryImpl) AddProjectKeyword(ctx context.Context, id int, version int, keywordId int) error {
+	return r.modifyProject(ctx, id, version, func(tx *gorm.DB, project *models.Project) error {
+		if err := checkSameProgram(tx, &models.Keyword{}, "keyword", keywordId, project.ProgramID); err != nil {
+			return err
+		}
+
+		result := tx.Exec("INSERT INTO project_keywords (project_id, keyword_id) VALUES (?, ?) ON CONFLICT DO NOTHING", id, keywordId)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: keyword %d", ErrAlreadyAssociated, keywordId)
+		}
+		return nil
+	})
+}
+
+func (r *projectRepositoryImpl) RemoveProjectKeyword(ctx context.Context, id int, version int, keywordId int) error {
+	return r.modifyProject(ctx, id, version, func(tx *gorm.DB, project *models.Project) error {
+		result := tx.Where("project_id = ? AND keyword_id = ?", id, keywordId).Delete(&models.ProjectKeyword{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: keyword %d", ErrNotAssociated, keywordId)
+		}
+		return nil
+	})
+}
+
+// AddProjectResource stores a single link, or file when the resource has no
+// URL. A title that matches a current resource becomes its new version.
+func (r *projectRepositoryImpl) AddProjectResource(ctx context.Context, id int, version int, projectResource *models.ProjectResource, file *multipart.FileHeader) error {
+	var uploadedObjectNames []string
+	err := r.modifyProject(ctx, id, version, func(tx *gorm.DB, _ *models.Project) error {
+		project := &models.Project{}
+		if err := tx.Preload("Program").First(project, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		projectResource.ID = 0
+		if projectResource.URL != nil {
+			return r.processURL(ctx, tx, project, projectResource)
+		}
+		return r.processFile(ctx, tx, project, projectResource, file, &uploadedObjectNames)
+	})
+	if err != nil {
+		r.uploadRepo.DeleteUploadedFiles(ctx, r.projectBucketName, uploadedObjectNames)
+		return err
+	}
+	return nil
+}
+
+// RetireProjectResource takes a resource off the project without deleting it;
+// its versions stay available for download and restore.
+func (r *projectRepositoryImpl) RetireProjectResource(ctx context.Context, id int, version int, resourceId int) error {
+	return r.modifyProject(ctx, id, version, func(tx *gorm.DB, project *models.Project) error {
+		result := tx.Model(&models.ProjectResource{}).
+			Where("id = ? AND project_id = ? AND is_current = ?", resourceId, id, true).
+			Update("is_current", false)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: %d", ErrUnknownProjectResource, resourceId)
+		}
+		return nil
+	})
+}
+
+// DeleteProjectResource deletes one version of a resource of the project.
+// Deleting the current version makes the newest remaining one current.
+func (r *projectRepositoryImpl) DeleteProjectResource(ctx context.Context, id int, version int, resourceId int) error {
+	return r.modifyProject(ctx, id, version, func(tx *gorm.DB, _ *models.Project) error {
+		return r.resourceRepo.DeleteProjectResource(ctx, tx, id, resourceId)
+	})
+}
+
+// RestoreProjectResourceVersion makes an earlier version of a resource of the
+// project current again.
+func (r *projectRepositoryImpl) RestoreProjectResourceVersion(ctx context.Context, id int, version int, resourceId int) error {
+	return r.modifyProject(ctx, id, version, func(tx *gorm.DB, _ *models.Project) error {
+		return r.resourceRepo.RestoreProjectResourceVersion(ctx, tx, id, resourceId)
+	})
+}
@@ -6,11 +6,12 @@ import (
 
 	"github.com/project-box/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProjectNumberCounterRepository interface {
 	repository[models.ProjectNumberCounter]
-	GetNextProjectNumber(ctx context.Context, tx *gorm.DB, academicYear, semester int) (int, error)
+	GetNextProjectNumber(ctx context.Context, tx *gorm.DB, programId *int, academicYear, semester int) (int, error)
 }
 
 type projectNumberCounterRepositoryImpl struct {
@@ -25,26 +26,42 @@ func NewProjectNumberCounterRepository(db *gorm.DB) ProjectNumberCounterReposito
 	}
 }
 
-func (r *projectNumberCounterRepositoryImpl) GetNextProjectNumber(ctx context.Context, tx *gorm.DB, academicYear, semester int) (int, error) {
-	var counter models.ProjectNumberCounter
+// GetNextProjectNumber increments the program's counter for the academic year
+// and semester, or the counter shared by all programs when programId is nil.
+// The counter row stays locked until tx ends, so concurrent creates using the
+// same counter wait for each other instead of sharing a number.
+func (r *projectNumberCounterRepositoryImpl) GetNextProjectNumber(ctx context.Context, tx *gorm.DB, programId *int, academicYear, semester int) (int, error) {
 	if tx == nil {
 		tx = r.db
 	}
-	result := tx.WithContext(ctx).Where("academic_year = ? AND semester = ?", academicYear, semester).First(&counter)
-	if result.RowsAffected == 0 {
-		counter = models.ProjectNumberCounter{
+	tx = tx.WithContext(ctx)
+
+	// Number is selected explicitly so the zero value is not replaced by the
+	// column default.
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Select("Number", "AcademicYear", "Semester", "ProgramID").
+		Create(&models.ProjectNumberCounter{
 			AcademicYear: academicYear,
 			Semester:     semester,
-			Number:       1,
-		}
-		if err := tx.Create(&counter).Error; err != nil {
-			return 0, fmt.Errorf("could not create project number counter: %v", err)
-		}
-		return counter.Number, nil
+			ProgramID:    programId,
+			Number:       0,
+		}).Error; err != nil {
+		return 0, fmt.Errorf("could not create project number counter: %v", err)
+	}
+
+	scope := tx.Where("program_id IS NULL")
+	if programId != nil {
+		scope = tx.Where("program_id = ?", *programId)
+	}
+	var counter models.ProjectNumberCounter
+	if err := scope.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("academic_year = ? AND semester = ?", academicYear, semester).
+		First(&counter).Error; err != nil {
+		return 0, fmt.Errorf("could not lock project number counter: %v", err)
 	}
 
 	counter.Number++
-	if err := tx.Save(&counter).Error; err != nil {
+	if err := tx.Model(&counter).Update("number", counter.Number).Error; err != nil {
 		return 0, fmt.Errorf("could not update project number counter: %v", err)
 	}
 	return counter.Number, nil
@@ -11,6 +11,8 @@ type ProjectStaffRepository interface {
 	repository[models.ProjectStaff]
 	CreateProjectStaff(ctx context.Context, tx *gorm.DB, projectStaff *models.ProjectStaff) error
 	GetProjectStaffByProjectIdAndStaffId(ctx context.Context, projectId int, staffId int) (*models.ProjectStaff, error)
+	IsProjectAdvisor(ctx context.Context, projectId int, email string) (bool, error)
+	GetProjectStaffsByProjectIds(ctx context.Context, projectIds []int) ([]models.ProjectStaff, error)
 }
 
 type projectStaffRepositoryImpl struct {
@@ -58,3 +60,40 @@ func (r *projectStaffRepositoryImpl) GetProjectStaffByProjectIdAndStaffId(ctx co
 	}
 	return &projectStaff, nil
 }
+
+// IsProjectAdvisor reports whether the staff member with the given email is
+// assigned to the project under an advisor project role (e.g. "Advisor" or
+// "Co Advisor").
+func (r *projectStaffRepositoryImpl) IsProjectAdvisor(ctx context.Context, projectId int, email string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.ProjectStaff{}).
+		Joins("JOIN staffs ON staffs.id = project_staffs.staff_id").
+		Joins("JOIN project_roles ON project_roles.id = project_staffs.project_role_id").
+		Where("project_staffs.project_id = ?", projectId).
+		Where("LOWER(staffs.email) = LOWER(?)", email).
+		Where("project_roles.role_name_en ILIKE ?", "%advisor%").
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetProjectStaffsByProjectIds returns the committee assignments of the given
+// projects with their staff and project roles.
+func (r *projectStaffRepositoryImpl) GetProjectStaffsByProjectIds(ctx context.Context, projectIds []int) ([]models.ProjectStaff, error) {
+	var projectStaffs []models.ProjectStaff
+	if len(projectIds) == 0 {
+		return projectStaffs, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Preload("Staff").
+		Preload("ProjectRole").
+		Where("project_id IN ?", projectIds).
+		Order("id").
+		Find(&projectStaffs).Error; err != nil {
+		return nil, err
+	}
+	return projectStaffs, nil
+}
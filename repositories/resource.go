@@ -4,18 +4,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"time"
 
-	"github.com/minio/minio-go/v7"
 	"github.com/project-box/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ResourceRepository interface {
 	CreateProjectResource(ctx context.Context, tx *gorm.DB, projectResource *models.ProjectResource) error
 	FindDetailedResourceByID(ctx context.Context, id string) (*models.DetailedResource, error)
-	DeleteProjectResourceByID(ctx context.Context, id string, filePath *string) error
+	GetProjectResourceByID(ctx context.Context, id int) (*models.ProjectResource, error)
+	GetProjectResourcesByProjectId(ctx context.Context, projectId int) ([]models.ProjectResource, error)
+	GetProjectResourceVersions(ctx context.Context, id int) ([]models.ProjectResource, error)
+	// RestoreProjectResourceVersion and DeleteProjectResource change one
+	// version of a resource of the project within tx; the caller bumps the
+	// project version. A deleted version's file is left in storage for the
+	// caller to remove once the deletion is committed.
+	RestoreProjectResourceVersion(ctx context.Context, tx *gorm.DB, projectId int, id int) error
+	DeleteProjectResource(ctx context.Context, tx *gorm.DB, projectId int, id int) error
 }
 
 type resourceRepository struct {
@@ -23,14 +30,16 @@ type resourceRepository struct {
 	resourceTypeRepo  ResourceTypeRepository
 	fileExtensionRepo FileExtensionRepository
 	uploadRepo        UploadRepository
+	outboxRepo        OutboxRepository
 }
 
-func NewResourceRepository(db *gorm.DB, resourceTypeRepo ResourceTypeRepository, fileExtensionRepo FileExtensionRepository, uploadRepo UploadRepository) ResourceRepository {
+func NewResourceRepository(db *gorm.DB, resourceTypeRepo ResourceTypeRepository, fileExtensionRepo FileExtensionRepository, uploadRepo UploadRepository, outboxRepo OutboxRepository) ResourceRepository {
 	return &resourceRepository{
 		db:                db,
 		resourceTypeRepo:  resourceTypeRepo,
 		uploadRepo:        uploadRepo,
 		fileExtensionRepo: fileExtensionRepo,
+		outboxRepo:        outboxRepo,
 	}
 }
 
@@ -56,55 +65,168 @@ func (r *resourceRepository) buildFilePath(bucket, objectName string) string {
 func (r *resourceRepository) FindDetailedResourceByID(ctx context.Context, id string) (*models.DetailedResource, error) {
 	var detailedResource models.DetailedResource
 
+	// DetailedResource embeds Project, so gorm would scope the query to
+	// project_resources.deleted_at; filter deleted projects by hand instead.
 	query := r.db.WithContext(ctx).
+		Unscoped().
 		Table("project_resources").
 		Select(`
         projects.id AS project_id,
         project_resources.id AS project_resource_id,
         projects.*,
-        project_resources.*,
+        project_resources.*
     `).
 		Joins("LEFT JOIN projects ON project_resources.project_id IS NOT NULL AND projects.id = project_resources.project_id")
 
-	if err := query.Where("project_resources.id = ?", id).
-		Scan(&detailedResource).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("resource not found")
-		}
-		return nil, err
+	result := query.Where("project_resources.id = ?", id).
+		Where("projects.id IS NULL OR projects.deleted_at IS NULL").
+		Scan(&detailedResource)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
 	}
 
 	return &detailedResource, nil
 }
 
-func (r *resourceRepository) DeleteProjectResourceByID(ctx context.Context, id string, filePath *string) error {
-	tx := r.db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return tx.Error
+// GetProjectResourceByID loads a resource together with the project it
+// belongs to, its type and file extension. Resources of projects in the trash
+// are not found.
+func (r *resourceRepository) GetProjectResourceByID(ctx context.Context, id int) (*models.ProjectResource, error) {
+	var projectResource models.ProjectResource
+	if err := r.db.WithContext(ctx).
+		InnerJoins("Project").
+		Preload("ResourceType").
+		Preload("FileExtension").
+		First(&projectResource, "project_resources.id = ?", id).Error; err != nil {
+		return nil, err
 	}
+	return &projectResource, nil
+}
 
-	if result := tx.Delete(&models.ProjectResource{}, id); result.Error != nil {
-		tx.Rollback()
-		return result.Error
-	}
+func (r *resourceRepository) DeleteProjectResource(ctx context.Context, tx *gorm.DB, projectId int, id int) error {
+	db := tx.WithContext(ctx)
 
-	if filePath != nil {
-		if err := r.uploadRepo.DeleteUploadedFile(ctx, os.Getenv("MINIO_PROJECT_BUCKET"), *filePath, minio.RemoveObjectOptions{}); err != nil {
-			tx.Rollback()
-			return err
-		}
+	var projectResource models.ProjectResource
+	if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "project_id", "title", "is_current").
+		First(&projectResource, "id = ? AND project_id = ?", id, projectId).Error; err != nil {
+		return err
 	}
 
-	if err := tx.Commit().Error; err != nil {
+	if err := db.Delete(&models.ProjectResource{}, id).Error; err != nil {
 		return err
 	}
 
+	// Deleting the current version brings back the newest remaining one.
+	if projectResource.IsCurrent && projectResource.Title != nil {
+		return r.promoteLatestVersion(db, projectResource.ProjectID, *projectResource.Title)
+	}
 	return nil
 }
 
+// CreateProjectResource stores projectResource as the newest, current version
+// of its title within the project. Resources without a title have no history.
 func (r *resourceRepository) CreateProjectResource(ctx context.Context, tx *gorm.DB, projectResource *models.ProjectResource) error {
-	if err := tx.WithContext(ctx).Create(projectResource).Error; err != nil {
+	db := tx.WithContext(ctx)
+
+	projectResource.Version = 1
+	projectResource.IsCurrent = true
+	if projectResource.Title != nil {
+		var latest int
+		if err := db.Model(&models.ProjectResource{}).
+			Where("project_id = ? AND title = ?", projectResource.ProjectID, *projectResource.Title).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+		projectResource.Version = latest + 1
+
+		if err := db.Model(&models.ProjectResource{}).
+			Where("project_id = ? AND title = ? AND is_current = ?", projectResource.ProjectID, *projectResource.Title, true).
+			Update("is_current", false).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := db.Create(projectResource).Error; err != nil {
 		return err
 	}
 	return nil
 }
+
+// GetProjectResourcesByProjectId returns every version of every resource of
+// the project.
+func (r *resourceRepository) GetProjectResourcesByProjectId(ctx context.Context, projectId int) ([]models.ProjectResource, error) {
+	var projectResources []models.ProjectResource
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ?", projectId).
+		Order("id").
+		Find(&projectResources).Error; err != nil {
+		return nil, err
+	}
+	return projectResources, nil
+}
+
+// GetProjectResourceVersions returns the version history the resource belongs
+// to, newest first.
+func (r *resourceRepository) GetProjectResourceVersions(ctx context.Context, id int) ([]models.ProjectResource, error) {
+	projectResource := &models.ProjectResource{}
+	if err := r.db.WithContext(ctx).First(projectResource, id).Error; err != nil {
+		return nil, err
+	}
+	if projectResource.Title == nil {
+		return []models.ProjectResource{*projectResource}, nil
+	}
+
+	var versions []models.ProjectResource
+	if err := r.db.WithContext(ctx).
+		Preload("ResourceType").
+		Preload("FileExtension").
+		Where("project_id = ? AND title = ?", projectResource.ProjectID, *projectResource.Title).
+		Order("version DESC").
+		Find(&versions).Error; err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// RestoreProjectResourceVersion makes the given version the current one of
+// its title. The file is not copied; the version simply becomes current again.
+func (r *resourceRepository) RestoreProjectResourceVersion(ctx context.Context, tx *gorm.DB, projectId int, id int) error {
+	db := tx.WithContext(ctx)
+
+	projectResource := &models.ProjectResource{}
+	if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(projectResource, "id = ? AND project_id = ?", id, projectId).Error; err != nil {
+		return err
+	}
+	if projectResource.IsCurrent {
+		return nil
+	}
+
+	if projectResource.Title != nil {
+		if err := db.Model(&models.ProjectResource{}).
+			Where("project_id = ? AND title = ? AND is_current = ?", projectResource.ProjectID, *projectResource.Title, true).
+			Update("is_current", false).Error; err != nil {
+			return err
+		}
+	}
+	return db.Model(projectResource).Update("is_current", true).Error
+}
+
+func (r *resourceRepository) promoteLatestVersion(tx *gorm.DB, projectId int, title string) error {
+	latest := &models.ProjectResource{}
+	err := tx.Where("project_id = ? AND title = ?", projectId, title).
+		Order("version DESC").
+		First(latest).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Model(latest).Update("is_current", true).Error
+}
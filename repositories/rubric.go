@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/project-box/models"
+	"gorm.io/gorm"
+)
+
+type RubricRepository interface {
+	GetRubricsByProgramId(ctx context.Context, programId int) ([]models.Rubric, error)
+	GetRubricByID(ctx context.Context, id int) (*models.Rubric, error)
+	CreateRubric(ctx context.Context, rubric *models.Rubric) error
+	UpdateRubric(ctx context.Context, rubric *models.Rubric) error
+	DeleteRubric(ctx context.Context, id int) error
+	HasEvaluations(ctx context.Context, rubricId int) (bool, error)
+}
+
+type rubricRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRubricRepository(db *gorm.DB) RubricRepository {
+	return &rubricRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *rubricRepositoryImpl) preloadRubric(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Program").
+		Preload("Criteria", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order, id")
+		}).
+		Preload("RoleWeights.ProjectRole")
+}
+
+func (r *rubricRepositoryImpl) GetRubricsByProgramId(ctx context.Context, programId int) ([]models.Rubric, error) {
+	var rubrics []models.Rubric
+	if err := r.preloadRubric(r.db.WithContext(ctx)).
+		Where("program_id = ?", programId).
+		Order("id").
+		Find(&rubrics).Error; err != nil {
+		return nil, err
+	}
+	return rubrics, nil
+}
+
+func (r *rubricRepositoryImpl) GetRubricByID(ctx context.Context, id int) (*models.Rubric, error) {
+	rubric := &models.Rubric{}
+	if err := r.preloadRubric(r.db.WithContext(ctx)).First(rubric, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return rubric, nil
+}
+
+func (r *rubricRepositoryImpl) CreateRubric(ctx context.Context, rubric *models.Rubric) error {
+	return r.db.WithContext(ctx).Omit("Program", "RoleWeights.ProjectRole").Create(rubric).Error
+}
+
+// UpdateRubric saves the rubric's name and description, updates criteria that
+// carry an ID, creates the ones without and deletes criteria that are no
+// longer listed. Role weights are replaced as a whole.
+func (r *rubricRepositoryImpl) UpdateRubric(ctx context.Context, rubric *models.Rubric) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Rubric{ID: rubric.ID}).
+			Select("Name", "Description").
+			Updates(rubric).Error; err != nil {
+			return err
+		}
+
+		keepIDs := []int{}
+		for i := range rubric.Criteria {
+			criterion := &rubric.Criteria[i]
+			criterion.RubricID = rubric.ID
+			if criterion.ID == 0 {
+				if err := tx.Create(criterion).Error; err != nil {
+					return err
+				}
+			} else if err := tx.Model(criterion).
+				Select("Name", "Description", "Weight", "MinScore", "MaxScore", "SortOrder").
+				Updates(criterion).Error; err != nil {
+				return err
+			}
+			keepIDs = append(keepIDs, criterion.ID)
+		}
+		if err := tx.Where("rubric_id = ? AND id NOT IN ?", rubric.ID, keepIDs).
+			Delete(&models.RubricCriterion{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("rubric_id = ?", rubric.ID).Delete(&models.RubricRoleWeight{}).Error; err != nil {
+			return err
+		}
+		for i := range rubric.RoleWeights {
+			rubric.RoleWeights[i].ID = 0
+			rubric.RoleWeights[i].RubricID = rubric.ID
+		}
+		if len(rubric.RoleWeights) > 0 {
+			if err := tx.Omit("ProjectRole").Create(&rubric.RoleWeights).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *rubricRepositoryImpl) DeleteRubric(ctx context.Context, id int) error {
+	result := r.db.WithContext(ctx).Delete(&models.Rubric{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *rubricRepositoryImpl) HasEvaluations(ctx context.Context, rubricId int) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.ProjectEvaluation{}).
+		Where("rubric_id = ?", rubricId).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -17,8 +17,10 @@ type StaffRepository interface {
 	GetAllStaffByProgramId(ctx context.Context, programId int) ([]models.Staff, error)
 	CreateStaff(ctx context.Context, staff *models.Staff) (*models.Staff, error)
 	CreateStaffs(ctx context.Context, staffs []models.Staff) error
+	UpsertStaffs(ctx context.Context, staffs []models.Staff) ([]models.Staff, error)
 	UpdateStaff(updatedStaff *models.Staff) (*models.Staff, error)
 	GetByEmail(ctx context.Context, email string) (*models.Staff, error)
+	GetByEmailAndProgramId(ctx context.Context, email string, programId int) (*models.Staff, error)
 }
 
 type staffRepositoryImpl struct {
@@ -87,6 +89,41 @@ func (r *staffRepositoryImpl) GetByEmail(ctx context.Context, email string) (*mo
 	return &staff, nil
 }
 
+func (r *staffRepositoryImpl) GetByEmailAndProgramId(ctx context.Context, email string, programId int) (*models.Staff, error) {
+	var staff models.Staff
+	if err := r.db.WithContext(ctx).Where("email = ? AND program_id = ?", email, programId).Preload("Program").First(&staff).Error; err != nil {
+		return nil, err
+	}
+	return &staff, nil
+}
+
+func (r *staffRepositoryImpl) UpsertStaffs(ctx context.Context, staffs []models.Staff) ([]models.Staff, error) {
+	if len(staffs) == 0 {
+		return nil, nil
+	}
+
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	var upsertedStaffs []models.Staff
+	for _, staff := range staffs {
+		upsertedStaff, err := r.upsertStaff(ctx, tx, staff)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		upsertedStaffs = append(upsertedStaffs, *upsertedStaff)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return upsertedStaffs, nil
+}
+
 func (r *staffRepositoryImpl) CreateStaffs(ctx context.Context, staffs []models.Staff) error {
 	if len(staffs) == 0 {
 		return nil
@@ -114,13 +151,14 @@ func (r *staffRepositoryImpl) createStaff(ctx context.Context, tx *gorm.DB, staf
 	return tx.Save(&staff).Error
 }
 
-func (r *staffRepositoryImpl) upsertStaff(ctx context.Context, tx *gorm.DB, staff models.Staff) error {
-	existingStaff, err := r.GetByEmail(ctx, staff.Email)
+func (r *staffRepositoryImpl) upsertStaff(ctx context.Context, tx *gorm.DB, staff models.Staff) (*models.Staff, error) {
+	var existingStaff models.Staff
+	err := tx.WithContext(ctx).Where("email = ? AND program_id = ?", staff.Email, staff.ProgramID).First(&existingStaff).Error
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return err
+		return nil, err
 	}
 
-	if existingStaff != nil {
+	if err == nil {
 		existingStaff.PrefixTH = staff.PrefixTH
 		existingStaff.PrefixEN = staff.PrefixEN
 		existingStaff.FirstNameTH = staff.FirstNameTH
@@ -129,10 +167,16 @@ func (r *staffRepositoryImpl) upsertStaff(ctx context.Context, tx *gorm.DB, staf
 		existingStaff.LastNameEN = staff.LastNameEN
 		existingStaff.ProgramID = staff.ProgramID
 		existingStaff.IsActive = staff.IsActive
-		return tx.Save(existingStaff).Error
+		if err := tx.Save(&existingStaff).Error; err != nil {
+			return nil, err
+		}
+		return &existingStaff, nil
 	}
 
-	return tx.Create(&staff).Error
+	if err := tx.Create(&staff).Error; err != nil {
+		return nil, err
+	}
+	return &staff, nil
 }
 
 func (r *staffRepositoryImpl) GetStaffByFirstNameAndLastName(ctx context.Context, firstName, lastName string) (*models.Staff, error) {
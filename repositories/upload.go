@@ -6,33 +6,35 @@ import (
 	"mime/multipart"
 	"strings"
 
-	"github.com/minio/minio-go/v7"
+	storage "github.com/project-box/db/storage"
 )
 
 type UploadRepository interface {
-	UploadFile(ctx context.Context, bucketName string, objectName string, file *multipart.FileHeader, options minio.PutObjectOptions) error
-	DeleteUploadedFiles(ctx context.Context, bucketName string, objectNames []string, removeOptions minio.RemoveObjectOptions) error
-	DeleteUploadedFile(ctx context.Context, bucketName string, objectName string, removeOptions minio.RemoveObjectOptions) error
+	UploadFile(ctx context.Context, bucketName string, objectName string, file *multipart.FileHeader) error
+	DeleteUploadedFiles(ctx context.Context, bucketName string, objectNames []string) error
+	DeleteUploadedFile(ctx context.Context, bucketName string, objectName string) error
 }
 
 type uploadRepositoryImpl struct {
-	minioClient *minio.Client
+	storage storage.ObjectStorage
 }
 
-func NewUploadRepository(minioClient *minio.Client) UploadRepository {
+func NewUploadRepository(objectStorage storage.ObjectStorage) UploadRepository {
 	return &uploadRepositoryImpl{
-		minioClient: minioClient,
+		storage: objectStorage,
 	}
 }
 
-func (r *uploadRepositoryImpl) UploadFile(ctx context.Context, bucketName string, objectName string, file *multipart.FileHeader, options minio.PutObjectOptions) error {
+func (r *uploadRepositoryImpl) UploadFile(ctx context.Context, bucketName string, objectName string, file *multipart.FileHeader) error {
 	src, err := file.Open()
 	if err != nil {
 		return err
 	}
 	defer src.Close()
 
-	_, err = r.minioClient.PutObject(ctx, bucketName, objectName, src, file.Size, options)
+	_, err = r.storage.PutObject(ctx, bucketName, objectName, src, file.Size, storage.PutOptions{
+		ContentType: file.Header.Get("Content-Type"),
+	})
 	if err != nil {
 		return err
 	}
@@ -40,11 +42,11 @@ func (r *uploadRepositoryImpl) UploadFile(ctx context.Context, bucketName string
 	return nil
 }
 
-func (r *uploadRepositoryImpl) DeleteUploadedFiles(ctx context.Context, bucketName string, objectNames []string, removeOptions minio.RemoveObjectOptions) error {
+func (r *uploadRepositoryImpl) DeleteUploadedFiles(ctx context.Context, bucketName string, objectNames []string) error {
 	var allErrors []string
 
 	for _, objectName := range objectNames {
-		err := r.deleteFile(ctx, bucketName, objectName, removeOptions)
+		err := r.deleteFile(ctx, bucketName, objectName)
 		if err != nil {
 			allErrors = append(allErrors, fmt.Sprintf("Failed to delete file %s: %v", objectName, err))
 		}
@@ -56,12 +58,12 @@ func (r *uploadRepositoryImpl) DeleteUploadedFiles(ctx context.Context, bucketNa
 	return nil
 }
 
-func (r *uploadRepositoryImpl) DeleteUploadedFile(ctx context.Context, bucketName string, objectName string, removeOptions minio.RemoveObjectOptions) error {
-	return r.deleteFile(ctx, bucketName, objectName, removeOptions)
+func (r *uploadRepositoryImpl) DeleteUploadedFile(ctx context.Context, bucketName string, objectName string) error {
+	return r.deleteFile(ctx, bucketName, objectName)
 }
 
-func (r *uploadRepositoryImpl) deleteFile(ctx context.Context, bucketName string, objectName string, removeOptions minio.RemoveObjectOptions) error {
-	err := r.minioClient.RemoveObject(ctx, bucketName, objectName, removeOptions)
+func (r *uploadRepositoryImpl) deleteFile(ctx context.Context, bucketName string, objectName string) error {
+	err := r.storage.RemoveObject(ctx, bucketName, objectName)
 	if err != nil {
 		return fmt.Errorf("failed to delete file %s: %w", objectName, err)
 	}
@@ -0,0 +1,16 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
+	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
+)
+
+func SetupAuditRouter(r *gin.RouterGroup, handler handlers.AuditHandler) {
+	auditRouteV1 := r.Group("/v1/audit", middlewares.RequireRoles(auth.RoleProgramStaff))
+	{
+		auditRouteV1.GET("", handler.GetAuditLogs)
+		auditRouteV1.GET("/export", handler.ExportAuditLogs)
+	}
+}
@@ -2,7 +2,9 @@ package routers
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
 	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
 )
 
 func SetupConfigRouter(r *gin.RouterGroup, handler handlers.ConfigHandler) {
@@ -10,7 +12,7 @@ func SetupConfigRouter(r *gin.RouterGroup, handler handlers.ConfigHandler) {
 	{
 		configRouteV1.GET("/academic-years", handler.GetAllAcademicYear)
 		configRouteV1.GET("/program/:program_id", handler.GetConfigByProgramId)
-		configRouteV1.PUT("", handler.UpsertConfig)
-		configRouteV1.DELETE(":id", handler.DeleteConfig)
+		configRouteV1.PUT("", middlewares.RequireRoles(auth.RoleProgramStaff), handler.UpsertConfig)
+		configRouteV1.DELETE(":id", middlewares.RequireRoles(auth.RoleProgramStaff), handler.DeleteConfig)
 	}
 }
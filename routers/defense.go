@@ -0,0 +1,27 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
+	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
+)
+
+func SetupDefenseRouter(r *gin.RouterGroup, handler handlers.DefenseHandler) {
+	defenseRouteV1 := r.Group("/v1/defenses")
+	{
+		defenseRouteV1.GET("/rooms", handler.GetRooms)
+		defenseRouteV1.POST("/rooms", middlewares.RequireRoles(auth.RoleProgramStaff), handler.CreateRoom)
+		defenseRouteV1.DELETE("/rooms/:id", middlewares.RequireRoles(auth.RoleProgramStaff), handler.DeleteRoom)
+		defenseRouteV1.GET("/rooms/:id/calendar.ics", handler.GetRoomCalendar)
+		defenseRouteV1.GET("/slots", handler.GetSlots)
+		defenseRouteV1.POST("/slots", middlewares.RequireRoles(auth.RoleProgramStaff), handler.CreateSlot)
+		defenseRouteV1.DELETE("/slots/:id", middlewares.RequireRoles(auth.RoleProgramStaff), handler.DeleteSlot)
+		defenseRouteV1.GET("/staffs/:staff_id/calendar.ics", handler.GetStaffCalendar)
+		defenseRouteV1.POST("/proposals", middlewares.RequireRoles(auth.RoleProgramStaff), handler.ProposeDefenses)
+		defenseRouteV1.GET("", handler.GetDefenses)
+		defenseRouteV1.POST("", middlewares.RequireRoles(auth.RoleProgramStaff), handler.ScheduleDefense)
+		defenseRouteV1.GET("/:id", handler.GetDefense)
+		defenseRouteV1.DELETE("/:id", middlewares.RequireRoles(auth.RoleProgramStaff), handler.CancelDefense)
+	}
+}
@@ -0,0 +1,21 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
+	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
+)
+
+func SetupEvaluationRouter(r *gin.RouterGroup, handler handlers.EvaluationHandler) {
+	projectRouteV1 := r.Group("/v1/projects")
+	{
+		projectRouteV1.PUT("/:id/evaluations", middlewares.RequireRoles(auth.RoleProgramStaff, auth.RoleAdvisor), handler.SubmitEvaluation)
+		projectRouteV1.GET("/:id/scores", middlewares.RequireRoles(auth.RoleProgramStaff), handler.GetProjectScore)
+	}
+
+	rubricRouteV1 := r.Group("/v1/rubrics")
+	{
+		rubricRouteV1.GET("/:id/scores", middlewares.RequireRoles(auth.RoleProgramStaff), handler.GetRubricScores)
+	}
+}
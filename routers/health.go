@@ -0,0 +1,13 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/handlers"
+)
+
+// SetupHealthRouter mounts the Kubernetes probes. They are polled without
+// credentials, so they sit outside the authenticated /api group.
+func SetupHealthRouter(r *gin.Engine, handler handlers.HealthHandler) {
+	r.GET("/healthz", handler.Healthz)
+	r.GET("/readyz", handler.Readyz)
+}
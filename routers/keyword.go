@@ -2,7 +2,9 @@ package routers
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
 	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
 )
 
 func SetupKeywordRouter(r *gin.RouterGroup, handler handlers.KeywordHandler) {
@@ -11,8 +13,8 @@ func SetupKeywordRouter(r *gin.RouterGroup, handler handlers.KeywordHandler) {
 		keywordRouteV1.GET("/all", handler.GetAllKeywords)
 		keywordRouteV1.GET("", handler.GetKeywords)
 		keywordRouteV1.GET("/:id", handler.GetKeyword)
-		keywordRouteV1.POST("", handler.CreateKeyword)
-		keywordRouteV1.PUT("", handler.UpdateKeyword)
-		keywordRouteV1.DELETE("/:id", handler.DeleteKeyword)
+		keywordRouteV1.POST("", middlewares.RequireRoles(auth.RoleProgramStaff), handler.CreateKeyword)
+		keywordRouteV1.PUT("", middlewares.RequireRoles(auth.RoleProgramStaff), handler.UpdateKeyword)
+		keywordRouteV1.DELETE("/:id", middlewares.RequireRoles(auth.RoleProgramStaff), handler.DeleteKeyword)
 	}
 }
@@ -0,0 +1,12 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// SetupMetricsRouter exposes the Prometheus metrics for scraping. Like the
+// health probes it sits outside the authenticated /api group.
+func SetupMetricsRouter(r *gin.Engine) {
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+}
@@ -2,15 +2,17 @@ package routers
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
 	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
 )
 
 func SetUpProgramRoute(r *gin.RouterGroup, handler handlers.ProgramHandler) {
 	programRouteV1 := r.Group("/v1/programs")
 	{
 
-		programRouteV1.POST("", handler.CreateProgram)
+		programRouteV1.POST("", middlewares.RequireRoles(auth.RoleAdmin), handler.CreateProgram)
 		programRouteV1.GET("", handler.GetPrograms)
-		programRouteV1.PUT("", handler.UpdateProgram)
+		programRouteV1.PUT("", middlewares.RequireRoles(auth.RoleAdmin), handler.UpdateProgram)
 	}
 }
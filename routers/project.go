@@ -2,14 +2,33 @@ package routers
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
 	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
 )
 
 func SetupProjectRouter(r *gin.RouterGroup, handler handlers.ProjectHandler) {
 	projectRouteV1 := r.Group("/v1/projects")
 	{
-		projectRouteV1.POST("", handler.CreateProject)
-		projectRouteV1.PUT("", handler.UpdateProject)
-		projectRouteV1.DELETE("/:id", handler.DeleteProject)
+		projectRouteV1.GET("", handler.GetProjects)
+		projectRouteV1.GET("/:id", handler.GetProjectByID)
+		projectRouteV1.POST("", middlewares.RequireRoles(auth.RoleProgramStaff, auth.RoleAdvisor, auth.RoleStudent), handler.CreateProject)
+		projectRouteV1.PUT("", middlewares.RequireRoles(auth.RoleProgramStaff, auth.RoleAdvisor, auth.RoleStudent), handler.UpdateProject)
+		projectRouteV1.DELETE("/:id", middlewares.RequireRoles(auth.RoleProgramStaff), handler.DeleteProject)
+		projectRouteV1.GET("/:id/status-history", handler.GetProjectStatusHistory)
+		projectRouteV1.POST("/:id/approve", middlewares.RequireRoles(auth.RoleAdvisor), handler.ApproveProject)
+		projectRouteV1.POST("/:id/reject", middlewares.RequireRoles(auth.RoleAdvisor), handler.RejectProject)
+		projectRouteV1.POST("/:id/status", middlewares.RequireRoles(auth.RoleProgramStaff, auth.RoleAdvisor), handler.TransitionProjectStatus)
+
+		editRoute := projectRouteV1.Group("/:id", middlewares.RequireRoles(auth.RoleProgramStaff, auth.RoleAdvisor, auth.RoleStudent))
+		editRoute.PATCH("", handler.PatchProject)
+		editRoute.POST("/members", handler.AddProjectMember)
+		editRoute.DELETE("/members/:student_id", handler.RemoveProjectMember)
+		editRoute.POST("/staffs", handler.AddProjectStaff)
+		editRoute.DELETE("/staffs/:staff_id", handler.RemoveProjectStaff)
+		editRoute.POST("/keywords", handler.AddProjectKeyword)
+		editRoute.DELETE("/keywords/:keyword_id", handler.RemoveProjectKeyword)
+		editRoute.POST("/resources", handler.AddProjectResource)
+		editRoute.DELETE("/resources/:resource_id", handler.RetireProjectResource)
 	}
 }
@@ -2,7 +2,9 @@ package routers
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
 	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
 )
 
 func SetupProjectConfigRouter(r *gin.RouterGroup, handler handlers.ProjectConfigHandler) {
@@ -10,6 +12,6 @@ func SetupProjectConfigRouter(r *gin.RouterGroup, handler handlers.ProjectConfig
 	{
 
 		projectconfigRouteV1.GET("/program/:program_id", handler.GetProjectConfigByProgramId)
-		projectconfigRouteV1.PUT("", handler.UpsertProjectConfig)
+		projectconfigRouteV1.PUT("", middlewares.RequireRoles(auth.RoleProgramStaff), handler.UpsertProjectConfig)
 	}
 }
@@ -0,0 +1,15 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
+	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
+)
+
+func SetupProjectTrashRouter(r *gin.RouterGroup, handler handlers.ProjectTrashHandler) {
+	requireProgramStaff := middlewares.RequireRoles(auth.RoleProgramStaff)
+
+	r.GET("/v1/programs/:id/trash", requireProgramStaff, handler.GetTrash)
+	r.POST("/v1/projects/:id/restore", requireProgramStaff, handler.RestoreProject)
+}
@@ -0,0 +1,19 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
+	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
+)
+
+func SetupProjectUploadRouter(r *gin.RouterGroup, handler handlers.ProjectUploadHandler) {
+	projectUploadRouteV1 := r.Group("/v1/projects", middlewares.RequireRoles(auth.RoleProgramStaff, auth.RoleAdvisor, auth.RoleStudent))
+	{
+		projectUploadRouteV1.POST("/uploads", handler.RequestUploads)
+		projectUploadRouteV1.POST("/uploads/resumable", handler.StartResumableUpload)
+		projectUploadRouteV1.GET("/uploads/:id", handler.GetResumableUpload)
+		projectUploadRouteV1.PATCH("/uploads/:id", handler.WriteUploadChunk)
+		projectUploadRouteV1.POST("/finalize", handler.FinalizeProject)
+	}
+}
@@ -0,0 +1,16 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
+	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
+)
+
+func SetupReindexRouter(r *gin.RouterGroup, handler handlers.ReindexHandler) {
+	reindexRouteV1 := r.Group("/v1/admin/reindex", middlewares.RequireRoles(auth.RoleAdmin))
+	{
+		reindexRouteV1.POST("", handler.StartReindex)
+		reindexRouteV1.GET("", handler.GetReindexStatus)
+	}
+}
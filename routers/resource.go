@@ -2,13 +2,18 @@ package routers
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
 	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
 )
 
 func SetupResourceRouter(r *gin.RouterGroup, handler handlers.ResourceHandler) {
 	projectResourceRouteV1 := r.Group("/v1/projectResources")
 	{
-		projectResourceRouteV1.DELETE("/:id", handler.DeleteProjectResource)
+		projectResourceRouteV1.GET("/:id/download", handler.DownloadProjectResource)
+		projectResourceRouteV1.GET("/:id/versions", handler.GetProjectResourceVersions)
+		projectResourceRouteV1.POST("/:id/restore", middlewares.RequireRoles(auth.RoleProgramStaff, auth.RoleAdvisor, auth.RoleStudent), handler.RestoreProjectResourceVersion)
+		projectResourceRouteV1.DELETE("/:id", middlewares.RequireRoles(auth.RoleProgramStaff, auth.RoleAdvisor, auth.RoleStudent), handler.DeleteProjectResource)
 	}
 
 }
@@ -4,21 +4,29 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	storage "github.com/project-box/db/storage"
 	_ "github.com/project-box/docs"
 	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 func SetupRoutes(r *gin.Engine, projectHandler handlers.ProjectHandler, resourceHandler handlers.ResourceHandler, staffHandler handlers.StaffHandler,
-	configHandler handlers.ConfigHandler, projectConfigHandler handlers.ProjectConfigHandler, projectResourceConfigHandler handlers.ProjectResourceConfigHandler, projectRoleHandler handlers.ProjectRoleHandler, programHandler handlers.ProgramHandler, studentHandler handlers.StudentHandler, uploadHandler handlers.UploadHandler, keywordHandler handlers.KeywordHandler) {
-	r.NoRoute(func(ctx *gin.Context) {
+	configHandler handlers.ConfigHandler, projectConfigHandler handlers.ProjectConfigHandler, projectResourceConfigHandler handlers.ProjectResourceConfigHandler, projectRoleHandler handlers.ProjectRoleHandler, programHandler handlers.ProgramHandler, studentHandler handlers.StudentHandler, uploadHandler handlers.UploadHandler, keywordHandler handlers.KeywordHandler, reindexHandler handlers.ReindexHandler, rubricHandler handlers.RubricHandler, evaluationHandler handlers.EvaluationHandler, defenseHandler handlers.DefenseHandler, projectUploadHandler handlers.ProjectUploadHandler, projectTrashHandler handlers.ProjectTrashHandler, auditHandler handlers.AuditHandler, healthHandler handlers.HealthHandler, objectStorage storage.ObjectStorage, authMiddleware middlewares.AuthMiddleware) {
+	r.GET("/", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, gin.H{
 			"message": "Welcome to the api",
 		})
 	})
+	r.NoRoute(func(ctx *gin.Context) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Route not found"})
+	})
+	SetupHealthRouter(r, healthHandler)
+	SetupMetricsRouter(r)
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-	router := r.Group("/api")
+	SetupStorageRouter(r, objectStorage)
+	router := r.Group("/api", authMiddleware.Authenticate())
 	SetupKeywordRouter(router, keywordHandler)
 	SetupProjectRouter(router, projectHandler)
 	SetupResourceRouter(router, resourceHandler)
@@ -30,4 +38,11 @@ func SetupRoutes(r *gin.Engine, projectHandler handlers.ProjectHandler, resource
 	SetUpProgramRoute(router, programHandler)
 	SetupStudentRouter(router, studentHandler)
 	SetupUploadRouter(router, uploadHandler)
+	SetupReindexRouter(router, reindexHandler)
+	SetupRubricRouter(router, rubricHandler)
+	SetupEvaluationRouter(router, evaluationHandler)
+	SetupDefenseRouter(router, defenseHandler)
+	SetupProjectUploadRouter(router, projectUploadHandler)
+	SetupProjectTrashRouter(router, projectTrashHandler)
+	SetupAuditRouter(router, auditHandler)
 }
@@ -0,0 +1,19 @@
+package routers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
+	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
+)
+
+func SetupRubricRouter(r *gin.RouterGroup, handler handlers.RubricHandler) {
+	rubricRouteV1 := r.Group("/v1/rubrics")
+	{
+		rubricRouteV1.GET("", handler.GetRubrics)
+		rubricRouteV1.GET("/:id", handler.GetRubric)
+		rubricRouteV1.POST("", middlewares.RequireRoles(auth.RoleProgramStaff), handler.CreateRubric)
+		rubricRouteV1.PUT("/:id", middlewares.RequireRoles(auth.RoleProgramStaff), handler.UpdateRubric)
+		rubricRouteV1.DELETE("/:id", middlewares.RequireRoles(auth.RoleProgramStaff), handler.DeleteRubric)
+	}
+}
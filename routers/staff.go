@@ -2,7 +2,9 @@ package routers
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
 	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
 )
 
 func SetupStaffRouter(r *gin.RouterGroup, handler handlers.StaffHandler) {
@@ -11,8 +13,8 @@ func SetupStaffRouter(r *gin.RouterGroup, handler handlers.StaffHandler) {
 		staffRouteV1.GET("/:id", handler.GetStaffById)
 		staffRouteV1.GET("/program/:program_id", handler.GetStaffByProgramId)
 		staffRouteV1.GET("/email/:email", handler.GetStaffByEmail)
-		staffRouteV1.POST("", handler.CreateStaff)
-		staffRouteV1.PUT("", handler.UpdateStaff)
+		staffRouteV1.POST("", middlewares.RequireRoles(auth.RoleProgramStaff), handler.CreateStaff)
+		staffRouteV1.PUT("", middlewares.RequireRoles(auth.RoleProgramStaff), handler.UpdateStaff)
 		staffRouteV1.GET("/GetAllStaffs", handler.GetAllStaff)
 	}
 
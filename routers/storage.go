@@ -0,0 +1,19 @@
+package routers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	storage "github.com/project-box/db/storage"
+)
+
+// SetupStorageRouter mounts the signed-URL endpoint of backends that serve
+// their own objects. The signature in the URL is the credential, so the route
+// sits outside the authenticated /api group.
+func SetupStorageRouter(r *gin.Engine, objectStorage storage.ObjectStorage) {
+	handler, ok := objectStorage.(http.Handler)
+	if !ok {
+		return
+	}
+	r.Any("/storage/*path", gin.WrapH(http.StripPrefix("/storage", handler)))
+}
@@ -2,14 +2,18 @@ package routers
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/project-box/auth"
 	"github.com/project-box/handlers"
+	"github.com/project-box/middlewares"
 )
 
 func SetupUploadRouter(r *gin.RouterGroup, handler handlers.UploadHandler) {
-	uploadRouteV1 := r.Group("/v1/uploads")
+	uploadRouteV1 := r.Group("/v1/uploads", middlewares.RequireRoles(auth.RoleProgramStaff))
 	{
 		uploadRouteV1.POST("/program/:program_id/student-enrollment", handler.UploadStudentEnrollmentFile)
 		uploadRouteV1.POST("/program/:program_id/create-project", handler.UploadCreateProjectFile)
 		uploadRouteV1.POST("/program/:program_id/create-staff", handler.UploadCreateStaffFile)
+		uploadRouteV1.GET("/jobs/:id", handler.GetImportJob)
+		uploadRouteV1.POST("/jobs/:id/retry", handler.RetryImportJob)
 	}
 }
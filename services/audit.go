@@ -0,0 +1,214 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/project-box/auth"
+	"github.com/project-box/dtos"
+	"github.com/project-box/models"
+	"github.com/project-box/repositories"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 500
+
+	// auditSystemActor is recorded for changes made by background jobs
+	// without a signed-in user, such as the trash purge.
+	auditSystemActor = "system"
+)
+
+var (
+	ErrInvalidAuditFilter = errors.New("invalid audit log filter")
+	ErrAuditForbidden     = errors.New("not allowed to read the audit log of this program")
+)
+
+// AuditChange describes one create, update or delete for the audit log.
+// Before and After are snapshots of the entity, nil where it did not exist.
+type AuditChange struct {
+	Action     string
+	EntityType string
+	EntityID   any
+	ProgramID  int
+	Before     any
+	After      any
+}
+
+type AuditService interface {
+	// Record appends changes to the audit log, attributed to the principal
+	// in ctx. The changes are already committed when it is called, so a
+	// failure to record them is logged rather than returned.
+	Record(ctx context.Context, changes ...AuditChange)
+	GetAuditLogs(ctx context.Context, filter *dtos.AuditLogFilter) (*dtos.AuditLogPage, error)
+	// ExportAuditLogs calls write for every entry matching filter, oldest
+	// first, after checking that the caller may read them.
+	ExportAuditLogs(ctx context.Context, filter *dtos.AuditLogFilter, write func(*models.AuditLog) error) error
+}
+
+type auditServiceImpl struct {
+	auditLogRepo repositories.AuditLogRepository
+	logger       *slog.Logger
+}
+
+func NewAuditService(auditLogRepo repositories.AuditLogRepository, logger *slog.Logger) AuditService {
+	return &auditServiceImpl{
+		auditLogRepo: auditLogRepo,
+		logger:       logger,
+	}
+}
+
+func (s *auditServiceImpl) Record(ctx context.Context, changes ...AuditChange) {
+	actor, actorEmail := auditSystemActor, ""
+	if principal, _ := auth.PrincipalFromContext(ctx); principal != nil && principal.Subject != "" {
+		actor, actorEmail = principal.Subject, principal.Email
+	}
+
+	logs := make([]models.AuditLog, 0, len(changes))
+	for _, change := range changes {
+		diff, err := auditDiff(change.Before, change.After)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to audit change", "action", change.Action, "entity_type", change.EntityType, "entity_id", change.EntityID, "error", err)
+			continue
+		}
+		if diff == nil && change.Action == models.AuditActionUpdate {
+			continue
+		}
+
+		entry := models.AuditLog{
+			Actor:      actor,
+			ActorEmail: actorEmail,
+			Action:     change.Action,
+			EntityType: change.EntityType,
+			EntityID:   fmt.Sprint(change.EntityID),
+			Changes:    diff,
+		}
+		if change.ProgramID != 0 {
+			programId := change.ProgramID
+			entry.ProgramID = &programId
+		}
+		logs = append(logs, entry)
+	}
+
+	// The change has happened even if the caller has gone away, so it must
+	// still be recorded.
+	if err := s.auditLogRepo.CreateAuditLogs(context.WithoutCancel(ctx), logs); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to record audit log entries", "entries", len(logs), "error", err)
+	}
+}
+
+type auditFieldChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// auditDiff compares the JSON forms of before and after field by field and
+// returns the fields that differ, or nil if none do. Nested objects are
+// preloaded associations such as a record's program; they are skipped and
+// the foreign key beside them is compared instead.
+func auditDiff(before, after any) (json.RawMessage, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := map[string]auditFieldChange{}
+	compare := func(name string) {
+		beforeValue, afterValue := auditValue(beforeFields, name), auditValue(afterFields, name)
+		if !bytes.Equal(beforeValue, afterValue) {
+			diff[name] = auditFieldChange{Before: beforeValue, After: afterValue}
+		}
+	}
+	for name := range afterFields {
+		compare(name)
+	}
+	for name := range beforeFields {
+		compare(name)
+	}
+	if len(diff) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(diff)
+}
+
+// auditValue returns the named field, treating a missing field as null.
+func auditValue(fields map[string]json.RawMessage, name string) json.RawMessage {
+	if value, ok := fields[name]; ok {
+		return value
+	}
+	return json.RawMessage("null")
+}
+
+func auditFields(snapshot any) (map[string]json.RawMessage, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("audit snapshot is not an object: %w", err)
+	}
+	for name, value := range fields {
+		if len(value) > 0 && value[0] == '{' {
+			delete(fields, name)
+		}
+	}
+	return fields, nil
+}
+
+func (s *auditServiceImpl) GetAuditLogs(ctx context.Context, filter *dtos.AuditLogFilter) (*dtos.AuditLogPage, error) {
+	if err := checkAuditAccess(ctx, filter); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditPageSize
+	}
+	if filter.Limit > maxAuditPageSize {
+		filter.Limit = maxAuditPageSize
+	}
+	if filter.Offset < 0 {
+		return nil, fmt.Errorf("%w: offset must not be negative", ErrInvalidAuditFilter)
+	}
+
+	return s.auditLogRepo.GetAuditLogs(ctx, filter)
+}
+
+func (s *auditServiceImpl) ExportAuditLogs(ctx context.Context, filter *dtos.AuditLogFilter, write func(*models.AuditLog) error) error {
+	if err := checkAuditAccess(ctx, filter); err != nil {
+		return err
+	}
+	return s.auditLogRepo.EachAuditLog(ctx, filter, write)
+}
+
+// checkAuditAccess validates filter and lets admins read the whole log and
+// program staff the entries of their own program.
+func checkAuditAccess(ctx context.Context, filter *dtos.AuditLogFilter) error {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidAuditFilter)
+	}
+
+	principal, _ := auth.PrincipalFromContext(ctx)
+	if principal.IsAdmin() {
+		return nil
+	}
+	if filter.ProgramID == nil {
+		return fmt.Errorf("%w: program_id is required", ErrInvalidAuditFilter)
+	}
+	if !principal.HasRole(*filter.ProgramID, auth.RoleProgramStaff) {
+		return ErrAuditForbidden
+	}
+	return nil
+}
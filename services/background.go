@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/project-box/configs"
+)
+
+// backgroundWorkers runs the loops of a background service and stops them in
+// two steps: the loops first stop taking new work, then the work already in
+// progress gets the configured drain timeout to finish before its context is
+// cancelled.
+type backgroundWorkers struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	stopping     chan struct{}
+	drainTimeout time.Duration
+	wg           sync.WaitGroup
+}
+
+func newBackgroundWorkers() *backgroundWorkers {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &backgroundWorkers{
+		ctx:          ctx,
+		cancel:       cancel,
+		stopping:     make(chan struct{}),
+		drainTimeout: configs.GetShutdownConfig().WorkerDrainTimeout,
+	}
+}
+
+// Go runs loop in its own goroutine. The loop must return once stopping is
+// closed and pass ctx to the work it does.
+func (w *backgroundWorkers) Go(loop func(ctx context.Context, stopping <-chan struct{})) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		loop(w.ctx, w.stopping)
+	}()
+}
+
+// Stop stops the loops and waits for them to return.
+func (w *backgroundWorkers) Stop() {
+	close(w.stopping)
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(w.drainTimeout):
+		w.cancel()
+		<-done
+	}
+	w.cancel()
+}
+
+// stopped reports whether stopping has been closed.
+func stopped(stopping <-chan struct{}) bool {
+	select {
+	case <-stopping:
+		return true
+	default:
+		return false
+	}
+}
@@ -2,19 +2,26 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"strconv"
+	"time"
 
+	"github.com/project-box/configs"
 	"github.com/project-box/dtos"
 	"github.com/project-box/models"
 	"github.com/project-box/repositories"
+	"github.com/project-box/utils"
 	"github.com/robfig/cron/v3"
+	"gorm.io/gorm"
 )
 
 type ConfigService interface {
 	GetConfigByProgramId(programId int) ([]models.Config, error)
 	GetCurrentAcademicYearAndSemester(ctx context.Context, programId int) (int, int, error)
 	GetConfigByNameAndProgramId(ctx context.Context, name string, programId int) (*models.Config, error)
+	GetConfigByID(ctx context.Context, id int) (*models.Config, error)
 	GetAllAcademicYear(ctx context.Context) ([]dtos.AcademicYearResponse, error)
 	UpsertConfig(ctx context.Context, config *models.Config) (*models.Config, error)
 	DeleteConfig(ctx context.Context, id int) error
@@ -22,18 +29,27 @@ type ConfigService interface {
 	StartCronJob()
 }
 
+var ErrInvalidConfig = errors.New("invalid config")
+
 type configServiceImpl struct {
-	configRepo repositories.ConfigRepository
-	cron       *cron.Cron
+	configRepo   repositories.ConfigRepository
+	auditService AuditService
+	logger       *slog.Logger
+	cron         *cron.Cron
 }
 
-func NewConfigService(configRepo repositories.ConfigRepository) ConfigService {
+// NewConfigService returns the config service and starts its cron
+// scheduler. The cleanup function stops the scheduler and waits for a
+// running job, up to the worker drain timeout.
+func NewConfigService(configRepo repositories.ConfigRepository, auditService AuditService, logger *slog.Logger) (ConfigService, func()) {
 	service := &configServiceImpl{
-		configRepo: configRepo,
-		cron:       cron.New(),
+		configRepo:   configRepo,
+		auditService: auditService,
+		logger:       logger,
+		cron:         cron.New(),
 	}
 	service.StartCronJob()
-	return service
+	return service, service.stopCronJob
 }
 
 func (s *configServiceImpl) GetAllAcademicYear(ctx context.Context) ([]dtos.AcademicYearResponse, error) {
@@ -75,12 +91,14 @@ func (s *configServiceImpl) IncreaseAcademicYear(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	before := *highestAcademicYearConfig
 	highestAcademicYearConfigInt++
 	highestAcademicYearConfig.Value = strconv.Itoa(highestAcademicYearConfigInt)
 	_, err = s.configRepo.Upsert(ctx, highestAcademicYearConfig)
 	if err != nil {
 		return err
 	}
+	s.auditService.Record(ctx, configAuditChange(&before, highestAcademicYearConfig))
 	return nil
 }
 
@@ -125,26 +143,88 @@ func (s *configServiceImpl) GetConfigByNameAndProgramId(ctx context.Context, nam
 	return config, nil
 }
 
+func (s *configServiceImpl) GetConfigByID(ctx context.Context, id int) (*models.Config, error) {
+	return s.configRepo.Get(ctx, id)
+}
+
 func (s *configServiceImpl) UpsertConfig(ctx context.Context, config *models.Config) (*models.Config, error) {
+	if config.ConfigName == models.ConfigProjectNumberTemplate {
+		if err := utils.ValidateProjectNumberTemplate(config.Value); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+	}
+
+	var before *models.Config
+	if config.ID != 0 {
+		existing, err := s.configRepo.Get(ctx, config.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		before = existing
+	}
+
 	config, err := s.configRepo.Upsert(ctx, config)
 	if err != nil {
 		return nil, err
 	}
+	s.auditService.Record(ctx, configAuditChange(before, config))
 	return config, nil
 }
 
 func (s *configServiceImpl) DeleteConfig(ctx context.Context, id int) error {
-	return s.configRepo.Delete(ctx, id)
+	before, err := s.configRepo.Get(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.configRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.auditService.Record(ctx, AuditChange{
+		Action:     models.AuditActionDelete,
+		EntityType: models.AuditEntityConfig,
+		EntityID:   before.ID,
+		ProgramID:  before.ProgramID,
+		Before:     before,
+	})
+	return nil
+}
+
+// configAuditChange describes an upsert of config; before is nil when it was
+// created.
+func configAuditChange(before, config *models.Config) AuditChange {
+	change := AuditChange{
+		Action:     models.AuditActionCreate,
+		EntityType: models.AuditEntityConfig,
+		EntityID:   config.ID,
+		ProgramID:  config.ProgramID,
+		After:      config,
+	}
+	if before != nil {
+		change.Action, change.Before = models.AuditActionUpdate, before
+	}
+	return change
 }
 
 func (s *configServiceImpl) StartCronJob() {
 	s.cron.AddFunc("@yearly", func() {
 		ctx := context.Background()
 		if err := s.IncreaseAcademicYear(ctx); err != nil {
-			fmt.Printf("Failed to increase academic year: %v\n", err)
+			s.logger.ErrorContext(ctx, "Failed to increase academic year", "error", err)
 		} else {
-			fmt.Println("Successfully increased academic year")
+			s.logger.InfoContext(ctx, "Successfully increased academic year")
 		}
 	})
 	s.cron.Start()
 }
+
+func (s *configServiceImpl) stopCronJob() {
+	select {
+	case <-s.cron.Stop().Done():
+	case <-time.After(configs.GetShutdownConfig().WorkerDrainTimeout):
+		s.logger.Warn("Cron job still running at shutdown")
+	}
+}
@@ -0,0 +1,516 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/project-box/auth"
+	"github.com/project-box/dtos"
+	"github.com/project-box/models"
+	"github.com/project-box/repositories"
+	"github.com/project-box/utils"
+)
+
+type DefenseService interface {
+	GetRooms(ctx context.Context, programId int) ([]models.DefenseRoom, error)
+	GetRoom(ctx context.Context, id int) (*models.DefenseRoom, error)
+	CreateRoom(ctx context.Context, req *dtos.DefenseRoomRequest) (*models.DefenseRoom, error)
+	DeleteRoom(ctx context.Context, id int) error
+	GetSlots(ctx context.Context, filter *dtos.DefenseFilter) ([]models.DefenseSlot, error)
+	GetSlot(ctx context.Context, id int) (*models.DefenseSlot, error)
+	CreateSlot(ctx context.Context, req *dtos.DefenseSlotRequest) (*models.DefenseSlot, error)
+	DeleteSlot(ctx context.Context, id int) error
+	GetDefenses(ctx context.Context, filter *dtos.DefenseFilter) ([]models.Defense, error)
+	GetDefense(ctx context.Context, id int) (*models.Defense, error)
+	ScheduleDefense(ctx context.Context, req *dtos.DefenseRequest) (*models.Defense, error)
+	CancelDefense(ctx context.Context, id int) error
+	ProposeDefenses(ctx context.Context, req *dtos.DefenseProposalRequest) (*dtos.DefenseProposalResult, error)
+	GetStaffCalendar(ctx context.Context, staffId int) ([]byte, error)
+	GetRoomCalendar(ctx context.Context, roomId int) ([]byte, error)
+}
+
+var (
+	ErrInvalidDefense        = errors.New("invalid defense")
+	ErrProjectNotSchedulable = errors.New("only projects in progress or in their defense can be scheduled")
+	ErrDefenseConflict       = errors.New("defense conflicts with existing bookings")
+)
+
+// DefenseConflictError is returned when a booking would double-book a staff
+// member, a student or a room. It matches ErrDefenseConflict.
+type DefenseConflictError struct {
+	Conflicts []dtos.DefenseConflict
+}
+
+func (e *DefenseConflictError) Error() string {
+	return fmt.Sprintf("%s: %d conflict(s)", ErrDefenseConflict, len(e.Conflicts))
+}
+
+func (e *DefenseConflictError) Is(target error) bool {
+	return target == ErrDefenseConflict
+}
+
+type defenseServiceImpl struct {
+	defenseRepo      repositories.DefenseRepository
+	projectRepo      repositories.ProjectRepository
+	projectStaffRepo repositories.ProjectStaffRepository
+}
+
+func NewDefenseService(
+	defenseRepo repositories.DefenseRepository,
+	projectRepo repositories.ProjectRepository,
+	projectStaffRepo repositories.ProjectStaffRepository,
+) DefenseService {
+	return &defenseServiceImpl{
+		defenseRepo:      defenseRepo,
+		projectRepo:      projectRepo,
+		projectStaffRepo: projectStaffRepo,
+	}
+}
+
+func (s *defenseServiceImpl) GetRooms(ctx context.Context, programId int) ([]models.DefenseRoom, error) {
+	return s.defenseRepo.GetRoomsByProgramId(ctx, programId)
+}
+
+func (s *defenseServiceImpl) GetRoom(ctx context.Context, id int) (*models.DefenseRoom, error) {
+	return s.defenseRepo.GetRoomByID(ctx, id)
+}
+
+func (s *defenseServiceImpl) CreateRoom(ctx context.Context, req *dtos.DefenseRoomRequest) (*models.DefenseRoom, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: room name is required", ErrInvalidDefense)
+	}
+	if req.Capacity != nil && *req.Capacity <= 0 {
+		return nil, fmt.Errorf("%w: capacity must be positive", ErrInvalidDefense)
+	}
+
+	room := &models.DefenseRoom{
+		Name:      name,
+		Location:  req.Location,
+		Capacity:  req.Capacity,
+		ProgramID: req.ProgramID,
+	}
+	if err := s.defenseRepo.CreateRoom(ctx, room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+func (s *defenseServiceImpl) DeleteRoom(ctx context.Context, id int) error {
+	return s.defenseRepo.DeleteRoom(ctx, id)
+}
+
+func (s *defenseServiceImpl) GetSlots(ctx context.Context, filter *dtos.DefenseFilter) ([]models.DefenseSlot, error) {
+	return s.defenseRepo.GetSlots(ctx, filter)
+}
+
+func (s *defenseServiceImpl) GetSlot(ctx context.Context, id int) (*models.DefenseSlot, error) {
+	return s.defenseRepo.GetSlotByID(ctx, id)
+}
+
+func (s *defenseServiceImpl) CreateSlot(ctx context.Context, req *dtos.DefenseSlotRequest) (*models.DefenseSlot, error) {
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidDefense)
+	}
+
+	slot := &models.DefenseSlot{
+		ProgramID:    req.ProgramID,
+		AcademicYear: req.AcademicYear,
+		Semester:     req.Semester,
+		StartsAt:     req.StartsAt,
+		EndsAt:       req.EndsAt,
+	}
+	if err := s.defenseRepo.CreateSlot(ctx, slot); err != nil {
+		return nil, err
+	}
+	return slot, nil
+}
+
+func (s *defenseServiceImpl) DeleteSlot(ctx context.Context, id int) error {
+	return s.defenseRepo.DeleteSlot(ctx, id)
+}
+
+func (s *defenseServiceImpl) GetDefenses(ctx context.Context, filter *dtos.DefenseFilter) ([]models.Defense, error) {
+	return s.defenseRepo.GetDefenses(ctx, filter)
+}
+
+func (s *defenseServiceImpl) GetDefense(ctx context.Context, id int) (*models.Defense, error) {
+	return s.defenseRepo.GetDefenseByID(ctx, id)
+}
+
+// ScheduleDefense books a room during a slot for a project, moving its
+// defense if one was booked before. The booking is rejected with a
+// DefenseConflictError when it would double-book anyone on the project's
+// committee, any of its members or the room.
+func (s *defenseServiceImpl) ScheduleDefense(ctx context.Context, req *dtos.DefenseRequest) (*models.Defense, error) {
+	project, err := s.projectRepo.Get(ctx, req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if !isSchedulable(project) {
+		return nil, ErrProjectNotSchedulable
+	}
+
+	slot, err := s.defenseRepo.GetSlotByID(ctx, req.SlotID)
+	if err != nil {
+		return nil, err
+	}
+	if slot.ProgramID != project.ProgramID || slot.AcademicYear != project.AcademicYear || slot.Semester != project.Semester {
+		return nil, fmt.Errorf("%w: slot %d is not in the project's program and semester", ErrInvalidDefense, slot.ID)
+	}
+
+	room, err := s.defenseRepo.GetRoomByID(ctx, req.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.ProgramID != project.ProgramID {
+		return nil, fmt.Errorf("%w: room %d does not belong to the project's program", ErrInvalidDefense, room.ID)
+	}
+
+	defense, err := s.book(ctx, project.ID, slot, room)
+	if err != nil {
+		return nil, err
+	}
+	return s.defenseRepo.GetDefenseByID(ctx, defense.ID)
+}
+
+func (s *defenseServiceImpl) book(ctx context.Context, projectId int, slot *models.DefenseSlot, room *models.DefenseRoom) (*models.Defense, error) {
+	defense := &models.Defense{
+		ProjectID: projectId,
+		SlotID:    slot.ID,
+		RoomID:    room.ID,
+		StartsAt:  slot.StartsAt,
+		EndsAt:    slot.EndsAt,
+	}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		defense.CreatedBy = principal.Subject
+	}
+
+	conflicts, err := s.defenseRepo.ScheduleDefense(ctx, defense)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		return nil, &DefenseConflictError{Conflicts: conflicts}
+	}
+	return defense, nil
+}
+
+func (s *defenseServiceImpl) CancelDefense(ctx context.Context, id int) error {
+	return s.defenseRepo.DeleteDefense(ctx, id)
+}
+
+// booking is a defense, existing or proposed, with the people it occupies.
+type booking struct {
+	defenseId int
+	projectId int
+	projectNo string
+	roomId    int
+	roomName  string
+	startsAt  time.Time
+	endsAt    time.Time
+	staffs    map[int]string
+	students  map[string]int
+}
+
+func (b *booking) overlaps(other *booking) bool {
+	return b.startsAt.Before(other.endsAt) && other.startsAt.Before(b.endsAt)
+}
+
+// conflictsWith lists what candidate would double-book in b.
+func (b *booking) conflictsWith(candidate *booking) []dtos.DefenseConflict {
+	if b.projectId == candidate.projectId || !b.overlaps(candidate) {
+		return nil
+	}
+
+	var conflicts []dtos.DefenseConflict
+	conflict := func(conflictType string, id int, label string) dtos.DefenseConflict {
+		return dtos.DefenseConflict{
+			Type:      conflictType,
+			ID:        id,
+			Label:     label,
+			DefenseID: b.defenseId,
+			ProjectID: b.projectId,
+			ProjectNo: b.projectNo,
+			StartsAt:  b.startsAt,
+			EndsAt:    b.endsAt,
+		}
+	}
+	if b.roomId == candidate.roomId {
+		conflicts = append(conflicts, conflict(repositories.DefenseConflictRoom, b.roomId, b.roomName))
+	}
+	for staffId, email := range candidate.staffs {
+		if _, ok := b.staffs[staffId]; ok {
+			conflicts = append(conflicts, conflict(repositories.DefenseConflictStaff, staffId, email))
+		}
+	}
+	for studentCode, id := range candidate.students {
+		if _, ok := b.students[studentCode]; ok {
+			conflicts = append(conflicts, conflict(repositories.DefenseConflictStudent, id, studentCode))
+		}
+	}
+	return conflicts
+}
+
+// ProposeDefenses assigns each unscheduled project of the semester to the
+// earliest slot and room that double-book nobody, taking existing defenses of
+// every program into account. Projects that fit nowhere are reported with the
+// bookings that block them. With Apply set the proposals are booked; a
+// proposal that lost a race against another booking is reported as
+// unscheduled.
+func (s *defenseServiceImpl) ProposeDefenses(ctx context.Context, req *dtos.DefenseProposalRequest) (*dtos.DefenseProposalResult, error) {
+	result := &dtos.DefenseProposalResult{
+		Proposals:   []dtos.DefenseProposal{},
+		Unscheduled: []dtos.UnscheduledProject{},
+	}
+
+	projects, err := s.defenseRepo.GetUnscheduledProjects(ctx, req.ProgramID, req.AcademicYear, req.Semester, req.ProjectIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(projects) == 0 {
+		return result, nil
+	}
+
+	slots, err := s.defenseRepo.GetSlots(ctx, &dtos.DefenseFilter{
+		ProgramID:    req.ProgramID,
+		AcademicYear: &req.AcademicYear,
+		Semester:     &req.Semester,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rooms, err := s.defenseRepo.GetRoomsByProgramId(ctx, req.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+	if len(slots) == 0 || len(rooms) == 0 {
+		for _, project := range projects {
+			result.Unscheduled = append(result.Unscheduled, dtos.UnscheduledProject{
+				ProjectID: project.ID,
+				ProjectNo: project.ProjectNo,
+				Reason:    "no defense slots or rooms are defined for this semester",
+				Conflicts: []dtos.DefenseConflict{},
+			})
+		}
+		return result, nil
+	}
+
+	windowStart, windowEnd := slots[0].StartsAt, slots[0].EndsAt
+	for _, slot := range slots {
+		if slot.EndsAt.After(windowEnd) {
+			windowEnd = slot.EndsAt
+		}
+	}
+	existing, err := s.defenseRepo.GetDefensesBetween(ctx, windowStart, windowEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	projectIds := make([]int, 0, len(projects)+len(existing))
+	for _, project := range projects {
+		projectIds = append(projectIds, project.ID)
+	}
+	for _, defense := range existing {
+		projectIds = append(projectIds, defense.ProjectID)
+	}
+	staffs, students, err := s.getParticipants(ctx, projectIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var booked []*booking
+	for _, defense := range existing {
+		booked = append(booked, &booking{
+			defenseId: defense.ID,
+			projectId: defense.ProjectID,
+			projectNo: defense.Project.ProjectNo,
+			roomId:    defense.RoomID,
+			roomName:  defense.Room.Name,
+			startsAt:  defense.StartsAt,
+			endsAt:    defense.EndsAt,
+			staffs:    staffs[defense.ProjectID],
+			students:  students[defense.ProjectID],
+		})
+	}
+
+	type proposal struct {
+		project models.Project
+		slot    models.DefenseSlot
+		room    models.DefenseRoom
+	}
+	var proposals []proposal
+	for _, project := range projects {
+		blocking := map[string]dtos.DefenseConflict{}
+		placed := false
+		for _, slot := range slots {
+			for _, room := range rooms {
+				candidate := &booking{
+					projectId: project.ID,
+					projectNo: project.ProjectNo,
+					roomId:    room.ID,
+					roomName:  room.Name,
+					startsAt:  slot.StartsAt,
+					endsAt:    slot.EndsAt,
+					staffs:    staffs[project.ID],
+					students:  students[project.ID],
+				}
+
+				var conflicts []dtos.DefenseConflict
+				for _, other := range booked {
+					conflicts = append(conflicts, other.conflictsWith(candidate)...)
+				}
+				if len(conflicts) == 0 {
+					booked = append(booked, candidate)
+					proposals = append(proposals, proposal{project: project, slot: slot, room: room})
+					placed = true
+					break
+				}
+				for _, conflict := range conflicts {
+					if conflict.Type == repositories.DefenseConflictRoom {
+						continue
+					}
+					key := fmt.Sprintf("%s:%d:%d:%d", conflict.Type, conflict.ID, conflict.ProjectID, conflict.StartsAt.Unix())
+					blocking[key] = conflict
+				}
+			}
+			if placed {
+				break
+			}
+		}
+
+		if !placed {
+			unscheduled := dtos.UnscheduledProject{
+				ProjectID: project.ID,
+				ProjectNo: project.ProjectNo,
+				Reason:    "every slot double-books a room, a staff member or a student",
+				Conflicts: []dtos.DefenseConflict{},
+			}
+			for _, conflict := range blocking {
+				unscheduled.Conflicts = append(unscheduled.Conflicts, conflict)
+			}
+			result.Unscheduled = append(result.Unscheduled, unscheduled)
+		}
+	}
+
+	for _, p := range proposals {
+		item := dtos.DefenseProposal{
+			ProjectID: p.project.ID,
+			ProjectNo: p.project.ProjectNo,
+			SlotID:    p.slot.ID,
+			RoomID:    p.room.ID,
+			RoomName:  p.room.Name,
+			StartsAt:  p.slot.StartsAt,
+			EndsAt:    p.slot.EndsAt,
+		}
+
+		if req.Apply {
+			defense, err := s.book(ctx, p.project.ID, &p.slot, &p.room)
+			var conflictErr *DefenseConflictError
+			if errors.As(err, &conflictErr) {
+				result.Unscheduled = append(result.Unscheduled, dtos.UnscheduledProject{
+					ProjectID: p.project.ID,
+					ProjectNo: p.project.ProjectNo,
+					Reason:    "the proposed slot was booked by someone else in the meantime",
+					Conflicts: conflictErr.Conflicts,
+				})
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			item.DefenseID = &defense.ID
+		}
+
+		result.Proposals = append(result.Proposals, item)
+	}
+	result.Applied = req.Apply
+
+	return result, nil
+}
+
+func (s *defenseServiceImpl) getParticipants(ctx context.Context, projectIds []int) (map[int]map[int]string, map[int]map[string]int, error) {
+	projectStaffs, err := s.projectStaffRepo.GetProjectStaffsByProjectIds(ctx, projectIds)
+	if err != nil {
+		return nil, nil, err
+	}
+	members, err := s.defenseRepo.GetProjectStudents(ctx, projectIds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	staffs := map[int]map[int]string{}
+	for _, projectStaff := range projectStaffs {
+		if staffs[projectStaff.ProjectID] == nil {
+			staffs[projectStaff.ProjectID] = map[int]string{}
+		}
+		staffs[projectStaff.ProjectID][projectStaff.StaffID] = projectStaff.Staff.Email
+	}
+
+	students := map[int]map[string]int{}
+	for projectId, projectMembers := range members {
+		students[projectId] = map[string]int{}
+		for _, student := range projectMembers {
+			students[projectId][student.StudentID] = student.ID
+		}
+	}
+
+	return staffs, students, nil
+}
+
+func (s *defenseServiceImpl) GetStaffCalendar(ctx context.Context, staffId int) ([]byte, error) {
+	defenses, err := s.defenseRepo.GetDefensesByStaffId(ctx, staffId)
+	if err != nil {
+		return nil, err
+	}
+	return buildDefenseCalendar(fmt.Sprintf("Project defenses (staff %d)", staffId), defenses), nil
+}
+
+func (s *defenseServiceImpl) GetRoomCalendar(ctx context.Context, roomId int) ([]byte, error) {
+	room, err := s.defenseRepo.GetRoomByID(ctx, roomId)
+	if err != nil {
+		return nil, err
+	}
+	defenses, err := s.defenseRepo.GetDefensesByRoomId(ctx, roomId)
+	if err != nil {
+		return nil, err
+	}
+	return buildDefenseCalendar("Project defenses in "+room.Name, defenses), nil
+}
+
+func buildDefenseCalendar(name string, defenses []models.Defense) []byte {
+	events := make([]utils.CalendarEvent, 0, len(defenses))
+	for _, defense := range defenses {
+		title := defense.Project.ProjectNo
+		var description []string
+		if defense.Project.TitleEN != nil && *defense.Project.TitleEN != "" {
+			title += " " + *defense.Project.TitleEN
+			description = append(description, *defense.Project.TitleEN)
+		}
+		if defense.Project.TitleTH != nil && *defense.Project.TitleTH != "" {
+			description = append(description, *defense.Project.TitleTH)
+		}
+
+		location := defense.Room.Name
+		if defense.Room.Location != nil && *defense.Room.Location != "" {
+			location += ", " + *defense.Room.Location
+		}
+
+		events = append(events, utils.CalendarEvent{
+			UID:         fmt.Sprintf("defense-%d@project-box", defense.ID),
+			Summary:     "Project defense: " + title,
+			Description: strings.Join(description, "\n"),
+			Location:    location,
+			Start:       defense.StartsAt,
+			End:         defense.EndsAt,
+		})
+	}
+	return utils.BuildICalendar(name, events, time.Now())
+}
+
+func isSchedulable(project *models.Project) bool {
+	return project.Status == models.ProjectStatusInProgress || project.Status == models.ProjectStatusDefense
+}
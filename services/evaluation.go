@@ -0,0 +1,330 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/project-box/auth"
+	"github.com/project-box/dtos"
+	"github.com/project-box/models"
+	"github.com/project-box/repositories"
+)
+
+type EvaluationService interface {
+	SubmitEvaluation(ctx context.Context, projectId int, req *dtos.EvaluationRequest) (*models.ProjectEvaluation, error)
+	GetProjectScore(ctx context.Context, projectId int, rubricId int) (*dtos.ProjectScore, error)
+	GetRubricScores(ctx context.Context, rubricId int, filter *dtos.ProjectScoreFilter) ([]dtos.ProjectScore, error)
+}
+
+var (
+	ErrInvalidEvaluation   = errors.New("invalid evaluation")
+	ErrNotProjectCommittee = errors.New("only staff assigned to the project can evaluate it")
+	ErrProjectNotInDefense = errors.New("project can only be evaluated during its defense")
+)
+
+type evaluationServiceImpl struct {
+	evaluationRepo   repositories.EvaluationRepository
+	rubricRepo       repositories.RubricRepository
+	projectRepo      repositories.ProjectRepository
+	projectStaffRepo repositories.ProjectStaffRepository
+}
+
+func NewEvaluationService(
+	evaluationRepo repositories.EvaluationRepository,
+	rubricRepo repositories.RubricRepository,
+	projectRepo repositories.ProjectRepository,
+	projectStaffRepo repositories.ProjectStaffRepository,
+) EvaluationService {
+	return &evaluationServiceImpl{
+		evaluationRepo:   evaluationRepo,
+		rubricRepo:       rubricRepo,
+		projectRepo:      projectRepo,
+		projectStaffRepo: projectStaffRepo,
+	}
+}
+
+// SubmitEvaluation records the calling staff member's scores for a project.
+// Every criterion of the rubric must be scored within its range.
+func (s *evaluationServiceImpl) SubmitEvaluation(ctx context.Context, projectId int, req *dtos.EvaluationRequest) (*models.ProjectEvaluation, error) {
+	project, err := s.projectRepo.Get(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+	if project.Status != models.ProjectStatusDefense {
+		return nil, ErrProjectNotInDefense
+	}
+
+	rubric, err := s.rubricRepo.GetRubricByID(ctx, req.RubricID)
+	if err != nil {
+		return nil, err
+	}
+	if rubric.ProgramID != project.ProgramID {
+		return nil, fmt.Errorf("%w: rubric %d does not belong to the project's program", ErrInvalidEvaluation, rubric.ID)
+	}
+
+	principal, _ := auth.PrincipalFromContext(ctx)
+	if principal == nil || principal.Email == "" {
+		return nil, ErrNotProjectCommittee
+	}
+	projectStaffs, err := s.projectStaffRepo.GetProjectStaffsByProjectIds(ctx, []int{projectId})
+	if err != nil {
+		return nil, err
+	}
+	var assignment *models.ProjectStaff
+	for i := range projectStaffs {
+		if strings.EqualFold(projectStaffs[i].Staff.Email, principal.Email) {
+			assignment = &projectStaffs[i]
+			break
+		}
+	}
+	if assignment == nil {
+		return nil, ErrNotProjectCommittee
+	}
+
+	criteria := map[int]models.RubricCriterion{}
+	for _, criterion := range rubric.Criteria {
+		criteria[criterion.ID] = criterion
+	}
+	scored := map[int]bool{}
+	evaluation := &models.ProjectEvaluation{
+		ProjectID:     projectId,
+		RubricID:      rubric.ID,
+		StaffID:       assignment.StaffID,
+		ProjectRoleID: assignment.ProjectRoleID,
+		Comment:       req.Comment,
+	}
+	for _, score := range req.Scores {
+		criterion, ok := criteria[score.CriterionID]
+		if !ok {
+			return nil, fmt.Errorf("%w: criterion %d does not belong to rubric %d", ErrInvalidEvaluation, score.CriterionID, rubric.ID)
+		}
+		if scored[score.CriterionID] {
+			return nil, fmt.Errorf("%w: criterion %q is scored more than once", ErrInvalidEvaluation, criterion.Name)
+		}
+		if score.Score < criterion.MinScore || score.Score > criterion.MaxScore {
+			return nil, fmt.Errorf("%w: score for %q must be between %g and %g", ErrInvalidEvaluation, criterion.Name, criterion.MinScore, criterion.MaxScore)
+		}
+		scored[score.CriterionID] = true
+		evaluation.Scores = append(evaluation.Scores, models.EvaluationScore{
+			CriterionID: score.CriterionID,
+			Score:       score.Score,
+			Comment:     score.Comment,
+		})
+	}
+	for _, criterion := range rubric.Criteria {
+		if !scored[criterion.ID] {
+			return nil, fmt.Errorf("%w: criterion %q is not scored", ErrInvalidEvaluation, criterion.Name)
+		}
+	}
+
+	if err := s.evaluationRepo.UpsertEvaluation(ctx, evaluation); err != nil {
+		return nil, err
+	}
+	return evaluation, nil
+}
+
+func (s *evaluationServiceImpl) GetProjectScore(ctx context.Context, projectId int, rubricId int) (*dtos.ProjectScore, error) {
+	project, err := s.projectRepo.Get(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+	rubric, err := s.rubricRepo.GetRubricByID(ctx, rubricId)
+	if err != nil {
+		return nil, err
+	}
+	if rubric.ProgramID != project.ProgramID {
+		return nil, fmt.Errorf("%w: rubric %d does not belong to the project's program", ErrInvalidEvaluation, rubric.ID)
+	}
+
+	scores, err := s.scoreProjects(ctx, rubric, []models.Project{*project})
+	if err != nil {
+		return nil, err
+	}
+	return &scores[0], nil
+}
+
+// GetRubricScores returns the score breakdown of every project in the
+// rubric's program that has reached its defense.
+func (s *evaluationServiceImpl) GetRubricScores(ctx context.Context, rubricId int, filter *dtos.ProjectScoreFilter) ([]dtos.ProjectScore, error) {
+	rubric, err := s.rubricRepo.GetRubricByID(ctx, rubricId)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := s.evaluationRepo.GetScoringProjects(ctx, rubric.ProgramID, filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.scoreProjects(ctx, rubric, projects)
+}
+
+func (s *evaluationServiceImpl) scoreProjects(ctx context.Context, rubric *models.Rubric, projects []models.Project) ([]dtos.ProjectScore, error) {
+	projectIds := make([]int, 0, len(projects))
+	for _, project := range projects {
+		projectIds = append(projectIds, project.ID)
+	}
+
+	projectStaffs, err := s.projectStaffRepo.GetProjectStaffsByProjectIds(ctx, projectIds)
+	if err != nil {
+		return nil, err
+	}
+	evaluations, err := s.evaluationRepo.GetEvaluations(ctx, rubric.ID, projectIds)
+	if err != nil {
+		return nil, err
+	}
+
+	staffsByProject := map[int][]models.ProjectStaff{}
+	for _, projectStaff := range projectStaffs {
+		staffsByProject[projectStaff.ProjectID] = append(staffsByProject[projectStaff.ProjectID], projectStaff)
+	}
+	evaluationsByProject := map[int][]models.ProjectEvaluation{}
+	for _, evaluation := range evaluations {
+		evaluationsByProject[evaluation.ProjectID] = append(evaluationsByProject[evaluation.ProjectID], evaluation)
+	}
+
+	scores := make([]dtos.ProjectScore, 0, len(projects))
+	for _, project := range projects {
+		scores = append(scores, computeProjectScore(&project, rubric, staffsByProject[project.ID], evaluationsByProject[project.ID]))
+	}
+	return scores, nil
+}
+
+// computeProjectScore scales each evaluation to 0-100 from its weighted
+// criteria, averages the evaluations per project role and combines the role
+// averages using the rubric's role weights. When the rubric has no role
+// weights every role counts equally; otherwise roles without a weight do not
+// count towards the final score.
+func computeProjectScore(project *models.Project, rubric *models.Rubric, projectStaffs []models.ProjectStaff, evaluations []models.ProjectEvaluation) dtos.ProjectScore {
+	criteria := map[int]models.RubricCriterion{}
+	for _, criterion := range rubric.Criteria {
+		criteria[criterion.ID] = criterion
+	}
+	roleWeights := map[int]float64{}
+	for _, roleWeight := range rubric.RoleWeights {
+		roleWeights[roleWeight.ProjectRoleID] = roleWeight.Weight
+	}
+	weightOf := func(roleId int) float64 {
+		if len(rubric.RoleWeights) == 0 {
+			return 1
+		}
+		return roleWeights[roleId]
+	}
+
+	roles := map[int]*dtos.RoleScore{}
+	roleFor := func(roleId int, role models.ProjectRole) *dtos.RoleScore {
+		roleScore, ok := roles[roleId]
+		if !ok {
+			roleScore = &dtos.RoleScore{
+				ProjectRoleID: roleId,
+				RoleNameTH:    role.RoleNameTH,
+				RoleNameEN:    role.RoleNameEN,
+				Weight:        weightOf(roleId),
+				Evaluations:   []dtos.EvaluatorScore{},
+				PendingStaff:  []int{},
+			}
+			roles[roleId] = roleScore
+		}
+		if roleScore.RoleNameEN == "" && roleScore.RoleNameTH == "" {
+			roleScore.RoleNameTH = role.RoleNameTH
+			roleScore.RoleNameEN = role.RoleNameEN
+		}
+		return roleScore
+	}
+
+	for _, roleWeight := range rubric.RoleWeights {
+		roleFor(roleWeight.ProjectRoleID, roleWeight.ProjectRole)
+	}
+
+	submitted := map[int]bool{}
+	for _, evaluation := range evaluations {
+		submitted[evaluation.StaffID] = true
+
+		evaluatorScore := dtos.EvaluatorScore{
+			StaffID:     evaluation.StaffID,
+			Email:       evaluation.Staff.Email,
+			FirstNameEN: evaluation.Staff.FirstNameEN,
+			LastNameEN:  evaluation.Staff.LastNameEN,
+			Comment:     evaluation.Comment,
+			SubmittedAt: evaluation.UpdatedAt,
+		}
+		var weighted, totalWeight float64
+		for _, score := range evaluation.Scores {
+			criterion, ok := criteria[score.CriterionID]
+			if !ok {
+				continue
+			}
+			weighted += criterion.Weight * (score.Score - criterion.MinScore) / (criterion.MaxScore - criterion.MinScore)
+			totalWeight += criterion.Weight
+			evaluatorScore.Criteria = append(evaluatorScore.Criteria, dtos.CriterionScore{
+				CriterionID: criterion.ID,
+				Name:        criterion.Name,
+				Weight:      criterion.Weight,
+				MinScore:    criterion.MinScore,
+				MaxScore:    criterion.MaxScore,
+				Score:       score.Score,
+				Comment:     score.Comment,
+			})
+		}
+		if totalWeight > 0 {
+			evaluatorScore.Score = roundScore(weighted / totalWeight * 100)
+		}
+
+		roleScore := roleFor(evaluation.ProjectRoleID, models.ProjectRole{})
+		roleScore.Evaluations = append(roleScore.Evaluations, evaluatorScore)
+	}
+
+	for _, projectStaff := range projectStaffs {
+		roleScore := roleFor(projectStaff.ProjectRoleID, projectStaff.ProjectRole)
+		if !submitted[projectStaff.StaffID] {
+			roleScore.PendingStaff = append(roleScore.PendingStaff, projectStaff.StaffID)
+		}
+	}
+
+	result := dtos.ProjectScore{
+		ProjectID: project.ID,
+		ProjectNo: project.ProjectNo,
+		TitleTH:   project.TitleTH,
+		TitleEN:   project.TitleEN,
+		RubricID:  rubric.ID,
+		Complete:  true,
+		Roles:     []dtos.RoleScore{},
+	}
+
+	var weightedTotal, weightTotal float64
+	for _, roleScore := range roles {
+		if len(roleScore.Evaluations) > 0 {
+			var sum float64
+			for _, evaluation := range roleScore.Evaluations {
+				sum += evaluation.Score
+			}
+			average := roundScore(sum / float64(len(roleScore.Evaluations)))
+			roleScore.Score = &average
+			if roleScore.Weight > 0 {
+				weightedTotal += roleScore.Weight * average
+				weightTotal += roleScore.Weight
+			}
+		}
+		if roleScore.Weight > 0 && len(roleScore.PendingStaff) > 0 {
+			result.Complete = false
+		}
+		result.Roles = append(result.Roles, *roleScore)
+	}
+	sort.Slice(result.Roles, func(i, j int) bool {
+		return result.Roles[i].ProjectRoleID < result.Roles[j].ProjectRoleID
+	})
+
+	if weightTotal > 0 {
+		finalScore := roundScore(weightedTotal / weightTotal)
+		result.FinalScore = &finalScore
+	} else {
+		result.Complete = false
+	}
+	return result
+}
+
+func roundScore(value float64) float64 {
+	return math.Round(value*100) / 100
+}
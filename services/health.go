@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/project-box/configs"
+	rabbitMQ "github.com/project-box/db/rabbitmq"
+	storage "github.com/project-box/db/storage"
+	"github.com/project-box/dtos"
+	"gorm.io/gorm"
+)
+
+var errDatabaseNotConnected = errors.New("database is not connected")
+
+// HealthService checks whether the dependencies needed to serve requests are
+// reachable.
+type HealthService interface {
+	// Readiness checks Postgres, the project bucket and the RabbitMQ
+	// publisher concurrently, each bounded by the configured timeout.
+	Readiness(ctx context.Context) *dtos.ReadinessReport
+}
+
+type healthServiceImpl struct {
+	db            *gorm.DB
+	objectStorage storage.ObjectStorage
+	publisher     rabbitMQ.Publisher
+	config        *configs.ReadinessConfig
+}
+
+func NewHealthService(db *gorm.DB, objectStorage storage.ObjectStorage, publisher rabbitMQ.Publisher) HealthService {
+	return &healthServiceImpl{
+		db:            db,
+		objectStorage: objectStorage,
+		publisher:     publisher,
+		config:        configs.GetReadinessConfig(),
+	}
+}
+
+func (s *healthServiceImpl) Readiness(ctx context.Context) *dtos.ReadinessReport {
+	checks := map[string]func(context.Context) error{
+		"postgres": s.checkPostgres,
+		"storage":  s.checkStorage,
+		"rabbitmq": func(context.Context) error { return s.publisher.Health() },
+	}
+
+	report := &dtos.ReadinessReport{
+		Status: dtos.HealthStatusOK,
+		Checks: make(map[string]dtos.HealthCheck, len(checks)),
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := s.runCheck(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status != dtos.HealthStatusOK {
+				report.Status = dtos.HealthStatusFail
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+func (s *healthServiceImpl) runCheck(ctx context.Context, check func(context.Context) error) dtos.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, s.config.CheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := dtos.HealthCheck{
+		Status:    dtos.HealthStatusOK,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = dtos.HealthStatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (s *healthServiceImpl) checkPostgres(ctx context.Context) error {
+	if s.db == nil {
+		return errDatabaseNotConnected
+	}
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (s *healthServiceImpl) checkStorage(ctx context.Context) error {
+	exists, err := s.objectStorage.BucketExists(ctx, s.config.Bucket)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("bucket %q does not exist", s.config.Bucket)
+	}
+	return nil
+}
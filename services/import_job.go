@@ -0,0 +1,317 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"time"
+
+	"github.com/project-box/auth"
+	"github.com/project-box/configs"
+	"github.com/project-box/dtos"
+	"github.com/project-box/metrics"
+	"github.com/project-box/models"
+	"github.com/project-box/repositories"
+	"github.com/project-box/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+type ImportJobService interface {
+	CreateImportJob(ctx context.Context, jobType string, programId int, file *multipart.FileHeader, dryRun bool, createdBy string) (*dtos.ImportJob, error)
+	GetImportJob(ctx context.Context, id int) (*dtos.ImportJob, error)
+	RetryImportJob(ctx context.Context, id int) (*dtos.ImportJob, error)
+}
+
+var ErrInvalidImportFile = errors.New("invalid file type: only Excel files are allowed")
+
+const importProgressInterval = time.Second
+
+type importJobServiceImpl struct {
+	importJobRepo repositories.ImportJobRepository
+	uploadService UploadService
+	auditService  AuditService
+	logger        *slog.Logger
+	config        *configs.ImportWorkerConfig
+	wake          chan struct{}
+}
+
+// NewImportJobService starts the background import workers. The returned
+// cleanup function stops them and waits for the jobs in progress to return.
+func NewImportJobService(importJobRepo repositories.ImportJobRepository, uploadService UploadService, auditService AuditService, logger *slog.Logger) (ImportJobService, func()) {
+	service := &importJobServiceImpl{
+		importJobRepo: importJobRepo,
+		uploadService: uploadService,
+		auditService:  auditService,
+		logger:        logger.With("job", "import"),
+		config:        configs.GetImportWorkerConfig(),
+		wake:          make(chan struct{}, 1),
+	}
+
+	// On shutdown the workers stop claiming jobs and finish the ones they
+	// hold; a job cut off by the drain timeout is requeued.
+	workers := newBackgroundWorkers()
+	for i := 0; i < service.config.Workers; i++ {
+		workers.Go(service.runWorker)
+	}
+
+	return service, workers.Stop
+}
+
+func (s *importJobServiceImpl) CreateImportJob(ctx context.Context, jobType string, programId int, file *multipart.FileHeader, dryRun bool, createdBy string) (*dtos.ImportJob, error) {
+	if err := validateExcelFileName(file.Filename); err != nil {
+		return nil, ErrInvalidImportFile
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	job, err := s.importJobRepo.Create(ctx, &models.ImportJob{
+		Type:      jobType,
+		Status:    models.ImportJobStatusPending,
+		DryRun:    dryRun,
+		FileName:  file.Filename,
+		FileData:  data,
+		CreatedBy: createdBy,
+		ProgramID: programId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	jobDTO := toImportJobDTO(job)
+	s.auditService.Record(ctx, AuditChange{
+		Action:     models.AuditActionCreate,
+		EntityType: models.AuditEntityImportJob,
+		EntityID:   job.ID,
+		ProgramID:  job.ProgramID,
+		After:      jobDTO,
+	})
+	return jobDTO, nil
+}
+
+func (s *importJobServiceImpl) GetImportJob(ctx context.Context, id int) (*dtos.ImportJob, error) {
+	job, err := s.importJobRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toImportJobDTO(job), nil
+}
+
+func (s *importJobServiceImpl) RetryImportJob(ctx context.Context, id int) (*dtos.ImportJob, error) {
+	before, err := s.importJobRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.importJobRepo.Retry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	jobDTO := toImportJobDTO(job)
+	s.auditService.Record(ctx, AuditChange{
+		Action:     models.AuditActionUpdate,
+		EntityType: models.AuditEntityImportJob,
+		EntityID:   job.ID,
+		ProgramID:  job.ProgramID,
+		Before:     toImportJobDTO(before),
+		After:      jobDTO,
+	})
+	return jobDTO, nil
+}
+
+func (s *importJobServiceImpl) runWorker(ctx context.Context, stopping <-chan struct{}) {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for !stopped(stopping) && s.processNext(ctx) {
+			if ctx.Err() != nil {
+				return
+			}
+		}
+
+		select {
+		case <-stopping:
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// processNext runs a single job and reports whether one was found.
+func (s *importJobServiceImpl) processNext(ctx context.Context) (found bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.ErrorContext(ctx, "Import worker panic", "panic", r)
+			found = false
+		}
+	}()
+
+	job, err := s.importJobRepo.ClaimNext(ctx, s.config.Lease)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) && ctx.Err() == nil {
+			s.logger.ErrorContext(ctx, "Failed to claim import job", "error", err)
+		}
+		return false
+	}
+
+	// Attribute the records the import writes to whoever uploaded the sheet.
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: job.CreatedBy})
+	ctx, span := tracing.Tracer().Start(ctx, "import_job.run",
+		trace.WithAttributes(
+			attribute.Int("import_job.id", job.ID),
+			attribute.String("import_job.type", job.Type),
+			attribute.Int("import_job.attempt", job.Attempts),
+		),
+	)
+
+	report, err := s.runImport(ctx, job)
+	recordImportRows(job.Type, report)
+	tracing.End(span, err)
+	s.finishJob(job, report, err)
+	return true
+}
+
+func (s *importJobServiceImpl) runImport(ctx context.Context, job *models.ImportJob) (*dtos.ImportReport, error) {
+	var lastUpdate time.Time
+	opts := ImportOptions{
+		DryRun: job.DryRun,
+		OnProgress: func(processed, total int) {
+			if processed < total && time.Since(lastUpdate) < importProgressInterval {
+				return
+			}
+			lastUpdate = time.Now()
+			if err := s.importJobRepo.UpdateProgress(ctx, job.ID, processed, total, s.config.Lease); err != nil {
+				s.logger.WarnContext(ctx, "Failed to update progress of import job", "import_job_id", job.ID, "error", err)
+			}
+		},
+	}
+
+	file := bytes.NewReader(job.FileData)
+	switch job.Type {
+	case models.ImportJobTypeStudentEnrollment:
+		return s.uploadService.ProcessStudentEnrollmentFile(ctx, job.ProgramID, file, opts)
+	case models.ImportJobTypeCreateProject:
+		return s.uploadService.ProcessCreateProjectFile(ctx, job.ProgramID, file, opts)
+	case models.ImportJobTypeCreateStaff:
+		return s.uploadService.ProcessCreateStaffFile(ctx, job.ProgramID, file, opts)
+	default:
+		return nil, fmt.Errorf("unknown import job type: %s", job.Type)
+	}
+}
+
+// recordImportRows counts the rows of a run as valid or invalid. A row with
+// several errors counts once; errors about the whole sheet count for none.
+func recordImportRows(jobType string, report *dtos.ImportReport) {
+	if report == nil {
+		return
+	}
+
+	invalidRows := map[int]struct{}{}
+	for _, rowErr := range report.Errors {
+		if rowErr.Row > 0 {
+			invalidRows[rowErr.Row] = struct{}{}
+		}
+	}
+	invalid := min(len(invalidRows), report.TotalRows)
+
+	metrics.ImportRowsTotal.WithLabelValues(jobType, "valid").Add(float64(report.TotalRows - invalid))
+	metrics.ImportRowsTotal.WithLabelValues(jobType, "invalid").Add(float64(invalid))
+}
+
+// finishJob records the outcome of a run. Validation failures are final;
+// other errors put the job back in the queue until it runs out of attempts.
+func (s *importJobServiceImpl) finishJob(job *models.ImportJob, report *dtos.ImportReport, runErr error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var reportData []byte
+	if report != nil {
+		data, err := json.Marshal(report)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to encode report of import job", "import_job_id", job.ID, "error", err)
+		}
+		reportData = data
+	}
+
+	// Each import writes all of its rows in a single transaction, so a run
+	// that failed before it was applied, including one cancelled by a
+	// shutdown, left nothing behind and may be retried. One that failed after
+	// its rows were written must not run again.
+	var err error
+	switch {
+	case runErr == nil:
+		err = s.importJobRepo.Finish(ctx, job.ID, models.ImportJobStatusSucceeded, reportData, nil)
+	case errors.Is(runErr, ErrImportValidation) || job.Attempts >= s.config.MaxAttempts || (report != nil && report.Applied):
+		message := runErr.Error()
+		err = s.importJobRepo.Finish(ctx, job.ID, models.ImportJobStatusFailed, reportData, &message)
+	default:
+		s.logger.WarnContext(ctx, "Import job failed, retrying", "import_job_id", job.ID, "attempt", job.Attempts, "error", runErr)
+		err = s.importJobRepo.Requeue(ctx, job.ID, reportData, runErr.Error())
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to update import job", "import_job_id", job.ID, "error", err)
+	}
+}
+
+func toImportJobDTO(job *models.ImportJob) *dtos.ImportJob {
+	result := &dtos.ImportJob{
+		ID:            job.ID,
+		Type:          job.Type,
+		Status:        job.Status,
+		ProgramID:     job.ProgramID,
+		DryRun:        job.DryRun,
+		FileName:      job.FileName,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		Attempts:      job.Attempts,
+		Error:         job.Error,
+		CreatedBy:     job.CreatedBy,
+		CreatedAt:     job.CreatedAt,
+		StartedAt:     job.StartedAt,
+		FinishedAt:    job.FinishedAt,
+	}
+
+	switch {
+	case job.Status == models.ImportJobStatusSucceeded:
+		result.PercentComplete = 100
+	case job.TotalRows > 0:
+		result.PercentComplete = job.ProcessedRows * 100 / job.TotalRows
+	}
+
+	if len(job.Report) > 0 {
+		var report dtos.ImportReport
+		if err := json.Unmarshal(job.Report, &report); err == nil {
+			result.Report = &report
+		}
+	}
+
+	return result
+}
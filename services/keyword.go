@@ -2,8 +2,12 @@ package services
 
 import (
 	"context"
+	"errors"
+	"strconv"
+
 	"github.com/project-box/models"
 	"github.com/project-box/repositories"
+	"gorm.io/gorm"
 )
 
 type KeywordService interface {
@@ -16,11 +20,12 @@ type KeywordService interface {
 }
 
 type keywordService struct {
-	repo repositories.KeywordRepository
+	repo         repositories.KeywordRepository
+	auditService AuditService
 }
 
-func NewKeywordService(repo repositories.KeywordRepository) KeywordService {
-	return &keywordService{repo: repo}
+func NewKeywordService(repo repositories.KeywordRepository, auditService AuditService) KeywordService {
+	return &keywordService{repo: repo, auditService: auditService}
 }
 
 func (s *keywordService) GetAllKeywords(ctx context.Context) ([]models.Keyword, error) {
@@ -36,13 +41,58 @@ func (s *keywordService) GetKeyword(ctx context.Context, id string) (*models.Key
 }
 
 func (s *keywordService) CreateKeyword(ctx context.Context, keyword *models.Keyword) error {
-	return s.repo.Create(ctx, keyword)
+	if err := s.repo.Create(ctx, keyword); err != nil {
+		return err
+	}
+	s.auditService.Record(ctx, AuditChange{
+		Action:     models.AuditActionCreate,
+		EntityType: models.AuditEntityKeyword,
+		EntityID:   keyword.ID,
+		ProgramID:  keyword.ProgramID,
+		After:      keyword,
+	})
+	return nil
 }
 
+// UpdateKeyword saves keyword, creating it if its ID does not exist yet.
 func (s *keywordService) UpdateKeyword(ctx context.Context, keyword *models.Keyword) error {
-	return s.repo.Update(ctx, keyword)
+	change := AuditChange{Action: models.AuditActionUpdate, EntityType: models.AuditEntityKeyword}
+	before, err := s.repo.FindByID(ctx, strconv.Itoa(keyword.ID))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		change.Action = models.AuditActionCreate
+	case err != nil:
+		return err
+	default:
+		change.Before = before
+	}
+
+	if err := s.repo.Update(ctx, keyword); err != nil {
+		return err
+	}
+	change.EntityID, change.ProgramID, change.After = keyword.ID, keyword.ProgramID, keyword
+	s.auditService.Record(ctx, change)
+	return nil
 }
 
 func (s *keywordService) DeleteKeyword(ctx context.Context, id string) error {
-	return s.repo.Delete(ctx, id)
+	before, err := s.repo.FindByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.auditService.Record(ctx, AuditChange{
+		Action:     models.AuditActionDelete,
+		EntityType: models.AuditEntityKeyword,
+		EntityID:   before.ID,
+		ProgramID:  before.ProgramID,
+		Before:     before,
+	})
+	return nil
 }
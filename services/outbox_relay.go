@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/project-box/configs"
+	rabbitMQ "github.com/project-box/db/rabbitmq"
+	"github.com/project-box/dtos"
+	"github.com/project-box/logging"
+	"github.com/project-box/models"
+	rabbitMQQueue "github.com/project-box/queues/rabbitmq"
+	"github.com/project-box/repositories"
+	"github.com/project-box/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// OutboxRelayService publishes the search index events stored in the outbox
+// table to RabbitMQ.
+type OutboxRelayService interface {
+	// Notify wakes the relay up so freshly committed events are published
+	// without waiting for the next poll.
+	Notify()
+}
+
+const (
+	outboxPublishTimeout = 10 * time.Second
+	outboxInitialBackoff = time.Second
+)
+
+type outboxRelayServiceImpl struct {
+	publisher   rabbitMQ.Publisher
+	outboxRepo  repositories.OutboxRepository
+	projectRepo repositories.ProjectRepository
+	logger      *slog.Logger
+	config      *configs.OutboxConfig
+	wake        chan struct{}
+}
+
+// NewOutboxRelayService starts the relay goroutine. The returned cleanup
+// function stops it.
+func NewOutboxRelayService(publisher rabbitMQ.Publisher, outboxRepo repositories.OutboxRepository, projectRepo repositories.ProjectRepository, logger *slog.Logger) (OutboxRelayService, func()) {
+	config := configs.GetOutboxConfig()
+	// A lease must leave room for at least one publish.
+	config.Lease = max(config.Lease, 2*outboxPublishTimeout)
+
+	service := &outboxRelayServiceImpl{
+		publisher:   publisher,
+		outboxRepo:  outboxRepo,
+		projectRepo: projectRepo,
+		logger:      logger.With("job", "outbox_relay"),
+		config:      config,
+		wake:        make(chan struct{}, 1),
+	}
+
+	workers := newBackgroundWorkers()
+	workers.Go(service.run)
+
+	return service, workers.Stop
+}
+
+func (s *outboxRelayServiceImpl) Notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *outboxRelayServiceImpl) run(ctx context.Context, stopping <-chan struct{}) {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.relayPending(ctx, stopping)
+
+		select {
+		case <-stopping:
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// relayPending claims due events batch by batch and publishes them outside
+// any transaction until the outbox is drained. Once stopping is closed it
+// finishes the event in flight; events it claimed but did not publish are
+// picked up again when their lease runs out.
+func (s *outboxRelayServiceImpl) relayPending(ctx context.Context, stopping <-chan struct{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.ErrorContext(ctx, "Outbox relay panic", "panic", r)
+		}
+	}()
+
+	for ctx.Err() == nil && !stopped(stopping) {
+		if s.publisher.Health() != nil {
+			return
+		}
+
+		events, err := s.outboxRepo.ClaimPending(ctx, s.config.BatchSize, s.config.Lease)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to claim outbox events", "error", err)
+			return
+		}
+		if len(events) == 0 {
+			return
+		}
+
+		// Stop publishing before the lease could run out mid-publish, so
+		// another relay never sends an event while this one still might.
+		leaseEnd := time.Now().Add(s.config.Lease - outboxPublishTimeout)
+		for i := range events {
+			if ctx.Err() != nil || stopped(stopping) || time.Now().After(leaseEnd) {
+				return
+			}
+			if err := s.publish(ctx, &events[i]); err != nil {
+				s.handleFailure(&events[i], err)
+				continue
+			}
+			if err := s.outboxRepo.MarkDelivered(context.WithoutCancel(ctx), events[i].ID); err != nil {
+				s.logger.ErrorContext(ctx, "Failed to mark outbox event delivered", "event_id", events[i].ID, "error", err)
+			}
+		}
+	}
+}
+
+func (s *outboxRelayServiceImpl) publish(ctx context.Context, event *models.OutboxEvent) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, outboxPublishTimeout)
+	defer cancel()
+	if event.RequestID != "" {
+		ctx = logging.WithRequestID(ctx, event.RequestID)
+	}
+
+	// Continue the trace of the request that wrote the event.
+	ctx, span := tracing.Tracer().Start(tracing.ContextWithTraceParent(ctx, event.TraceParent), "outbox.relay",
+		trace.WithAttributes(
+			attribute.Int("outbox.event_id", event.ID),
+			attribute.String("outbox.operation", event.Operation),
+			attribute.Int("project.id", event.AggregateID),
+		),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	projectMessage := &dtos.ProjectData{ID: event.AggregateID}
+	if event.Operation != "delete" {
+		message, err := s.projectRepo.GetProjectMessageByID(ctx, event.AggregateID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The project was deleted before the event went out; the delete
+			// event that follows keeps the index consistent.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		projectMessage = message
+	}
+
+	return rabbitMQQueue.PublishMessageFromRabbitMQToElasticSearch(ctx, s.publisher, event.Operation, projectMessage)
+}
+
+// handleFailure schedules the next attempt of event with backoff, or marks
+// it dead once it has been tried MaxAttempts times.
+func (s *outboxRelayServiceImpl) handleFailure(event *models.OutboxEvent, publishErr error) {
+	attempt := event.Attempts + 1
+	ctx, cancel := context.WithTimeout(logging.WithRequestID(context.Background(), event.RequestID), outboxPublishTimeout)
+	defer cancel()
+
+	if attempt >= s.config.MaxAttempts {
+		s.logger.ErrorContext(ctx, "Failed to publish outbox event, giving up", "event_id", event.ID, "attempt", attempt, "error", publishErr)
+		if err := s.outboxRepo.MarkDead(ctx, event.ID, publishErr.Error()); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to record outbox event failure", "event_id", event.ID, "error", err)
+		}
+		return
+	}
+
+	nextAttemptAt := time.Now().Add(s.backoff(attempt))
+	s.logger.WarnContext(ctx, "Failed to publish outbox event, retrying", "event_id", event.ID, "attempt", attempt, "next_attempt_at", nextAttemptAt, "error", publishErr)
+	if err := s.outboxRepo.MarkFailed(ctx, event.ID, nextAttemptAt, publishErr.Error()); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to record outbox event failure", "event_id", event.ID, "error", err)
+	}
+}
+
+// backoff doubles the delay with every attempt up to the configured maximum.
+func (s *outboxRelayServiceImpl) backoff(attempt int) time.Duration {
+	delay := outboxInitialBackoff
+	for i := 1; i < attempt && delay < s.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.config.MaxBackoff {
+		delay = s.config.MaxBackoff
+	}
+	return delay
+}
@@ -5,160 +5,572 @@ import (
 	"errors"
 	"fmt"
 	"mime/multipart"
+	"strings"
 
+	"github.com/project-box/auth"
 	"github.com/project-box/dtos"
 	"github.com/project-box/models"
-	rabbitMQQueue "github.com/project-box/queues/rabbitmq"
 	"github.com/project-box/repositories"
-	rabbitmq "github.com/rabbitmq/amqp091-go"
+	"github.com/project-box/utils"
 	"gorm.io/gorm"
 )
 
 type ProjectService interface {
-	PublishProjectMessageToElasticSearch(ctx context.Context, action string, projectId int) error
 	GetProjectWithPDFByID(ctx context.Context, id int) (*dtos.ProjectData, error)
+	GetProjectByID(ctx context.Context, id int) (*dtos.ProjectData, error)
+	GetProjects(ctx context.Context, filter *dtos.ProjectFilter) (*dtos.ProjectPage, error)
 	CheckDuplicateProjectByTitleAndSemester(ctx context.Context, titleTH, titleEN string, academicYear, semester int) (bool, error)
-	CreateProjects(ctx context.Context, project []models.ProjectRequest) error
+	// CreateProjects creates every project in one transaction, running prepare
+	// in it first. See repositories.ProjectRepository.CreateProjects.
+	CreateProjects(ctx context.Context, project []models.ProjectRequest, prepare func(tx *gorm.DB) error) ([]*dtos.ProjectData, error)
 	CreateProjectWithFiles(ctx context.Context, project *models.ProjectRequest, projectResources []*models.ProjectResource, files []*multipart.FileHeader) (*dtos.ProjectData, error)
-	UpdateProjectWithFiles(ctx context.Context, project *models.ProjectRequest, projectResources []*models.ProjectResource, files []*multipart.FileHeader) (*dtos.ProjectData, error)
-	DeleteProject(ctx context.Context, id int) error
+	UpdateProjectWithFiles(ctx context.Context, project *models.ProjectRequest, version int, projectResources []*models.ProjectResource, files []*multipart.FileHeader) (*dtos.ProjectData, error)
+	DeleteProject(ctx context.Context, id int, version int) error
+	ApproveProject(ctx context.Context, id int, comment *string) (*models.ProjectStatusHistory, error)
+	RejectProject(ctx context.Context, id int, comment *string) (*models.ProjectStatusHistory, error)
+	TransitionProjectStatus(ctx context.Context, id int, status models.ProjectStatus, comment *string) (*models.ProjectStatusHistory, error)
+	GetProjectStatusHistory(ctx context.Context, id int) ([]models.ProjectStatusHistory, error)
+	PatchProject(ctx context.Context, id int, version int, patch *dtos.PatchProjectRequest) (*dtos.ProjectData, error)
+	AddProjectMember(ctx context.Context, id int, version int, studentId int) (*dtos.ProjectData, error)
+	RemoveProjectMember(ctx context.Context, id int, version int, studentId int) (*dtos.ProjectData, error)
+	AddProjectStaff(ctx context.Context, id int, version int, req *dtos.ProjectStaffRequest) (*dtos.ProjectData, error)
+	RemoveProjectStaff(ctx context.Context, id int, version int, staffId int, projectRoleId *int) (*dtos.ProjectData, error)
+	AddProjectKeyword(ctx context.Context, id int, version int, keywordId int) (*dtos.ProjectData, error)
+	RemoveProjectKeyword(ctx context.Context, id int, version int, keywordId int) (*dtos.ProjectData, error)
+	AddProjectResource(ctx context.Context, id int, version int, req *dtos.AddProjectResourceRequest) (*dtos.ProjectData, error)
+	RetireProjectResource(ctx context.Context, id int, version int, resourceId int) (*dtos.ProjectData, error)
+}
+
+const (
+	defaultProjectPageSize = 20
+	maxProjectPageSize     = 100
+)
+
+var (
+	ErrInvalidProjectFilter   = errors.New("invalid project filter")
+	ErrInvalidProjectStatus   = errors.New("invalid project status")
+	ErrCommentRequired        = errors.New("comment is required")
+	ErrProjectStatusForbidden = errors.New("not allowed to change the status of this project")
+	ErrProjectForbidden       = errors.New("not allowed to change this project")
+	ErrEmptyProjectPatch      = errors.New("no fields to update")
+	ErrInvalidProjectResource = errors.New("a resource needs either a file or a url")
+)
+
+// ProjectVersionConflictError is returned when an edit was based on an
+// outdated version of the project. Current holds the project as it is now so
+// the client can reapply its change.
+type ProjectVersionConflictError struct {
+	Current *dtos.ProjectData
+}
+
+func (e *ProjectVersionConflictError) Error() string {
+	return fmt.Sprintf("%s: current version is %d", repositories.ErrProjectVersionMismatch, e.Current.Version)
+}
+
+func (e *ProjectVersionConflictError) Is(target error) bool {
+	return target == repositories.ErrProjectVersionMismatch
 }
 
 type projectServiceImpl struct {
-	rabbitMQChannel *rabbitmq.Channel
-	projectRepo     repositories.ProjectRepository
-	committeeRepo   repositories.StaffRepository
-	programRepo     repositories.ProgramRepository
-	resourceRepo    repositories.ResourceRepository
+	outboxRelay      OutboxRelayService
+	auditService     AuditService
+	projectRepo      repositories.ProjectRepository
+	projectStaffRepo repositories.ProjectStaffRepository
+	committeeRepo    repositories.StaffRepository
+	programRepo      repositories.ProgramRepository
 }
 
 func NewProjectService(
-	rabbitMQChannel *rabbitmq.Channel,
+	outboxRelay OutboxRelayService,
+	auditService AuditService,
 	projectRepo repositories.ProjectRepository,
+	projectStaffRepo repositories.ProjectStaffRepository,
 	committeeRepo repositories.StaffRepository,
 	programRepo repositories.ProgramRepository,
-	resourceRepo repositories.ResourceRepository,
 ) ProjectService {
 	return &projectServiceImpl{
-		rabbitMQChannel: rabbitMQChannel,
-		resourceRepo:    resourceRepo,
-		projectRepo:     projectRepo,
-		committeeRepo:   committeeRepo,
-		programRepo:     programRepo,
+		outboxRelay:      outboxRelay,
+		auditService:     auditService,
+		projectRepo:      projectRepo,
+		projectStaffRepo: projectStaffRepo,
+		committeeRepo:    committeeRepo,
+		programRepo:      programRepo,
 	}
 }
 
-func (s *projectServiceImpl) CreateProjects(ctx context.Context, projects []models.ProjectRequest) error {
-	projectMessages, err := s.projectRepo.CreateProjects(ctx, projects)
+func (s *projectServiceImpl) CreateProjects(ctx context.Context, projects []models.ProjectRequest, prepare func(tx *gorm.DB) error) ([]*dtos.ProjectData, error) {
+	projectMessages, err := s.projectRepo.CreateProjects(ctx, projects, prepare)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	s.outboxRelay.Notify()
+
+	changes := make([]AuditChange, 0, len(projectMessages))
 	for _, projectMessage := range projectMessages {
-		err := s.PublishProjectMessageToElasticSearch(ctx, "create", projectMessage.ID)
-		if err != nil {
-			return err
-		}
+		changes = append(changes, projectAuditChange(models.AuditActionCreate, nil, projectMessage))
 	}
+	s.auditService.Record(ctx, changes...)
 
-	return nil
+	return projectMessages, nil
 }
 
-func (s *projectServiceImpl) getProjectMessage(ctx context.Context, projectId int) (*dtos.ProjectData, error) {
-	projectMessage, err := s.projectRepo.GetProjectMessageByID(ctx, projectId)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, err
+func (s *projectServiceImpl) CreateProjectWithFiles(ctx context.Context, project *models.ProjectRequest, projectResources []*models.ProjectResource, files []*multipart.FileHeader) (*dtos.ProjectData, error) {
+	if !canCreateProject(ctx, project.ProgramID) {
+		return nil, ErrProjectForbidden
 	}
 
-	if projectMessage == nil {
-		projectMessage = &dtos.ProjectData{
-			ID: projectId,
-		}
+	projectMessage, err := s.projectRepo.CreateProjectWithFiles(ctx, nil, project, projectResources, files)
+	if err != nil {
+		return nil, err
 	}
+	s.outboxRelay.Notify()
+	s.auditService.Record(ctx, projectAuditChange(models.AuditActionCreate, nil, projectMessage))
 
 	return projectMessage, nil
 }
 
-func (s *projectServiceImpl) publishMessage(ctx context.Context, action string, projectMessage *dtos.ProjectData) error {
-	if err := rabbitMQQueue.PublishMessageFromRabbitMQToElasticSearch(s.rabbitMQChannel, action, projectMessage); err != nil {
-		return err
+// projectAuditChange describes a change to a project. Either snapshot may be
+// nil, but not both.
+func projectAuditChange(action string, before, after *dtos.ProjectData) AuditChange {
+	change := AuditChange{Action: action, EntityType: models.AuditEntityProject}
+	if before != nil {
+		change.EntityID, change.ProgramID, change.Before = before.ID, before.ProgramID, before
+	}
+	if after != nil {
+		change.EntityID, change.ProgramID, change.After = after.ID, after.ProgramID, after
+	}
+	return change
+}
+
+func (s *projectServiceImpl) GetProjectWithPDFByID(ctx context.Context, id int) (*dtos.ProjectData, error) {
+	project, err := s.projectRepo.GetProjectWithPDFByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (s *projectServiceImpl) GetProjectByID(ctx context.Context, id int) (*dtos.ProjectData, error) {
+	return s.projectRepo.GetProjectByID(ctx, id)
+}
+
+func (s *projectServiceImpl) GetProjects(ctx context.Context, filter *dtos.ProjectFilter) (*dtos.ProjectPage, error) {
+	if err := normalizeProjectFilter(filter); err != nil {
+		return nil, err
+	}
+
+	return s.projectRepo.GetProjects(ctx, filter)
+}
+
+func normalizeProjectFilter(filter *dtos.ProjectFilter) error {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultProjectPageSize
+	}
+	if filter.Limit > maxProjectPageSize {
+		filter.Limit = maxProjectPageSize
+	}
+	if filter.Offset < 0 {
+		return fmt.Errorf("%w: offset must not be negative", ErrInvalidProjectFilter)
+	}
+	if filter.Cursor != "" && filter.Offset > 0 {
+		return fmt.Errorf("%w: cursor and offset cannot be combined", ErrInvalidProjectFilter)
+	}
+	if filter.Cursor != "" {
+		if _, err := utils.DecodeCursor(filter.Cursor); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidProjectFilter, err)
+		}
+	}
+
+	if filter.SortBy == "" {
+		filter.SortBy = "created_at"
+	}
+	if _, ok := repositories.ProjectSortColumns[filter.SortBy]; !ok {
+		return fmt.Errorf("%w: unsupported sort_by %q", ErrInvalidProjectFilter, filter.SortBy)
+	}
+
+	filter.Order = strings.ToLower(filter.Order)
+	if filter.Order == "" {
+		filter.Order = "desc"
+	}
+	if filter.Order != "asc" && filter.Order != "desc" {
+		return fmt.Errorf("%w: order must be asc or desc", ErrInvalidProjectFilter)
 	}
 
 	return nil
 }
 
-func (s *projectServiceImpl) PublishProjectMessageToElasticSearch(ctx context.Context, action string, projectId int) error {
-	projectMessage, err := s.getProjectMessage(ctx, projectId)
+func (s *projectServiceImpl) CheckDuplicateProjectByTitleAndSemester(ctx context.Context, titleTH, titleEN string, academicYear, semester int) (bool, error) {
+	isDuplicate, err := s.projectRepo.CheckDuplicateProjectByTitleAndSemester(ctx, titleTH, titleEN, academicYear, semester)
 	if err != nil {
-		return err
+		return true, err
 	}
 
-	return s.publishMessage(ctx, action, projectMessage)
+	return isDuplicate, nil
 }
 
-func (s *projectServiceImpl) CreateProjectWithFiles(ctx context.Context, project *models.ProjectRequest, projectResources []*models.ProjectResource, files []*multipart.FileHeader) (*dtos.ProjectData, error) {
-	projectMessage, err := s.projectRepo.CreateProjectWithFiles(ctx, nil, project, projectResources, files)
+func (s *projectServiceImpl) UpdateProjectWithFiles(ctx context.Context, project *models.ProjectRequest, version int, projectResources []*models.ProjectResource, files []*multipart.FileHeader) (*dtos.ProjectData, error) {
+	current, err := s.projectRepo.Get(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	canEdit, err := canEditProject(ctx, s.projectRepo, current)
 	if err != nil {
 		return nil, err
 	}
+	if !canEdit || !canCreateProject(ctx, project.ProgramID) {
+		return nil, ErrProjectForbidden
+	}
 
-	err = s.PublishProjectMessageToElasticSearch(ctx, "create", projectMessage.ID)
+	before, err := s.projectRepo.GetProjectByID(ctx, project.ID)
 	if err != nil {
 		return nil, err
 	}
+	if before.AcademicYear != project.AcademicYear || before.Semester != project.Semester || !sameCommittee(before.ProjectStaffs, project.ProjectStaffs) {
+		canManage, err := s.canManageProject(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		if !canManage {
+			return nil, ErrProjectForbidden
+		}
+	}
+
+	projectMessage, err := s.projectRepo.UpdateProjectWithFiles(ctx, nil, project, version, projectResources, files)
+	if err != nil {
+		return nil, versionConflict(ctx, s.projectRepo, project.ID, err)
+	}
+	s.outboxRelay.Notify()
+	s.auditService.Record(ctx, projectAuditChange(models.AuditActionUpdate, before, projectMessage))
 
 	return projectMessage, nil
 }
 
-func (s *projectServiceImpl) GetProjectWithPDFByID(ctx context.Context, id int) (*dtos.ProjectData, error) {
-	project, err := s.projectRepo.GetProjectWithPDFByID(ctx, id)
+// DeleteProject moves the project to the trash. Its files are kept until the
+// trash retention period has passed, so it can still be restored. Only
+// program staff of the project's program may delete it.
+func (s *projectServiceImpl) DeleteProject(ctx context.Context, id int, version int) error {
+	principal, _ := auth.PrincipalFromContext(ctx)
+	var deletedBy string
+	if principal != nil {
+		deletedBy = principal.Subject
+	}
+
+	before, err := s.projectRepo.GetProjectByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !principal.HasRole(before.ProgramID, auth.RoleProgramStaff) {
+		return ErrProjectForbidden
+	}
+
+	if err := s.projectRepo.DeleteProject(ctx, id, version, deletedBy); err != nil {
+		return versionConflict(ctx, s.projectRepo, id, err)
+	}
+	s.outboxRelay.Notify()
+	s.auditService.Record(ctx, projectAuditChange(models.AuditActionDelete, before, nil))
+	return nil
+}
+
+// ApproveProject accepts a proposal and moves the project to in_progress.
+// Only a staff member assigned to the project with an advisor role may
+// approve it.
+func (s *projectServiceImpl) ApproveProject(ctx context.Context, id int, comment *string) (*models.ProjectStatusHistory, error) {
+	return s.reviewProposal(ctx, id, models.ProjectStatusInProgress, comment)
+}
+
+// RejectProject sends a proposal back to the students. A comment explaining
+// the decision is required.
+func (s *projectServiceImpl) RejectProject(ctx context.Context, id int, comment *string) (*models.ProjectStatusHistory, error) {
+	return s.reviewProposal(ctx, id, models.ProjectStatusRejected, comment)
+}
+
+// reviewProposal records an advisor's decision on a proposal. Rejections,
+// however they arrive, must explain the decision in a comment.
+func (s *projectServiceImpl) reviewProposal(ctx context.Context, id int, status models.ProjectStatus, comment *string) (*models.ProjectStatusHistory, error) {
+	if status == models.ProjectStatusRejected && (comment == nil || strings.TrimSpace(*comment) == "") {
+		return nil, ErrCommentRequired
+	}
+
+	project, err := s.projectRepo.Get(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return project, nil
+	principal, _ := auth.PrincipalFromContext(ctx)
+	isAdvisor, err := s.isProjectAdvisor(ctx, id, principal)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdvisor {
+		return nil, ErrProjectStatusForbidden
+	}
+
+	return s.transitionProjectStatus(ctx, project, status, comment, principal)
 }
 
-func (s *projectServiceImpl) CheckDuplicateProjectByTitleAndSemester(ctx context.Context, titleTH, titleEN string, academicYear, semester int) (bool, error) {
-	isDuplicate, err := s.projectRepo.CheckDuplicateProjectByTitleAndSemester(ctx, titleTH, titleEN, academicYear, semester)
+// TransitionProjectStatus moves a project along its lifecycle. Decisions on a
+// proposal are reserved for the project's advisors; other transitions may also
+// be made by program staff of the project's program.
+func (s *projectServiceImpl) TransitionProjectStatus(ctx context.Context, id int, status models.ProjectStatus, comment *string) (*models.ProjectStatusHistory, error) {
+	if !status.IsValid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidProjectStatus, status)
+	}
+
+	project, err := s.projectRepo.Get(ctx, id)
 	if err != nil {
-		return true, err
+		return nil, err
+	}
+	if project.Status == models.ProjectStatusProposal {
+		return s.reviewProposal(ctx, id, status, comment)
 	}
 
-	return isDuplicate, nil
+	principal, _ := auth.PrincipalFromContext(ctx)
+	if !principal.HasRole(project.ProgramID, auth.RoleProgramStaff) {
+		isAdvisor, err := s.isProjectAdvisor(ctx, id, principal)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdvisor {
+			return nil, ErrProjectStatusForbidden
+		}
+	}
+
+	return s.transitionProjectStatus(ctx, project, status, comment, principal)
 }
 
-func (s *projectServiceImpl) UpdateProjectWithFiles(ctx context.Context, project *models.ProjectRequest, projectResources []*models.ProjectResource, files []*multipart.FileHeader) (*dtos.ProjectData, error) {
-	projectMessage, err := s.projectRepo.UpdateProjectWithFiles(ctx, nil, project, projectResources, files)
+func (s *projectServiceImpl) transitionProjectStatus(ctx context.Context, project *models.Project, status models.ProjectStatus, comment *string, principal *auth.Principal) (*models.ProjectStatusHistory, error) {
+	var changedBy string
+	if principal != nil {
+		changedBy = principal.Subject
+	}
+
+	before, err := s.projectRepo.GetProjectByID(ctx, project.ID)
 	if err != nil {
 		return nil, err
 	}
+	history, err := s.projectRepo.TransitionProjectStatus(ctx, project.ID, status, comment, changedBy)
+	if err != nil {
+		return nil, err
+	}
+	s.outboxRelay.Notify()
 
-	err = s.PublishProjectMessageToElasticSearch(ctx, "update", project.ID)
+	after, err := s.projectRepo.GetProjectByID(ctx, project.ID)
 	if err != nil {
 		return nil, err
 	}
+	s.auditService.Record(ctx, projectAuditChange(models.AuditActionUpdate, before, after))
+	return history, nil
+}
 
-	return projectMessage, nil
+func (s *projectServiceImpl) isProjectAdvisor(ctx context.Context, id int, principal *auth.Principal) (bool, error) {
+	if principal == nil {
+		return false, nil
+	}
+	if principal.IsAdmin() {
+		return true, nil
+	}
+	if principal.Email == "" {
+		return false, nil
+	}
+	return s.projectStaffRepo.IsProjectAdvisor(ctx, id, principal.Email)
 }
 
-func (s *projectServiceImpl) DeleteProject(ctx context.Context, id int) error {
-	project, err := s.projectRepo.GetProjectByID(ctx, id)
-	if err != nil {
+func (s *projectServiceImpl) GetProjectStatusHistory(ctx context.Context, id int) ([]models.ProjectStatusHistory, error) {
+	return s.projectRepo.GetProjectStatusHistory(ctx, id)
+}
+
+// versionConflict attaches the current project to a version mismatch.
+func versionConflict(ctx context.Context, projectRepo repositories.ProjectRepository, id int, err error) error {
+	if !errors.Is(err, repositories.ErrProjectVersionMismatch) {
 		return err
 	}
+	current, getErr := projectRepo.GetProjectByID(ctx, id)
+	if getErr != nil {
+		return getErr
+	}
+	return &ProjectVersionConflictError{Current: current}
+}
+
+// canCreateProject reports whether the caller holds a role in programId that
+// may add projects to it.
+func canCreateProject(ctx context.Context, programId int) bool {
+	principal, _ := auth.PrincipalFromContext(ctx)
+	return principal.HasRole(programId, auth.RoleProgramStaff, auth.RoleAdvisor, auth.RoleStudent)
+}
 
-	for _, resource := range project.ProjectResources {
-		resourceID := fmt.Sprintf("%v", resource.ID)
-		if err := s.resourceRepo.DeleteProjectResourceByID(ctx, resourceID, resource.Path); err != nil {
-			return err
+// canEditProject reports whether the caller may change the project: program
+// staff of its program, or staff and students assigned to it.
+func canEditProject(ctx context.Context, projectRepo repositories.ProjectRepository, project *models.Project) (bool, error) {
+	principal, _ := auth.PrincipalFromContext(ctx)
+	if principal == nil {
+		return false, nil
+	}
+	if principal.HasRole(project.ProgramID, auth.RoleProgramStaff) {
+		return true, nil
+	}
+	return projectRepo.IsProjectMember(ctx, project.ID, principal.Email, principal.StudentID)
+}
+
+// canManageProject reports whether the caller may change the project's
+// committee or move it to another academic year or semester: program staff of
+// its program, or one of its advisors. Students of the project may not.
+func (s *projectServiceImpl) canManageProject(ctx context.Context, project *models.Project) (bool, error) {
+	principal, _ := auth.PrincipalFromContext(ctx)
+	if principal.HasRole(project.ProgramID, auth.RoleProgramStaff) {
+		return true, nil
+	}
+	return s.isProjectAdvisor(ctx, project.ID, principal)
+}
+
+// sameCommittee reports whether staffs assigns the same staff members in the
+// same roles as the project's current committee.
+func sameCommittee(current []dtos.ProjectStaffMessage, staffs []models.ProjectStaff) bool {
+	type assignment struct{ staffId, projectRoleId int }
+	assignments := make(map[assignment]bool, len(current))
+	for _, staff := range current {
+		assignments[assignment{staff.ID, staff.ProjectRole.ID}] = true
+	}
+	requested := make(map[assignment]bool, len(staffs))
+	for _, staff := range staffs {
+		requested[assignment{staff.StaffID, staff.ProjectRoleID}] = true
+	}
+	if len(requested) != len(assignments) {
+		return false
+	}
+	for key := range requested {
+		if !assignments[key] {
+			return false
 		}
 	}
+	return true
+}
 
-	if err := s.projectRepo.Delete(ctx, id); err != nil {
-		return err
+// manageProject is editProject for changes that only canManageProject allows.
+func (s *projectServiceImpl) manageProject(ctx context.Context, id int, change func() error) (*dtos.ProjectData, error) {
+	project, err := s.projectRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	if err := s.PublishProjectMessageToElasticSearch(ctx, "delete", id); err != nil {
-		return err
+	canManage, err := s.canManageProject(ctx, project)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	if !canManage {
+		return nil, ErrProjectForbidden
+	}
+	return s.editProject(ctx, id, change)
+}
+
+// editProject checks that the caller may change the project, applies change
+// and returns the project as it is afterwards.
+func (s *projectServiceImpl) editProject(ctx context.Context, id int, change func() error) (*dtos.ProjectData, error) {
+	project, err := s.projectRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	canEdit, err := canEditProject(ctx, s.projectRepo, project)
+	if err != nil {
+		return nil, err
+	}
+	if !canEdit {
+		return nil, ErrProjectForbidden
+	}
+
+	before, err := s.projectRepo.GetProjectByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(); err != nil {
+		return nil, versionConflict(ctx, s.projectRepo, id, err)
+	}
+	s.outboxRelay.Notify()
+
+	after, err := s.projectRepo.GetProjectByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.auditService.Record(ctx, projectAuditChange(models.AuditActionUpdate, before, after))
+	return after, nil
+}
+
+// PatchProject changes only the fields present in patch. Unlike a full update
+// it never touches members, staff, keywords or resources. Moving the project
+// to another academic year or semester gives it a new project number.
+func (s *projectServiceImpl) PatchProject(ctx context.Context, id int, version int, patch *dtos.PatchProjectRequest) (*dtos.ProjectData, error) {
+	if *patch == (dtos.PatchProjectRequest{}) {
+		return nil, ErrEmptyProjectPatch
+	}
+	edit := s.editProject
+	if patch.AcademicYear != nil || patch.Semester != nil {
+		edit = s.manageProject
+	}
+	return edit(ctx, id, func() error {
+		return s.projectRepo.PatchProject(ctx, id, version, patch)
+	})
+}
+
+func (s *projectServiceImpl) AddProjectMember(ctx context.Context, id int, version int, studentId int) (*dtos.ProjectData, error) {
+	return s.editProject(ctx, id, func() error {
+		return s.projectRepo.AddProjectMember(ctx, id, version, studentId)
+	})
+}
+
+func (s *projectServiceImpl) RemoveProjectMember(ctx context.Context, id int, version int, studentId int) (*dtos.ProjectData, error) {
+	return s.editProject(ctx, id, func() error {
+		return s.projectRepo.RemoveProjectMember(ctx, id, version, studentId)
+	})
+}
+
+func (s *projectServiceImpl) AddProjectStaff(ctx context.Context, id int, version int, req *dtos.ProjectStaffRequest) (*dtos.ProjectData, error) {
+	return s.manageProject(ctx, id, func() error {
+		return s.projectRepo.AddProjectStaff(ctx, version, &models.ProjectStaff{
+			ProjectID:     id,
+			StaffID:       req.StaffID,
+			ProjectRoleID: req.ProjectRoleID,
+		})
+	})
+}
+
+func (s *projectServiceImpl) RemoveProjectStaff(ctx context.Context, id int, version int, staffId int, projectRoleId *int) (*dtos.ProjectData, error) {
+	return s.manageProject(ctx, id, func() error {
+		return s.projectRepo.RemoveProjectStaff(ctx, id, version, staffId, projectRoleId)
+	})
+}
+
+func (s *projectServiceImpl) AddProjectKeyword(ctx context.Context, id int, version int, keywordId int) (*dtos.ProjectData, error) {
+	return s.editProject(ctx, id, func() error {
+		return s.projectRepo.AddProjectKeyword(ctx, id, version, keywordId)
+	})
+}
+
+func (s *projectServiceImpl) RemoveProjectKeyword(ctx context.Context, id int, version int, keywordId int) (*dtos.ProjectData, error) {
+	return s.editProject(ctx, id, func() error {
+		return s.projectRepo.RemoveProjectKeyword(ctx, id, version, keywordId)
+	})
+}
+
+// AddProjectResource adds one file or link without touching the project's
+// other resources.
+func (s *projectServiceImpl) AddProjectResource(ctx context.Context, id int, version int, req *dtos.AddProjectResourceRequest) (*dtos.ProjectData, error) {
+	hasURL := req.URL != nil && strings.TrimSpace(*req.URL) != ""
+	if hasURL == (req.File != nil) {
+		return nil, ErrInvalidProjectResource
+	}
+
+	projectResource := &models.ProjectResource{Title: &req.Title}
+	if hasURL {
+		projectResource.URL = req.URL
+	}
+	return s.editProject(ctx, id, func() error {
+		return s.projectRepo.AddProjectResource(ctx, id, version, projectResource, req.File)
+	})
+}
+
+// RetireProjectResource removes a resource from the project while keeping its
+// version history.
+func (s *projectServiceImpl) RetireProjectResource(ctx context.Context, id int, version int, resourceId int) (*dtos.ProjectData, error) {
+	return s.editProject(ctx, id, func() error {
+		return s.projectRepo.RetireProjectResource(ctx, id, version, resourceId)
+	})
 }
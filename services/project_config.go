@@ -1,23 +1,30 @@
 package services
 
 import (
+	"context"
+	"errors"
+
 	"github.com/project-box/dtos"
 	"github.com/project-box/models"
 	"github.com/project-box/repositories"
+	"gorm.io/gorm"
 )
 
 type ProjectConfigService interface {
 	GetProjectConfigByProgramId(programId int) ([]dtos.ProjectConfigResponse, error)
-	UpsertProjectConfig(configs []dtos.ProjectConfigUpsertRequest) error
+	GetProjectConfigByID(ctx context.Context, id int) (*models.ProjectConfig, error)
+	UpsertProjectConfig(ctx context.Context, configs []dtos.ProjectConfigUpsertRequest) error
 }
 
 type projectconfigServiceImpl struct {
 	projectconfigRepo repositories.ProjectConfigRepository
+	auditService      AuditService
 }
 
-func NewProjectConfigService(projectconfigRepo repositories.ProjectConfigRepository) ProjectConfigService {
+func NewProjectConfigService(projectconfigRepo repositories.ProjectConfigRepository, auditService AuditService) ProjectConfigService {
 	return &projectconfigServiceImpl{
 		projectconfigRepo: projectconfigRepo,
+		auditService:      auditService,
 	}
 }
 
@@ -39,7 +46,11 @@ func (s *projectconfigServiceImpl) GetProjectConfigByProgramId(programId int) ([
 
 }
 
-func (s *projectconfigServiceImpl) UpsertProjectConfig(configs []dtos.ProjectConfigUpsertRequest) error {
+func (s *projectconfigServiceImpl) GetProjectConfigByID(ctx context.Context, id int) (*models.ProjectConfig, error) {
+	return s.projectconfigRepo.Get(ctx, id)
+}
+
+func (s *projectconfigServiceImpl) UpsertProjectConfig(ctx context.Context, configs []dtos.ProjectConfigUpsertRequest) error {
 	var updateProjectConfigs []models.ProjectConfig
 	var insertProjectConfigs []models.ProjectConfig
 
@@ -59,11 +70,37 @@ func (s *projectconfigServiceImpl) UpsertProjectConfig(configs []dtos.ProjectCon
 		}
 	}
 
+	var changes []AuditChange
+
 	// Handle updates if there are any
 	if len(updateProjectConfigs) > 0 {
+		before := make(map[int]*models.ProjectConfig, len(updateProjectConfigs))
+		for _, config := range updateProjectConfigs {
+			existing, err := s.projectconfigRepo.Get(ctx, config.ID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			before[config.ID] = existing
+		}
+
 		if err := s.projectconfigRepo.UpdateProjectConfig(updateProjectConfigs); err != nil {
 			return err
 		}
+		for i := range updateProjectConfigs {
+			config := &updateProjectConfigs[i]
+			change := AuditChange{
+				Action:     models.AuditActionCreate,
+				EntityType: models.AuditEntityProjectConfig,
+				EntityID:   config.ID,
+				ProgramID:  config.ProgramID,
+				After:      config,
+			}
+			// Saving an unknown ID inserts it.
+			if existing := before[config.ID]; existing != nil {
+				change.Action, change.Before = models.AuditActionUpdate, existing
+			}
+			changes = append(changes, change)
+		}
 	}
 
 	// Handle inserts if there are any
@@ -71,7 +108,18 @@ func (s *projectconfigServiceImpl) UpsertProjectConfig(configs []dtos.ProjectCon
 		if err := s.projectconfigRepo.InsertProjectConfig(insertProjectConfigs); err != nil {
 			return err
 		}
+		for i := range insertProjectConfigs {
+			config := &insertProjectConfigs[i]
+			changes = append(changes, AuditChange{
+				Action:     models.AuditActionCreate,
+				EntityType: models.AuditEntityProjectConfig,
+				EntityID:   config.ID,
+				ProgramID:  config.ProgramID,
+				After:      config,
+			})
+		}
 	}
 
+	s.auditService.Record(ctx, changes...)
 	return nil
 }
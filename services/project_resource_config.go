@@ -2,31 +2,35 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"mime/multipart"
 
-	"github.com/minio/minio-go/v7"
 	"github.com/project-box/dtos"
 	"github.com/project-box/models"
 	"github.com/project-box/repositories"
+	"gorm.io/gorm"
 )
 
 type ProjectResourceConfigService interface {
 	GetProjectResourceConfigsByProgramId(ctx context.Context, programID int) ([]dtos.ProjectResourceConfig, error)
-	UpsertResourceProjectConfig(config *models.ProjectResourceConfig) error
+	GetProjectResourceConfigByID(ctx context.Context, id int) (*models.ProjectResourceConfig, error)
+	UpsertResourceProjectConfig(ctx context.Context, config *models.ProjectResourceConfig) error
 	UpsertResourceProjectConfigV2(ctx context.Context, req dtos.CreateProjectResourceConfigRequest) error
 }
 
 type projectResourceConfigServiceImpl struct {
 	uploadService             UploadService
 	programService            ProgramService
+	auditService              AuditService
 	projectResourceConfigRepo repositories.ProjectResourceConfigRepository
 }
 
-func NewProjectResourceConfigService(projectResourceConfigRepository repositories.ProjectResourceConfigRepository, programService ProgramService, uploadService UploadService) ProjectResourceConfigService {
+func NewProjectResourceConfigService(projectResourceConfigRepository repositories.ProjectResourceConfigRepository, programService ProgramService, uploadService UploadService, auditService AuditService) ProjectResourceConfigService {
 	return &projectResourceConfigServiceImpl{
 		programService:            programService,
 		uploadService:             uploadService,
+		auditService:              auditService,
 		projectResourceConfigRepo: projectResourceConfigRepository,
 	}
 }
@@ -66,8 +70,14 @@ func (s *projectResourceConfigServiceImpl) GetProjectResourceConfigsByProgramId(
 	return projectResourceConfigResponses, nil
 }
 
-func (s *projectResourceConfigServiceImpl) UpsertResourceProjectConfig(config *models.ProjectResourceConfig) error {
-	return s.projectResourceConfigRepo.UpsertResourceProjectConfig(config)
+func (s *projectResourceConfigServiceImpl) GetProjectResourceConfigByID(ctx context.Context, id int) (*models.ProjectResourceConfig, error) {
+	return s.projectResourceConfigRepo.Get(ctx, id)
+}
+
+func (s *projectResourceConfigServiceImpl) UpsertResourceProjectConfig(ctx context.Context, config *models.ProjectResourceConfig) error {
+	return s.saveConfig(ctx, config, func() error {
+		return s.projectResourceConfigRepo.UpsertResourceProjectConfig(config)
+	})
 }
 
 func (s *projectResourceConfigServiceImpl) UpsertResourceProjectConfigV2(ctx context.Context, req dtos.CreateProjectResourceConfigRequest) error {
@@ -86,7 +96,40 @@ func (s *projectResourceConfigServiceImpl) UpsertResourceProjectConfigV2(ctx con
 		}
 	}
 
-	return s.projectResourceConfigRepo.UpsertResourceProjectConfigV2(req.ProjectResourceConfig)
+	return s.saveConfig(ctx, req.ProjectResourceConfig, func() error {
+		return s.projectResourceConfigRepo.UpsertResourceProjectConfigV2(req.ProjectResourceConfig)
+	})
+}
+
+// saveConfig runs save and records the change to config in the audit log.
+func (s *projectResourceConfigServiceImpl) saveConfig(ctx context.Context, config *models.ProjectResourceConfig, save func() error) error {
+	var before *models.ProjectResourceConfig
+	if config.ID != 0 {
+		existing, err := s.projectResourceConfigRepo.Get(ctx, config.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		before = existing
+	}
+
+	if err := save(); err != nil {
+		return err
+	}
+
+	change := AuditChange{
+		Action:     models.AuditActionCreate,
+		EntityType: models.AuditEntityProjectResourceConfig,
+		EntityID:   config.ID,
+		After:      config,
+	}
+	if config.ProgramID != nil {
+		change.ProgramID = *config.ProgramID
+	}
+	if before != nil {
+		change.Action, change.Before = models.AuditActionUpdate, before
+	}
+	s.auditService.Record(ctx, change)
+	return nil
 }
 
 func (s *projectResourceConfigServiceImpl) handleExistingIcon(ctx context.Context, programNameTH string, config *models.ProjectResourceConfig) error {
@@ -96,7 +139,7 @@ func (s *projectResourceConfigServiceImpl) handleExistingIcon(ctx context.Contex
 			return err
 		}
 		if existingConfig.IconName != nil {
-			err := s.uploadService.RemoveObject(ctx, "icons", fmt.Sprintf("%s/%s", programNameTH, *existingConfig.IconName), minio.RemoveObjectOptions{})
+			err := s.uploadService.RemoveObject(ctx, "icons", fmt.Sprintf("%s/%s", programNameTH, *existingConfig.IconName))
 			if err != nil {
 				return err
 			}
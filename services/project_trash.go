@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/project-box/auth"
+	"github.com/project-box/configs"
+	storage "github.com/project-box/db/storage"
+	"github.com/project-box/dtos"
+	"github.com/project-box/models"
+	"github.com/project-box/repositories"
+	"gorm.io/gorm"
+)
+
+// ProjectTrashService manages deleted projects: listing and restoring them,
+// and purging them once the retention period has passed. Its purge job also
+// removes uploads that expired before being attached to a project.
+type ProjectTrashService interface {
+	GetTrash(ctx context.Context, programId int) ([]dtos.TrashedProject, error)
+	RestoreProject(ctx context.Context, id int) (*dtos.ProjectData, error)
+	// PurgeExpired permanently removes projects whose retention period has
+	// passed and reports how many were removed.
+	PurgeExpired(ctx context.Context) (int, error)
+	// PurgeExpiredUploads removes expired pending uploads together with
+	// their objects and any chunks of resumable uploads, and reports how
+	// many were removed.
+	PurgeExpiredUploads(ctx context.Context) (int, error)
+}
+
+const projectPurgeBatchSize = 50
+
+var ErrTrashForbidden = errors.New("only program staff may manage the program's trash")
+
+type projectTrashServiceImpl struct {
+	projectRepo       repositories.ProjectRepository
+	pendingUploadRepo repositories.PendingUploadRepository
+	storage           storage.ObjectStorage
+	outboxRelay       OutboxRelayService
+	auditService      AuditService
+	logger            *slog.Logger
+	config            *configs.ProjectTrashConfig
+}
+
+// NewProjectTrashService starts the purge job. The returned cleanup function
+// stops it.
+func NewProjectTrashService(projectRepo repositories.ProjectRepository, pendingUploadRepo repositories.PendingUploadRepository, objectStorage storage.ObjectStorage, outboxRelay OutboxRelayService, auditService AuditService, logger *slog.Logger) (ProjectTrashService, func()) {
+	service := &projectTrashServiceImpl{
+		projectRepo:       projectRepo,
+		pendingUploadRepo: pendingUploadRepo,
+		storage:           objectStorage,
+		outboxRelay:       outboxRelay,
+		auditService:      auditService,
+		logger:            logger.With("job", "project_purge"),
+		config:            configs.GetProjectTrashConfig(),
+	}
+
+	workers := newBackgroundWorkers()
+	workers.Go(service.run)
+
+	return service, workers.Stop
+}
+
+func (s *projectTrashServiceImpl) run(ctx context.Context, stopping <-chan struct{}) {
+	ticker := time.NewTicker(s.config.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if purged, err := s.PurgeExpired(ctx); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to purge expired projects", "error", err)
+		} else if purged > 0 {
+			s.logger.InfoContext(ctx, "Removed projects from the trash", "purged", purged)
+		}
+		if removed, err := s.PurgeExpiredUploads(ctx); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to remove expired uploads", "error", err)
+		} else if removed > 0 {
+			s.logger.InfoContext(ctx, "Removed expired uploads", "removed", removed)
+		}
+
+		select {
+		case <-stopping:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *projectTrashServiceImpl) GetTrash(ctx context.Context, programId int) ([]dtos.TrashedProject, error) {
+	principal, _ := auth.PrincipalFromContext(ctx)
+	if !principal.HasRole(programId, auth.RoleProgramStaff) {
+		return nil, ErrTrashForbidden
+	}
+
+	projects, err := s.projectRepo.GetDeletedProjects(ctx, programId)
+	if err != nil {
+		return nil, err
+	}
+
+	trash := make([]dtos.TrashedProject, 0, len(projects))
+	for _, project := range projects {
+		trash = append(trash, dtos.TrashedProject{
+			ID:           project.ID,
+			ProjectNo:    project.ProjectNo,
+			TitleTH:      project.TitleTH,
+			TitleEN:      project.TitleEN,
+			AcademicYear: project.AcademicYear,
+			Semester:     project.Semester,
+			Status:       string(project.Status),
+			DeletedAt:    project.DeletedAt.Time,
+			DeletedBy:    project.DeletedBy,
+			PurgeAt:      project.DeletedAt.Time.Add(s.config.Retention),
+		})
+	}
+	return trash, nil
+}
+
+// RestoreProject brings a project back from the trash with all of its
+// members, staff, keywords and resources.
+func (s *projectTrashServiceImpl) RestoreProject(ctx context.Context, id int) (*dtos.ProjectData, error) {
+	project, err := s.projectRepo.GetDeletedProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	principal, _ := auth.PrincipalFromContext(ctx)
+	if !principal.HasRole(project.ProgramID, auth.RoleProgramStaff) {
+		return nil, ErrTrashForbidden
+	}
+
+	if err := s.projectRepo.RestoreProject(ctx, id); err != nil {
+		return nil, err
+	}
+	s.outboxRelay.Notify()
+	s.auditService.Record(ctx, AuditChange{
+		Action:     models.AuditActionRestore,
+		EntityType: models.AuditEntityProject,
+		EntityID:   project.ID,
+		ProgramID:  project.ProgramID,
+		Before:     map[string]any{"deleted_at": project.DeletedAt.Time, "deleted_by": project.DeletedBy},
+		After:      map[string]any{"deleted_at": nil, "deleted_by": nil},
+	})
+	return s.projectRepo.GetProjectByID(ctx, id)
+}
+
+func (s *projectTrashServiceImpl) PurgeExpired(ctx context.Context) (int, error) {
+	deletedBefore := time.Now().Add(-s.config.Retention)
+
+	purged := 0
+	for {
+		ids, err := s.projectRepo.GetPurgeableProjectIDs(ctx, deletedBefore, projectPurgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		for _, id := range ids {
+			project, err := s.projectRepo.GetDeletedProject(ctx, id)
+			if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, repositories.ErrProjectNotDeleted) {
+				// Restored since it was listed.
+				continue
+			}
+			if err != nil {
+				return purged, err
+			}
+
+			paths, err := s.projectRepo.PurgeProject(ctx, id, deletedBefore)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			if err != nil {
+				return purged, err
+			}
+			purged++
+			s.auditService.Record(ctx, AuditChange{
+				Action:     models.AuditActionPurge,
+				EntityType: models.AuditEntityProject,
+				EntityID:   project.ID,
+				ProgramID:  project.ProgramID,
+				Before:     project,
+			})
+			s.removeFiles(ctx, id, paths)
+		}
+
+		if len(ids) < projectPurgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// removeFiles deletes the stored files of a purged project. The rows are gone
+// already, so a failure only leaves an orphaned object behind and is logged
+// rather than retried.
+func (s *projectTrashServiceImpl) removeFiles(ctx context.Context, projectId int, paths []string) {
+	for _, path := range paths {
+		// Path is stored as "<bucket>/<object key>".
+		bucket, key, ok := strings.Cut(path, "/")
+		if !ok || key == "" {
+			continue
+		}
+		if err := s.storage.RemoveObject(ctx, bucket, key); err != nil {
+			s.logger.WarnContext(ctx, "Failed to remove file of purged project", "path", path, "project_id", projectId, "error", err)
+		}
+	}
+}
+
+func (s *projectTrashServiceImpl) PurgeExpiredUploads(ctx context.Context) (int, error) {
+	expiredBefore := time.Now()
+
+	removed := 0
+	for {
+		uploads, err := s.pendingUploadRepo.GetExpiredPendingUploads(ctx, expiredBefore, projectPurgeBatchSize)
+		if err != nil {
+			return removed, err
+		}
+
+		for i := range uploads {
+			// The row goes first: once it is gone no project can claim the
+			// upload, so removing its objects cannot break a finalize.
+			deleted, err := s.pendingUploadRepo.DeleteExpiredPendingUpload(ctx, uploads[i].ID, expiredBefore)
+			if err != nil {
+				return removed, err
+			}
+			if !deleted {
+				continue
+			}
+			removed++
+			s.removeUploadObjects(ctx, &uploads[i])
+		}
+
+		if len(uploads) < projectPurgeBatchSize {
+			return removed, nil
+		}
+	}
+}
+
+// removeUploadObjects deletes the object of an expired upload and any chunks
+// left by a resumable upload that never completed. Failures are logged; the
+// objects are orphaned but unreachable.
+func (s *projectTrashServiceImpl) removeUploadObjects(ctx context.Context, upload *models.PendingUpload) {
+	keys := []string{upload.ObjectKey}
+	if upload.Resumable {
+		parts, err := s.storage.ListObjects(ctx, upload.Bucket, uploadPartsPrefix(upload))
+		if err != nil {
+			s.logger.WarnContext(ctx, "Failed to list chunks of expired upload", "upload_id", upload.ID, "error", err)
+		}
+		for _, part := range parts {
+			keys = append(keys, part.Key)
+		}
+	}
+
+	for _, key := range keys {
+		if err := s.storage.RemoveObject(ctx, upload.Bucket, key); err != nil {
+			s.logger.WarnContext(ctx, "Failed to remove object of expired upload", "key", key, "upload_id", upload.ID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,626 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/project-box/auth"
+	"github.com/project-box/configs"
+	storage "github.com/project-box/db/storage"
+	"github.com/project-box/dtos"
+	"github.com/project-box/models"
+	"github.com/project-box/repositories"
+	"github.com/project-box/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	maxUploadsPerRequest = 20
+	// uploadChunkLease is how long a chunk stays reserved for the request
+	// that is storing it.
+	uploadChunkLease = 5 * time.Minute
+)
+
+var (
+	ErrInvalidUpload   = errors.New("invalid upload")
+	ErrUploadNotFound  = errors.New("upload not found")
+	ErrUploadExpired   = errors.New("upload has expired")
+	ErrUploadMismatch  = errors.New("uploaded file does not match its declaration")
+	ErrUploadForbidden = errors.New("uploads require a signed-in user")
+	ErrUploadOffset    = errors.New("chunk does not start at the current upload offset")
+)
+
+// UploadOffsetError is returned when a chunk is sent for the wrong offset,
+// typically after a dropped connection. Offset is where the client should
+// resume. It matches ErrUploadOffset.
+type UploadOffsetError struct {
+	Offset int64
+}
+
+func (e *UploadOffsetError) Error() string {
+	return fmt.Sprintf("%s: expected offset %d", ErrUploadOffset, e.Offset)
+}
+
+func (e *UploadOffsetError) Is(target error) bool {
+	return target == ErrUploadOffset
+}
+
+type ProjectUploadService interface {
+	RequestUploads(ctx context.Context, req *dtos.ProjectUploadRequest) (*dtos.ProjectUploadResponse, error)
+	FinalizeProject(ctx context.Context, req *dtos.FinalizeProjectRequest) (*dtos.ProjectData, error)
+	StartResumableUpload(ctx context.Context, file *dtos.ProjectUploadFile) (*dtos.ResumableUploadStatus, error)
+	GetResumableUpload(ctx context.Context, id int) (*dtos.ResumableUploadStatus, error)
+	WriteUploadChunk(ctx context.Context, id int, offset int64, chunk io.Reader, length int64) (*dtos.ResumableUploadStatus, error)
+}
+
+type projectUploadServiceImpl struct {
+	pendingUploadRepo repositories.PendingUploadRepository
+	projectRepo       repositories.ProjectRepository
+	fileExtensionRepo repositories.FileExtensionRepository
+	storage           storage.ObjectStorage
+	outboxRelay       OutboxRelayService
+	auditService      AuditService
+	logger            *slog.Logger
+	bucketName        string
+	config            *configs.UploadConfig
+}
+
+func NewProjectUploadService(pendingUploadRepo repositories.PendingUploadRepository, projectRepo repositories.ProjectRepository, fileExtensionRepo repositories.FileExtensionRepository, objectStorage storage.ObjectStorage, outboxRelay OutboxRelayService, auditService AuditService, logger *slog.Logger) ProjectUploadService {
+	return &projectUploadServiceImpl{
+		pendingUploadRepo: pendingUploadRepo,
+		projectRepo:       projectRepo,
+		fileExtensionRepo: fileExtensionRepo,
+		storage:           objectStorage,
+		outboxRelay:       outboxRelay,
+		auditService:      auditService,
+		logger:            logger,
+		bucketName:        os.Getenv("MINIO_PROJECT_BUCKET"),
+		config:            configs.GetUploadConfig(),
+	}
+}
+
+func currentSubject(ctx context.Context) (string, error) {
+	principal, _ := auth.PrincipalFromContext(ctx)
+	if principal == nil || principal.Subject == "" {
+		return "", ErrUploadForbidden
+	}
+	return principal.Subject, nil
+}
+
+// RequestUploads records the declared files and hands out one presigned PUT
+// URL per file so the bytes go straight to object storage.
+func (s *projectUploadServiceImpl) RequestUploads(ctx context.Context, req *dtos.ProjectUploadRequest) (*dtos.ProjectUploadResponse, error) {
+	subject, err := currentSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Files) > maxUploadsPerRequest {
+		return nil, fmt.Errorf("%w: at most %d files per request", ErrInvalidUpload, maxUploadsPerRequest)
+	}
+
+	now := time.Now()
+	uploads := make([]models.PendingUpload, 0, len(req.Files))
+	for _, file := range req.Files {
+		upload, err := s.newPendingUpload(ctx, subject, file, now)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, *upload)
+	}
+
+	if err := s.pendingUploadRepo.CreatePendingUploads(ctx, uploads); err != nil {
+		return nil, err
+	}
+
+	response := &dtos.ProjectUploadResponse{Uploads: make([]dtos.ProjectUploadTarget, 0, len(uploads))}
+	for _, upload := range uploads {
+		signedURL, err := s.storage.PresignPutObject(ctx, upload.Bucket, upload.ObjectKey, s.config.URLTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to presign upload: %w", err)
+		}
+		response.Uploads = append(response.Uploads, dtos.ProjectUploadTarget{
+			UploadID:  upload.ID,
+			FileName:  upload.FileName,
+			Method:    http.MethodPut,
+			URL:       signedURL.String(),
+			Headers:   map[string]string{"Content-Type": upload.ContentType},
+			ExpiresAt: now.Add(s.config.URLTTL),
+		})
+	}
+	return response, nil
+}
+
+func (s *projectUploadServiceImpl) newPendingUpload(ctx context.Context, subject string, file dtos.ProjectUploadFile, now time.Time) (*models.PendingUpload, error) {
+	if file.Size > s.config.MaxFileSize {
+		return nil, fmt.Errorf("%w: %s is larger than %d MiB", ErrInvalidUpload, file.FileName, s.config.MaxFileSize>>20)
+	}
+	contentType := baseMediaType(file.ContentType)
+	if _, err := s.fileExtensionRepo.GetFileExtensionByMimeType(ctx, nil, contentType); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: unsupported content type %q", ErrInvalidUpload, file.ContentType)
+		}
+		return nil, err
+	}
+
+	fileName := sanitizeFileName(file.FileName)
+	return &models.PendingUpload{
+		Bucket:         s.bucketName,
+		ObjectKey:      fmt.Sprintf("uploads/%s", generateUniqueFileName(fileName)),
+		FileName:       fileName,
+		ContentType:    contentType,
+		Size:           file.Size,
+		ChecksumSHA256: strings.ToLower(file.ChecksumSHA256),
+		CreatedBy:      subject,
+		ExpiresAt:      now.Add(s.config.Expiry),
+	}, nil
+}
+
+// FinalizeProject creates a project from uploads made with RequestUploads or
+// completed through WriteUploadChunk.
+// Every uploaded object is checked against its declared size, SHA-256 and
+// content type before any ProjectResource row is written.
+func (s *projectUploadServiceImpl) FinalizeProject(ctx context.Context, req *dtos.FinalizeProjectRequest) (*dtos.ProjectData, error) {
+	subject, err := currentSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !canCreateProject(ctx, req.Project.ProgramID) {
+		return nil, ErrProjectForbidden
+	}
+
+	uploads, err := s.loadUploads(ctx, subject, req.ProjectResources)
+	if err != nil {
+		return nil, err
+	}
+
+	projectResources := make([]*models.ProjectResource, 0, len(req.ProjectResources))
+	uploadIds := make([]int, 0, len(uploads))
+	for _, resource := range req.ProjectResources {
+		if resource.UploadID == nil {
+			projectResources = append(projectResources, &models.ProjectResource{Title: resource.Title, URL: resource.URL})
+			continue
+		}
+
+		upload := uploads[*resource.UploadID]
+		projectResource, err := s.verifyUpload(ctx, upload)
+		if err != nil {
+			return nil, err
+		}
+		projectResource.Title = resource.Title
+		projectResources = append(projectResources, projectResource)
+		uploadIds = append(uploadIds, upload.ID)
+	}
+
+	project, err := s.projectRepo.CreateProjectWithStagedFiles(ctx, req.Project, projectResources, uploadIds)
+	if err != nil {
+		return nil, err
+	}
+	s.outboxRelay.Notify()
+	s.auditService.Record(ctx, projectAuditChange(models.AuditActionCreate, nil, project))
+
+	return project, nil
+}
+
+func (s *projectUploadServiceImpl) loadUploads(ctx context.Context, subject string, resources []dtos.FinalizeProjectResource) (map[int]*models.PendingUpload, error) {
+	var ids []int
+	seen := map[int]bool{}
+	for _, resource := range resources {
+		if (resource.UploadID == nil) == (resource.URL == nil) {
+			return nil, fmt.Errorf("%w: each resource needs either a url or an upload_id", ErrInvalidUpload)
+		}
+		if resource.Title == nil || *resource.Title == "" {
+			return nil, fmt.Errorf("%w: title is required", ErrInvalidUpload)
+		}
+		if resource.UploadID == nil {
+			continue
+		}
+		if seen[*resource.UploadID] {
+			return nil, fmt.Errorf("%w: upload %d is used more than once", ErrInvalidUpload, *resource.UploadID)
+		}
+		seen[*resource.UploadID] = true
+		ids = append(ids, *resource.UploadID)
+	}
+
+	rows, err := s.pendingUploadRepo.GetPendingUploadsByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	uploads := make(map[int]*models.PendingUpload, len(rows))
+	for i := range rows {
+		// Someone else's upload is reported as missing rather than forbidden.
+		if rows[i].CreatedBy == subject {
+			uploads[rows[i].ID] = &rows[i]
+		}
+	}
+
+	now := time.Now()
+	for _, id := range ids {
+		upload, ok := uploads[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrUploadNotFound, id)
+		}
+		if now.After(upload.ExpiresAt) {
+			return nil, fmt.Errorf("%w: %d", ErrUploadExpired, id)
+		}
+	}
+	return uploads, nil
+}
+
+// verifyUpload reads the stored object back, checks it against the
+// declaration and returns the resource to attach. PDFs up to MaxPDFTextSize
+// are kept in memory for text extraction; larger PDFs and other files are
+// only hashed.
+func (s *projectUploadServiceImpl) verifyUpload(ctx context.Context, upload *models.PendingUpload) (*models.ProjectResource, error) {
+	object, info, err := s.storage.GetObject(ctx, upload.Bucket, upload.ObjectKey)
+	if errors.Is(err, storage.ErrObjectNotFound) && upload.Resumable && upload.ReceivedBytes == upload.Size {
+		// Every chunk arrived but assembling them failed; try again.
+		if err := s.assembleUpload(ctx, upload); err != nil {
+			return nil, err
+		}
+		object, info, err = s.storage.GetObject(ctx, upload.Bucket, upload.ObjectKey)
+	}
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, fmt.Errorf("%w: %s has not been uploaded", ErrUploadMismatch, upload.FileName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+
+	if info.Size != upload.Size {
+		return nil, fmt.Errorf("%w: %s is %d bytes, expected %d", ErrUploadMismatch, upload.FileName, info.Size, upload.Size)
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(object, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	head = head[:n]
+
+	hash := sha256.New()
+	hash.Write(head)
+
+	extractText := upload.ContentType == "application/pdf" && upload.Size <= s.config.MaxPDFTextSize
+	var content bytes.Buffer
+	if extractText {
+		content.Grow(int(upload.Size))
+		content.Write(head)
+		_, err = io.Copy(io.MultiWriter(hash, &content), object)
+	} else {
+		_, err = io.Copy(hash, object)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if hex.EncodeToString(hash.Sum(nil)) != upload.ChecksumSHA256 {
+		return nil, fmt.Errorf("%w: checksum of %s does not match", ErrUploadMismatch, upload.FileName)
+	}
+	if sniffed := http.DetectContentType(head); !sniffedTypeMatches(upload.ContentType, sniffed) {
+		return nil, fmt.Errorf("%w: %s looks like %s, not %s", ErrUploadMismatch, upload.FileName, baseMediaType(sniffed), upload.ContentType)
+	}
+
+	fileExtension, err := s.fileExtensionRepo.GetFileExtensionByMimeType(ctx, nil, upload.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	var pdf *models.PDF
+	if extractText {
+		if pdf, err = utils.ReadPdfBytes(ctx, content.Bytes()); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUploadMismatch, err)
+		}
+	}
+
+	filePath := upload.Bucket + "/" + upload.ObjectKey
+	fileName := upload.FileName
+	return &models.ProjectResource{
+		ResourceName:    &fileName,
+		Path:            &filePath,
+		PDF:             pdf,
+		FileExtensionID: &fileExtension.ID,
+	}, nil
+}
+
+func baseMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
+
+// sniffedTypeMatches reports whether the type sniffed from the first bytes of
+// a file is consistent with the declared type. Sniffing recognises only a
+// handful of formats, so generic results are accepted where they are the best
+// the sniffer can do for the declared type.
+func sniffedTypeMatches(declared, sniffed string) bool {
+	declared = baseMediaType(declared)
+	sniffed = baseMediaType(sniffed)
+
+	switch sniffed {
+	case declared, "application/octet-stream":
+		return true
+	case "application/zip":
+		// Office Open XML and OpenDocument files are zip archives.
+		return strings.Contains(declared, "openxmlformats") || strings.Contains(declared, "opendocument") || strings.HasSuffix(declared, "+zip")
+	case "text/plain":
+		return strings.HasPrefix(declared, "text/") || declared == "application/json" || strings.HasSuffix(declared, "+json")
+	case "text/xml":
+		return strings.HasSuffix(declared, "xml")
+	default:
+		return false
+	}
+}
+
+func sanitizeFileName(fileName string) string {
+	fileName = path.Base(strings.ReplaceAll(fileName, `\`, "/"))
+	if fileName == "." || fileName == "/" || fileName == ".." {
+		return "file"
+	}
+	return fileName
+}
+
+func generateUniqueFileName(fileName string) string {
+	return fmt.Sprintf("%d_%s", time.Now().UnixNano(), fileName)
+}
+
+// StartResumableUpload declares a file that will be sent in chunks through
+// WriteUploadChunk. Once every byte has arrived the chunks are joined into one
+// object and the upload can be attached with FinalizeProject.
+func (s *projectUploadServiceImpl) StartResumableUpload(ctx context.Context, file *dtos.ProjectUploadFile) (*dtos.ResumableUploadStatus, error) {
+	subject, err := currentSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := s.newPendingUpload(ctx, subject, *file, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	upload.Resumable = true
+
+	uploads := []models.PendingUpload{*upload}
+	if err := s.pendingUploadRepo.CreatePendingUploads(ctx, uploads); err != nil {
+		return nil, err
+	}
+	return s.resumableUploadStatus(&uploads[0]), nil
+}
+
+func (s *projectUploadServiceImpl) GetResumableUpload(ctx context.Context, id int) (*dtos.ResumableUploadStatus, error) {
+	subject, err := currentSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := s.pendingUploadRepo.GetPendingUploadByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResumableUpload(upload, subject); err != nil {
+		return nil, err
+	}
+	return s.resumableUploadStatus(upload), nil
+}
+
+// WriteUploadChunk stores length bytes at offset as a separate part object.
+// A chunk that does not start where the previous one ended is rejected with
+// an UploadOffsetError, so a client that lost its connection can ask for the
+// status and resend from there. The chunk is reserved with a short lease and
+// stored outside any transaction; only then is the offset advanced. The chunk
+// that completes the file triggers assembly of the final object.
+func (s *projectUploadServiceImpl) WriteUploadChunk(ctx context.Context, id int, offset int64, chunk io.Reader, length int64) (*dtos.ResumableUploadStatus, error) {
+	subject, err := currentSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if length <= 0 || length > s.config.MaxChunkSize {
+		return nil, fmt.Errorf("%w: chunks must be between 1 byte and %d MiB", ErrInvalidUpload, s.config.MaxChunkSize>>20)
+	}
+
+	upload, err := s.pendingUploadRepo.GetPendingUploadByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResumableUpload(upload, subject); err != nil {
+		return nil, err
+	}
+	if offset != upload.ReceivedBytes {
+		return nil, &UploadOffsetError{Offset: upload.ReceivedBytes}
+	}
+	if offset+length > upload.Size {
+		return nil, fmt.Errorf("%w: chunk ends past the declared size of %d bytes", ErrInvalidUpload, upload.Size)
+	}
+
+	// Postgres keeps microseconds, and the lease end is compared for equality
+	// when the chunk is accepted.
+	leaseUntil := time.Now().Add(uploadChunkLease).Truncate(time.Microsecond)
+	reserved, err := s.pendingUploadRepo.ReserveUploadChunk(ctx, id, offset, leaseUntil)
+	if err != nil {
+		return nil, err
+	}
+	if !reserved {
+		return nil, s.chunkConflict(ctx, id)
+	}
+
+	// The write must not outlive the lease, or it could overwrite the part of
+	// a request that reserved the same offset after the lease ran out.
+	writeCtx, cancel := context.WithDeadline(ctx, leaseUntil)
+	_, err = s.storage.PutObject(writeCtx, upload.Bucket, uploadPartKey(upload, offset), chunk, length, storage.PutOptions{})
+	cancel()
+	if err != nil {
+		if err := s.pendingUploadRepo.ReleaseUploadChunk(context.WithoutCancel(ctx), id, leaseUntil); err != nil {
+			s.logger.WarnContext(ctx, "Failed to release upload chunk", "upload_id", id, "error", err)
+		}
+		return nil, fmt.Errorf("failed to store chunk: %w", err)
+	}
+
+	advanced, err := s.pendingUploadRepo.AdvanceUpload(ctx, id, offset, length, leaseUntil)
+	if err != nil {
+		return nil, err
+	}
+	if !advanced {
+		return nil, s.chunkConflict(ctx, id)
+	}
+	upload.ReceivedBytes = offset + length
+
+	if upload.ReceivedBytes == upload.Size {
+		// A failed assembly is retried when the project is finalized.
+		if err := s.assembleUpload(ctx, upload); err != nil {
+			return nil, err
+		}
+	}
+	return s.resumableUploadStatus(upload), nil
+}
+
+// chunkConflict explains why a chunk could not be reserved or accepted: the
+// upload has moved on to another offset, or another request is writing it.
+func (s *projectUploadServiceImpl) chunkConflict(ctx context.Context, id int) error {
+	upload, err := s.pendingUploadRepo.GetPendingUploadByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUploadNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if upload.ChunkLeaseUntil != nil && time.Now().Before(*upload.ChunkLeaseUntil) {
+		return repositories.ErrPendingUploadBusy
+	}
+	return &UploadOffsetError{Offset: upload.ReceivedBytes}
+}
+
+func checkResumableUpload(upload *models.PendingUpload, subject string) error {
+	if upload.CreatedBy != subject || !upload.Resumable {
+		return ErrUploadNotFound
+	}
+	if time.Now().After(upload.ExpiresAt) {
+		return ErrUploadExpired
+	}
+	return nil
+}
+
+func (s *projectUploadServiceImpl) resumableUploadStatus(upload *models.PendingUpload) *dtos.ResumableUploadStatus {
+	return &dtos.ResumableUploadStatus{
+		UploadID:     upload.ID,
+		FileName:     upload.FileName,
+		Size:         upload.Size,
+		Offset:       upload.ReceivedBytes,
+		MaxChunkSize: s.config.MaxChunkSize,
+		Complete:     upload.ReceivedBytes == upload.Size,
+		ExpiresAt:    upload.ExpiresAt,
+	}
+}
+
+func uploadPartsPrefix(upload *models.PendingUpload) string {
+	return upload.ObjectKey + ".parts/"
+}
+
+// uploadPartKey zero-pads the offset so parts list in byte order.
+func uploadPartKey(upload *models.PendingUpload, offset int64) string {
+	return fmt.Sprintf("%s%020d", uploadPartsPrefix(upload), offset)
+}
+
+// assembleUpload streams the parts, in order, into the upload's final object
+// and removes them.
+func (s *projectUploadServiceImpl) assembleUpload(ctx context.Context, upload *models.PendingUpload) error {
+	parts, err := s.storage.ListObjects(ctx, upload.Bucket, uploadPartsPrefix(upload))
+	if err != nil {
+		return err
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Key < parts[j].Key })
+
+	// Parts are keyed by offset and only accepted in sequence, but check that
+	// they tile the file exactly before trusting them.
+	keys := make([]string, 0, len(parts))
+	var next int64
+	for _, part := range parts {
+		if part.Key != uploadPartKey(upload, next) {
+			return fmt.Errorf("upload %d is missing the chunk at offset %d", upload.ID, next)
+		}
+		keys = append(keys, part.Key)
+		next += part.Size
+	}
+	if next != upload.Size {
+		return fmt.Errorf("upload %d has %d of %d bytes", upload.ID, next, upload.Size)
+	}
+
+	reader := &partReader{ctx: ctx, storage: s.storage, bucket: upload.Bucket, keys: keys}
+	defer reader.Close()
+	if _, err := s.storage.PutObject(ctx, upload.Bucket, upload.ObjectKey, reader, upload.Size, storage.PutOptions{ContentType: upload.ContentType}); err != nil {
+		return fmt.Errorf("failed to assemble upload: %w", err)
+	}
+
+	for _, key := range keys {
+		if err := s.storage.RemoveObject(ctx, upload.Bucket, key); err != nil {
+			s.logger.WarnContext(ctx, "Failed to remove upload part", "key", key, "error", err)
+		}
+	}
+	return nil
+}
+
+// partReader reads a sequence of objects as one stream, opening each only
+// when the previous one is exhausted.
+type partReader struct {
+	ctx     context.Context
+	storage storage.ObjectStorage
+	bucket  string
+	keys    []string
+	current io.ReadCloser
+}
+
+func (r *partReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.keys) == 0 {
+				return 0, io.EOF
+			}
+			object, _, err := r.storage.GetObject(r.ctx, r.bucket, r.keys[0])
+			if err != nil {
+				return 0, err
+			}
+			r.current = object
+			r.keys = r.keys[1:]
+		}
+
+		n, err := r.current.Read(p)
+		if errors.Is(err, io.EOF) {
+			r.current.Close()
+			r.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (r *partReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/project-box/configs"
+	rabbitMQ "github.com/project-box/db/rabbitmq"
+	"github.com/project-box/dtos"
+	rabbitMQQueue "github.com/project-box/queues/rabbitmq"
+	"github.com/project-box/repositories"
+	"golang.org/x/time/rate"
+	"gorm.io/gorm"
+)
+
+type ReindexService interface {
+	// StartReindex runs a reindex in the background and returns its initial
+	// status. Only one background reindex runs at a time.
+	StartReindex(request dtos.ReindexRequest) (*dtos.ReindexStatus, error)
+	// GetReindexStatus returns the status of the latest background reindex.
+	GetReindexStatus() (*dtos.ReindexStatus, error)
+	// Reindex publishes a reindex operation for every matching project and
+	// blocks until done, calling onProgress after every project.
+	Reindex(ctx context.Context, request dtos.ReindexRequest, onProgress func(status dtos.ReindexStatus)) (*dtos.ReindexStatus, error)
+}
+
+const (
+	ReindexStatusRunning   = "running"
+	ReindexStatusCompleted = "completed"
+	ReindexStatusFailed    = "failed"
+	ReindexStatusCancelled = "cancelled"
+
+	reindexBatchSize      = 500
+	reindexMaxFailedIDs   = 100
+	reindexPublishTimeout = 10 * time.Second
+	reindexPublisherWait  = 30 * time.Second
+	reindexPublisherPoll  = 500 * time.Millisecond
+	reindexOperation      = "reindex"
+)
+
+var (
+	ErrReindexRunning  = errors.New("a reindex is already running")
+	ErrReindexNotFound = errors.New("no reindex has been started")
+)
+
+type reindexServiceImpl struct {
+	publisher   rabbitMQ.Publisher
+	projectRepo repositories.ProjectRepository
+	defaultRate int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	status *dtos.ReindexStatus
+}
+
+// NewReindexService returns the reindex service. The cleanup function cancels
+// a background reindex that is still running and waits for it to stop.
+func NewReindexService(publisher rabbitMQ.Publisher, projectRepo repositories.ProjectRepository) (ReindexService, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	service := &reindexServiceImpl{
+		publisher:   publisher,
+		projectRepo: projectRepo,
+		defaultRate: configs.GetReindexRate(),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+
+	return service, func() {
+		cancel()
+		service.wg.Wait()
+	}
+}
+
+func (s *reindexServiceImpl) StartReindex(request dtos.ReindexRequest) (*dtos.ReindexStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.status != nil && s.status.Status == ReindexStatusRunning {
+		return nil, ErrReindexRunning
+	}
+
+	s.status = &dtos.ReindexStatus{
+		Status:    ReindexStatusRunning,
+		Request:   request,
+		StartedAt: time.Now(),
+	}
+	initial := copyReindexStatus(s.status)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		_, _ = s.Reindex(s.ctx, request, func(status dtos.ReindexStatus) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.status = &status
+		})
+	}()
+
+	return initial, nil
+}
+
+func (s *reindexServiceImpl) GetReindexStatus() (*dtos.ReindexStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.status == nil {
+		return nil, ErrReindexNotFound
+	}
+	return copyReindexStatus(s.status), nil
+}
+
+func (s *reindexServiceImpl) Reindex(ctx context.Context, request dtos.ReindexRequest, onProgress func(status dtos.ReindexStatus)) (*dtos.ReindexStatus, error) {
+	status := &dtos.ReindexStatus{
+		Status:    ReindexStatusRunning,
+		Request:   request,
+		StartedAt: time.Now(),
+		FailedIDs: []int{},
+	}
+	report := func() {
+		if onProgress != nil {
+			onProgress(*copyReindexStatus(status))
+		}
+	}
+	finish := func(state string, err error) (*dtos.ReindexStatus, error) {
+		now := time.Now()
+		status.Status = state
+		status.FinishedAt = &now
+		if err != nil {
+			message := err.Error()
+			status.Error = &message
+		}
+		report()
+		return status, err
+	}
+
+	ratePerSecond := request.RatePerSecond
+	if ratePerSecond <= 0 {
+		ratePerSecond = s.defaultRate
+	}
+	limiter := rate.NewLimiter(rate.Limit(ratePerSecond), 1)
+
+	filter := &dtos.ProjectFilter{
+		ProgramID:    request.ProgramID,
+		AcademicYear: request.AcademicYear,
+	}
+
+	total, err := s.projectRepo.CountProjects(ctx, filter)
+	if err != nil {
+		return finish(ReindexStatusFailed, err)
+	}
+	status.Total = total
+	report()
+
+	if err := s.waitForPublisher(ctx); err != nil {
+		return finish(ReindexStatusFailed, err)
+	}
+
+	afterID := 0
+	for {
+		ids, err := s.projectRepo.GetProjectIDs(ctx, filter, afterID, reindexBatchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return finish(ReindexStatusCancelled, ctx.Err())
+			}
+			return finish(ReindexStatusFailed, err)
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		for _, id := range ids {
+			if err := limiter.Wait(ctx); err != nil {
+				return finish(ReindexStatusCancelled, ctx.Err())
+			}
+
+			published, err := s.publishProject(ctx, id)
+			status.Processed++
+			switch {
+			case err != nil:
+				if ctx.Err() != nil {
+					return finish(ReindexStatusCancelled, ctx.Err())
+				}
+				status.Failed++
+				if len(status.FailedIDs) < reindexMaxFailedIDs {
+					status.FailedIDs = append(status.FailedIDs, id)
+				}
+			case published:
+				status.Published++
+			}
+			report()
+		}
+		afterID = ids[len(ids)-1]
+	}
+
+	return finish(ReindexStatusCompleted, nil)
+}
+
+// publishProject publishes the current state of a project. It reports false
+// when the project was deleted after the reindex started.
+func (s *reindexServiceImpl) publishProject(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, reindexPublishTimeout)
+	defer cancel()
+
+	projectMessage, err := s.projectRepo.GetProjectMessageByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := rabbitMQQueue.PublishMessageFromRabbitMQToElasticSearch(ctx, s.publisher, reindexOperation, projectMessage); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *reindexServiceImpl) waitForPublisher(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, reindexPublisherWait)
+	defer cancel()
+
+	ticker := time.NewTicker(reindexPublisherPoll)
+	defer ticker.Stop()
+	for {
+		err := s.publisher.Health()
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("rabbitmq is unavailable: %w", err)
+		case <-ticker.C:
+		}
+	}
+}
+
+func copyReindexStatus(status *dtos.ReindexStatus) *dtos.ReindexStatus {
+	copied := *status
+	copied.FailedIDs = append([]int{}, status.FailedIDs...)
+	return &copied
+}
@@ -2,22 +2,63 @@ package services
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"mime"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/project-box/configs"
+	storage "github.com/project-box/db/storage"
 	"github.com/project-box/models"
 	"github.com/project-box/repositories"
+	"github.com/project-box/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrResourceNotFound  = errors.New("resource not found")
+	ErrResourceForbidden = errors.New("you do not have access to this resource")
+	ErrResourceHasNoFile = errors.New("resource is a link and has no file to download")
 )
 
+// ResourceDownload locates the stored object behind a project resource.
+type ResourceDownload struct {
+	Bucket   string
+	Key      string
+	FileName string
+}
+
 type ResourceService interface {
 	GetDetailedResourceByID(ctx context.Context, id string) (*models.DetailedResource, error)
-	DeleteProjectResourceByID(ctx context.Context, id string, filePath *string) error
+	DeleteProjectResourceByID(ctx context.Context, id int, version int) error
+	GetResourceDownload(ctx context.Context, id int) (*ResourceDownload, error)
+	PresignResourceDownload(ctx context.Context, download *ResourceDownload, inline bool) (*url.URL, error)
+	OpenResourceDownload(ctx context.Context, download *ResourceDownload) (storage.ObjectReader, *storage.ObjectInfo, error)
+	GetResourceVersions(ctx context.Context, id int) ([]models.ProjectResource, error)
+	RestoreResourceVersion(ctx context.Context, id int, version int) (*models.ProjectResource, error)
 }
 
 type resourceService struct {
 	resourceRepository repositories.ResourceRepository
+	projectRepository  repositories.ProjectRepository
+	storage            storage.ObjectStorage
+	outboxRelay        OutboxRelayService
+	auditService       AuditService
+	logger             *slog.Logger
+	downloadURLTTL     time.Duration
 }
 
-func NewResourceService(resourceRepository repositories.ResourceRepository) ResourceService {
+func NewResourceService(resourceRepository repositories.ResourceRepository, projectRepository repositories.ProjectRepository, objectStorage storage.ObjectStorage, outboxRelay OutboxRelayService, auditService AuditService, logger *slog.Logger) ResourceService {
 	return &resourceService{
 		resourceRepository: resourceRepository,
+		projectRepository:  projectRepository,
+		storage:            objectStorage,
+		outboxRelay:        outboxRelay,
+		auditService:       auditService,
+		logger:             logger,
+		downloadURLTTL:     configs.GetStorageConfig().DownloadURLTTL,
 	}
 }
 
@@ -25,6 +66,180 @@ func (s *resourceService) GetDetailedResourceByID(ctx context.Context, id string
 	return s.resourceRepository.FindDetailedResourceByID(ctx, id)
 }
 
-func (s *resourceService) DeleteProjectResourceByID(ctx context.Context, id string, filePath *string) error {
-	return s.resourceRepository.DeleteProjectResourceByID(ctx, id, filePath)
+// DeleteProjectResourceByID deletes one version of a resource and then its
+// file, provided the project is still at version. Only program staff and
+// members of the project may delete.
+func (s *resourceService) DeleteProjectResourceByID(ctx context.Context, id int, version int) error {
+	resource, err := s.getResource(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkMemberAccess(ctx, &resource.Project); err != nil {
+		return err
+	}
+
+	if err := s.projectRepository.DeleteProjectResource(ctx, resource.ProjectID, version, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrResourceNotFound
+		}
+		return versionConflict(ctx, s.projectRepository, resource.ProjectID, err)
+	}
+	s.outboxRelay.Notify()
+	s.auditService.Record(ctx, resourceAuditChange(models.AuditActionDelete, resource, nil))
+
+	// The row is gone, so a file that cannot be removed is only logged; the
+	// deletion itself has succeeded.
+	if resource.Path != nil {
+		if bucket, key, ok := strings.Cut(*resource.Path, "/"); ok && key != "" {
+			if err := s.storage.RemoveObject(context.WithoutCancel(ctx), bucket, key); err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+				s.logger.ErrorContext(ctx, "Failed to remove file of deleted resource", "resource_id", id, "path", *resource.Path, "error", err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetResourceDownload resolves the file behind a resource after checking that
+// the caller may read it. Files of public projects are readable by any
+// signed-in user; otherwise the caller must be program staff or a member of
+// the project.
+func (s *resourceService) GetResourceDownload(ctx context.Context, id int) (*ResourceDownload, error) {
+	resource, err := s.getResource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkReadAccess(ctx, &resource.Project); err != nil {
+		return nil, err
+	}
+
+	if resource.Path == nil || *resource.Path == "" {
+		return nil, ErrResourceHasNoFile
+	}
+
+	// Path is stored as "<bucket>/<object key>".
+	bucket, key, ok := strings.Cut(*resource.Path, "/")
+	if !ok || key == "" {
+		return nil, ErrResourceHasNoFile
+	}
+
+	download := &ResourceDownload{Bucket: bucket, Key: key}
+	if resource.ResourceName != nil {
+		download.FileName = *resource.ResourceName
+	}
+	if download.FileName == "" {
+		download.FileName = key[strings.LastIndex(key, "/")+1:]
+	}
+	return download, nil
+}
+
+func (s *resourceService) checkReadAccess(ctx context.Context, project *models.Project) error {
+	if project.IsPublic {
+		return nil
+	}
+	return s.checkMemberAccess(ctx, project)
+}
+
+// checkMemberAccess allows program staff of the project's program and the
+// project's own staff and students.
+func (s *resourceService) checkMemberAccess(ctx context.Context, project *models.Project) error {
+	canEdit, err := canEditProject(ctx, s.projectRepository, project)
+	if err != nil {
+		return err
+	}
+	if !canEdit {
+		return ErrResourceForbidden
+	}
+	return nil
+}
+
+func (s *resourceService) getResource(ctx context.Context, id int) (*models.ProjectResource, error) {
+	resource, err := s.resourceRepository.GetProjectResourceByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrResourceNotFound
+	}
+	return resource, err
+}
+
+// GetResourceVersions lists every version of the resource's title, newest
+// first. Readers of the project may see the whole history.
+func (s *resourceService) GetResourceVersions(ctx context.Context, id int) ([]models.ProjectResource, error) {
+	resource, err := s.getResource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkReadAccess(ctx, &resource.Project); err != nil {
+		return nil, err
+	}
+	return s.resourceRepository.GetProjectResourceVersions(ctx, id)
+}
+
+// RestoreResourceVersion makes an earlier version current again, provided the
+// project is still at version. Only program staff and members of the project
+// may restore.
+func (s *resourceService) RestoreResourceVersion(ctx context.Context, id int, version int) (*models.ProjectResource, error) {
+	resource, err := s.getResource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkMemberAccess(ctx, &resource.Project); err != nil {
+		return nil, err
+	}
+
+	if err := s.projectRepository.RestoreProjectResourceVersion(ctx, resource.ProjectID, version, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrResourceNotFound
+		}
+		return nil, versionConflict(ctx, s.projectRepository, resource.ProjectID, err)
+	}
+	s.outboxRelay.Notify()
+
+	restored, err := s.getResource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.auditService.Record(ctx, resourceAuditChange(models.AuditActionRestore, resource, restored))
+	return restored, nil
+}
+
+// resourceAuditChange describes a change to one version of a resource. Either
+// snapshot may be nil, but not both.
+func resourceAuditChange(action string, before, after *models.ProjectResource) AuditChange {
+	change := AuditChange{Action: action, EntityType: models.AuditEntityProjectResource}
+	if before != nil {
+		change.EntityID, change.ProgramID, change.Before = before.ID, before.Project.ProgramID, utils.SanitizeProjectResource(*before)
+	}
+	if after != nil {
+		change.EntityID, change.ProgramID, change.After = after.ID, after.Project.ProgramID, utils.SanitizeProjectResource(*after)
+	}
+	return change
+}
+
+// PresignResourceDownload returns a short-lived link to the file that names it
+// after the original upload.
+func (s *resourceService) PresignResourceDownload(ctx context.Context, download *ResourceDownload, inline bool) (*url.URL, error) {
+	params := url.Values{}
+	params.Set("response-content-disposition", ContentDisposition(inline, download.FileName))
+	return s.storage.PresignGetObject(ctx, download.Bucket, download.Key, s.downloadURLTTL, params)
+}
+
+func (s *resourceService) OpenResourceDownload(ctx context.Context, download *ResourceDownload) (storage.ObjectReader, *storage.ObjectInfo, error) {
+	object, info, err := s.storage.GetObject(ctx, download.Bucket, download.Key)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, nil, ErrResourceNotFound
+	}
+	return object, info, err
+}
+
+// ContentDisposition builds a Content-Disposition header value for fileName,
+// encoding non-ASCII names (e.g. Thai titles) as RFC 2231 parameters.
+func ContentDisposition(inline bool, fileName string) string {
+	dispositionType := "attachment"
+	if inline {
+		dispositionType = "inline"
+	}
+	if value := mime.FormatMediaType(dispositionType, map[string]string{"filename": fileName}); value != "" {
+		return value
+	}
+	return dispositionType
 }
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/project-box/dtos"
+	"github.com/project-box/models"
+	"github.com/project-box/repositories"
+)
+
+type RubricService interface {
+	GetRubricsByProgramId(ctx context.Context, programId int) ([]models.Rubric, error)
+	GetRubricByID(ctx context.Context, id int) (*models.Rubric, error)
+	CreateRubric(ctx context.Context, req *dtos.RubricRequest) (*models.Rubric, error)
+	UpdateRubric(ctx context.Context, id int, req *dtos.RubricRequest) (*models.Rubric, error)
+	DeleteRubric(ctx context.Context, id int) error
+}
+
+var (
+	ErrInvalidRubric = errors.New("invalid rubric")
+	ErrRubricInUse   = errors.New("rubric already has evaluations")
+)
+
+type rubricServiceImpl struct {
+	rubricRepo      repositories.RubricRepository
+	projectRoleRepo repositories.ProjectRoleRepository
+}
+
+func NewRubricService(rubricRepo repositories.RubricRepository, projectRoleRepo repositories.ProjectRoleRepository) RubricService {
+	return &rubricServiceImpl{
+		rubricRepo:      rubricRepo,
+		projectRoleRepo: projectRoleRepo,
+	}
+}
+
+func (s *rubricServiceImpl) GetRubricsByProgramId(ctx context.Context, programId int) ([]models.Rubric, error) {
+	return s.rubricRepo.GetRubricsByProgramId(ctx, programId)
+}
+
+func (s *rubricServiceImpl) GetRubricByID(ctx context.Context, id int) (*models.Rubric, error) {
+	return s.rubricRepo.GetRubricByID(ctx, id)
+}
+
+func (s *rubricServiceImpl) CreateRubric(ctx context.Context, req *dtos.RubricRequest) (*models.Rubric, error) {
+	for _, criterion := range req.Criteria {
+		if criterion.ID != 0 {
+			return nil, fmt.Errorf("%w: new criteria must not have an id", ErrInvalidRubric)
+		}
+	}
+
+	rubric, err := s.buildRubric(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rubricRepo.CreateRubric(ctx, rubric); err != nil {
+		return nil, err
+	}
+
+	return s.rubricRepo.GetRubricByID(ctx, rubric.ID)
+}
+
+// UpdateRubric replaces the rubric's definition. Once evaluations have been
+// submitted against it, criteria can still be renamed and reweighted, but not
+// added, removed or given a different score range, since that would
+// invalidate the scores already recorded.
+func (s *rubricServiceImpl) UpdateRubric(ctx context.Context, id int, req *dtos.RubricRequest) (*models.Rubric, error) {
+	existing, err := s.rubricRepo.GetRubricByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.ProgramID != existing.ProgramID {
+		return nil, fmt.Errorf("%w: program_id cannot be changed", ErrInvalidRubric)
+	}
+
+	existingCriteria := map[int]models.RubricCriterion{}
+	for _, criterion := range existing.Criteria {
+		existingCriteria[criterion.ID] = criterion
+	}
+	for _, criterion := range req.Criteria {
+		if _, ok := existingCriteria[criterion.ID]; criterion.ID != 0 && !ok {
+			return nil, fmt.Errorf("%w: criterion %d does not belong to this rubric", ErrInvalidRubric, criterion.ID)
+		}
+	}
+
+	rubric, err := s.buildRubric(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	rubric.ID = id
+
+	hasEvaluations, err := s.rubricRepo.HasEvaluations(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if hasEvaluations && !sameScoreRanges(existing.Criteria, rubric.Criteria) {
+		return nil, fmt.Errorf("%w: criteria cannot be added, removed or rescaled", ErrRubricInUse)
+	}
+
+	if err := s.rubricRepo.UpdateRubric(ctx, rubric); err != nil {
+		return nil, err
+	}
+
+	return s.rubricRepo.GetRubricByID(ctx, id)
+}
+
+func (s *rubricServiceImpl) DeleteRubric(ctx context.Context, id int) error {
+	hasEvaluations, err := s.rubricRepo.HasEvaluations(ctx, id)
+	if err != nil {
+		return err
+	}
+	if hasEvaluations {
+		return ErrRubricInUse
+	}
+	return s.rubricRepo.DeleteRubric(ctx, id)
+}
+
+func (s *rubricServiceImpl) buildRubric(ctx context.Context, req *dtos.RubricRequest) (*models.Rubric, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidRubric)
+	}
+	if len(req.Criteria) == 0 {
+		return nil, fmt.Errorf("%w: at least one criterion is required", ErrInvalidRubric)
+	}
+
+	rubric := &models.Rubric{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		ProgramID:   req.ProgramID,
+	}
+
+	for i, criterion := range req.Criteria {
+		if strings.TrimSpace(criterion.Name) == "" {
+			return nil, fmt.Errorf("%w: criterion %d has no name", ErrInvalidRubric, i+1)
+		}
+		if criterion.Weight <= 0 {
+			return nil, fmt.Errorf("%w: criterion %q must have a positive weight", ErrInvalidRubric, criterion.Name)
+		}
+		if criterion.MaxScore <= criterion.MinScore {
+			return nil, fmt.Errorf("%w: criterion %q max_score must be greater than min_score", ErrInvalidRubric, criterion.Name)
+		}
+		rubric.Criteria = append(rubric.Criteria, models.RubricCriterion{
+			ID:          criterion.ID,
+			Name:        strings.TrimSpace(criterion.Name),
+			Description: criterion.Description,
+			Weight:      criterion.Weight,
+			MinScore:    criterion.MinScore,
+			MaxScore:    criterion.MaxScore,
+			SortOrder:   i,
+		})
+	}
+
+	roles, err := s.projectRoleRepo.GetAllByProgramId(ctx, req.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+	programRoles := map[int]bool{}
+	for _, role := range roles {
+		programRoles[role.ID] = true
+	}
+
+	seenRoles := map[int]bool{}
+	for _, roleWeight := range req.RoleWeights {
+		if !programRoles[roleWeight.ProjectRoleID] {
+			return nil, fmt.Errorf("%w: project role %d does not belong to program %d", ErrInvalidRubric, roleWeight.ProjectRoleID, req.ProgramID)
+		}
+		if seenRoles[roleWeight.ProjectRoleID] {
+			return nil, fmt.Errorf("%w: project role %d is listed more than once", ErrInvalidRubric, roleWeight.ProjectRoleID)
+		}
+		if roleWeight.Weight < 0 {
+			return nil, fmt.Errorf("%w: role weights must not be negative", ErrInvalidRubric)
+		}
+		seenRoles[roleWeight.ProjectRoleID] = true
+		rubric.RoleWeights = append(rubric.RoleWeights, models.RubricRoleWeight{
+			ProjectRoleID: roleWeight.ProjectRoleID,
+			Weight:        roleWeight.Weight,
+		})
+	}
+
+	return rubric, nil
+}
+
+func sameScoreRanges(existing, updated []models.RubricCriterion) bool {
+	if len(existing) != len(updated) {
+		return false
+	}
+
+	ranges := map[int]models.RubricCriterion{}
+	for _, criterion := range existing {
+		ranges[criterion.ID] = criterion
+	}
+	for _, criterion := range updated {
+		previous, ok := ranges[criterion.ID]
+		if !ok || previous.MinScore != criterion.MinScore || previous.MaxScore != criterion.MaxScore {
+			return false
+		}
+	}
+	return true
+}
@@ -17,20 +17,24 @@ type StaffService interface {
 	UpdateStaff(ctx context.Context, staff *dtos.UpdateStaffRequest) (*dtos.StaffResponse, error)
 	DeleteStaff(ctx context.Context, id int) error
 	CreateStaffs(ctx context.Context, staffs []models.Staff) error
+	UpsertStaffs(ctx context.Context, staffs []models.Staff) ([]models.Staff, error)
 	GetStaffByProgramId(ctx context.Context, programId int) ([]dtos.StaffResponse, error)
 	GetAllStaff(ctx context.Context) ([]dtos.StaffResponse, error)
 	GetAllStaffByProgramId(ctx context.Context, programId int) ([]models.Staff, error)
 	GetStaffByEmail(ctx context.Context, email string) (*models.Staff, error)
+	GetStaffByEmailAndProgramId(ctx context.Context, email string, programId int) (*models.Staff, error)
 	GetStaffByName(ctx context.Context, name string) (*models.Staff, error)
 }
 
 type staffServiceImpl struct {
-	staffRepo repositories.StaffRepository
+	staffRepo    repositories.StaffRepository
+	auditService AuditService
 }
 
-func NewStaffService(staffRepo repositories.StaffRepository) StaffService {
+func NewStaffService(staffRepo repositories.StaffRepository, auditService AuditService) StaffService {
 	return &staffServiceImpl{
-		staffRepo: staffRepo,
+		staffRepo:    staffRepo,
+		auditService: auditService,
 	}
 }
 
@@ -107,6 +111,7 @@ func (s *staffServiceImpl) CreateStaff(ctx context.Context, staffBody *dtos.Crea
 	if err != nil {
 		return nil, err
 	}
+	s.auditService.Record(ctx, staffAuditChange(models.AuditActionCreate, nil, staff))
 
 	return &dtos.StaffResponse{
 		ID:          staff.ID,
@@ -144,6 +149,11 @@ func (s *staffServiceImpl) GetStaffById(ctx context.Context, id int) (*dtos.Staf
 }
 
 func (s *staffServiceImpl) UpdateStaff(ctx context.Context, staff *dtos.UpdateStaffRequest) (*dtos.StaffResponse, error) {
+	before, err := s.staffRepo.Get(ctx, staff.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	// convert from dto to model
 	updatedStaff := &models.Staff{
 		ID:          staff.ID,
@@ -157,11 +167,18 @@ func (s *staffServiceImpl) UpdateStaff(ctx context.Context, staff *dtos.UpdateSt
 		Email:       staff.Email,
 		ProgramID:   staff.ProgramID,
 	}
-	updatedStaff, err := s.staffRepo.Update(ctx, staff.ID, updatedStaff)
+	updatedStaff, err = s.staffRepo.Update(ctx, staff.ID, updatedStaff)
 	if err != nil {
 		return nil, err
 	}
 
+	// Update skips zero values, so read back what was actually stored.
+	after, err := s.staffRepo.Get(ctx, staff.ID)
+	if err != nil {
+		after = updatedStaff
+	}
+	s.auditService.Record(ctx, staffAuditChange(models.AuditActionUpdate, before, after))
+
 	// convert to response
 	return &dtos.StaffResponse{
 		ID:          updatedStaff.ID,
@@ -178,7 +195,19 @@ func (s *staffServiceImpl) UpdateStaff(ctx context.Context, staff *dtos.UpdateSt
 }
 
 func (s *staffServiceImpl) DeleteStaff(ctx context.Context, id int) error {
-	return s.staffRepo.Delete(ctx, id)
+	before, err := s.staffRepo.Get(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.staffRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.auditService.Record(ctx, staffAuditChange(models.AuditActionDelete, before, nil))
+	return nil
 }
 
 func (s *staffServiceImpl) GetStaffByProgramId(ctx context.Context, programId int) ([]dtos.StaffResponse, error) {
@@ -219,5 +248,75 @@ func (s *staffServiceImpl) GetStaffByEmail(ctx context.Context, email string) (*
 }
 
 func (s *staffServiceImpl) CreateStaffs(ctx context.Context, staffs []models.Staff) error {
-	return s.staffRepo.CreateStaffs(ctx, staffs)
+	if err := s.staffRepo.CreateStaffs(ctx, staffs); err != nil {
+		return err
+	}
+
+	// CreateStaffs does not report the new IDs, so the entries are keyed by
+	// email instead.
+	changes := make([]AuditChange, 0, len(staffs))
+	for i := range staffs {
+		change := staffAuditChange(models.AuditActionCreate, nil, &staffs[i])
+		change.EntityID = staffs[i].Email
+		changes = append(changes, change)
+	}
+	s.auditService.Record(ctx, changes...)
+	return nil
+}
+
+// UpsertStaffs creates or updates staff matched by email and program.
+func (s *staffServiceImpl) UpsertStaffs(ctx context.Context, staffs []models.Staff) ([]models.Staff, error) {
+	existing := map[int]map[string]models.Staff{}
+	for _, staff := range staffs {
+		if _, ok := existing[staff.ProgramID]; ok {
+			continue
+		}
+		programStaffs, err := s.staffRepo.GetStaffByProgramId(staff.ProgramID)
+		if err != nil {
+			return nil, err
+		}
+		existing[staff.ProgramID] = make(map[string]models.Staff, len(programStaffs))
+		for _, programStaff := range programStaffs {
+			existing[staff.ProgramID][programStaff.Email] = programStaff
+		}
+	}
+
+	upsertedStaffs, err := s.staffRepo.UpsertStaffs(ctx, staffs)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]AuditChange, 0, len(upsertedStaffs))
+	for i := range upsertedStaffs {
+		after := &upsertedStaffs[i]
+		if before, ok := existing[after.ProgramID][after.Email]; ok {
+			changes = append(changes, staffAuditChange(models.AuditActionUpdate, &before, after))
+		} else {
+			changes = append(changes, staffAuditChange(models.AuditActionCreate, nil, after))
+		}
+	}
+	s.auditService.Record(ctx, changes...)
+	return upsertedStaffs, nil
+}
+
+func staffAuditChange(action string, before, after *models.Staff) AuditChange {
+	change := AuditChange{Action: action, EntityType: models.AuditEntityStaff}
+	if before != nil {
+		change.EntityID, change.ProgramID, change.Before = before.ID, before.ProgramID, before
+	}
+	if after != nil {
+		change.EntityID, change.ProgramID, change.After = after.ID, after.ProgramID, after
+	}
+	return change
+}
+
+func (s *staffServiceImpl) GetStaffByEmailAndProgramId(ctx context.Context, email string, programId int) (*models.Staff, error) {
+	staff, err := s.staffRepo.GetByEmailAndProgramId(ctx, email, programId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return staff, nil
 }
@@ -2,7 +2,7 @@ package services
 
 import (
 	"context"
-	"fmt"
+	"log/slog"
 
 	"github.com/project-box/models"
 	"github.com/project-box/repositories"
@@ -12,6 +12,9 @@ import (
 type StudentService interface {
 	CreateStudents(ctx context.Context, students []models.Student) error
 	UpsertStudents(ctx context.Context, students []models.Student, programId int) ([]models.Student, error)
+	// UpsertStudentsInTransaction saves students within tx and returns the
+	// audit changes, which the caller records once tx has committed.
+	UpsertStudentsInTransaction(ctx context.Context, tx *gorm.DB, students []models.Student, programId int) ([]models.Student, []AuditChange, error)
 	GetStudentByStudentId(ctx context.Context, studentId string) (*models.Student, error)
 	GetStudentByStudentIdOnCurrentYearAndSemester(ctx context.Context, studentId string) (*models.Student, error)
 	GetStudentByProgramIdOnCurrentYearAndSemester(ctx context.Context, programId int) ([]models.Student, error)
@@ -23,13 +26,17 @@ type StudentService interface {
 type studentServiceImpl struct {
 	studentRepo   repositories.StudentRepository
 	configService ConfigService
+	auditService  AuditService
+	logger        *slog.Logger
 	db            *gorm.DB
 }
 
-func NewStudentService(configService ConfigService, studentRepo repositories.StudentRepository, db *gorm.DB) StudentService {
+func NewStudentService(configService ConfigService, studentRepo repositories.StudentRepository, auditService AuditService, db *gorm.DB, logger *slog.Logger) StudentService {
 	return &studentServiceImpl{
 		studentRepo:   studentRepo,
 		configService: configService,
+		auditService:  auditService,
+		logger:        logger,
 		db:            db,
 	}
 }
@@ -38,7 +45,16 @@ func (s *studentServiceImpl) CreateStudents(ctx context.Context, students []mode
 	if len(students) == 0 {
 		return nil
 	}
-	return s.studentRepo.CreateMany(ctx, students)
+	if err := s.studentRepo.CreateMany(ctx, students); err != nil {
+		return err
+	}
+
+	changes := make([]AuditChange, 0, len(students))
+	for i := range students {
+		changes = append(changes, studentAuditChange(nil, &students[i]))
+	}
+	s.auditService.Record(ctx, changes...)
+	return nil
 }
 
 func (s *studentServiceImpl) GetStudentByProgramIdOnCurrentYearAndSemester(ctx context.Context, programId int) ([]models.Student, error) {
@@ -84,54 +100,84 @@ func (s *studentServiceImpl) UpsertStudents(ctx context.Context, students []mode
 		return nil, nil
 	}
 
-	academicYear, semester, err := s.configService.GetCurrentAcademicYearAndSemester(ctx, programId)
+	var upsertedStudents []models.Student
+	var changes []AuditChange
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		upsertedStudents, changes, err = s.UpsertStudentsInTransaction(ctx, tx, students, programId)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	s.auditService.Record(ctx, changes...)
+
+	return upsertedStudents, nil
+}
 
-	tx := s.db.Begin()
-	if tx.Error != nil {
-		return nil, tx.Error
+func (s *studentServiceImpl) UpsertStudentsInTransaction(ctx context.Context, tx *gorm.DB, students []models.Student, programId int) ([]models.Student, []AuditChange, error) {
+	if len(students) == 0 {
+		return nil, nil, nil
+	}
+
+	academicYear, semester, err := s.configService.GetCurrentAcademicYearAndSemester(ctx, programId)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	var upsertedStudents []models.Student
+	var changes []AuditChange
 	for _, student := range students {
-		upsertedStudent, err := s.upsertStudent(ctx, tx, &student, semester, academicYear)
+		upsertedStudent, before, err := s.upsertStudent(ctx, tx, &student, semester, academicYear)
 		if err != nil {
-			tx.Rollback()
-			return nil, err
+			return nil, nil, err
 		}
 		upsertedStudents = append(upsertedStudents, *upsertedStudent)
+		changes = append(changes, studentAuditChange(before, upsertedStudent))
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		return nil, err
-	}
-
-	return upsertedStudents, nil
+	return upsertedStudents, changes, nil
 }
 
-func (s *studentServiceImpl) upsertStudent(ctx context.Context, tx *gorm.DB, student *models.Student, semester, academicYear int) (*models.Student, error) {
-	existingStudent, err := s.GetStudentByStudentIdAndProgramIdOnCurrentYearAndSemester(ctx, student.StudentID, student.ProgramID)
+// upsertStudent saves student and returns it along with the record as it was
+// before, which is nil when the student was created. The existing record is
+// looked up within tx so students written earlier in it are found.
+func (s *studentServiceImpl) upsertStudent(ctx context.Context, tx *gorm.DB, student *models.Student, semester, academicYear int) (*models.Student, *models.Student, error) {
+	existingStudent := &models.Student{}
+	err := tx.WithContext(ctx).
+		Where("student_id = ? AND program_id = ? AND academic_year = ? AND semester = ?", student.StudentID, student.ProgramID, academicYear, semester).
+		First(existingStudent).Error
 	if err != nil && err != gorm.ErrRecordNotFound {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err == gorm.ErrRecordNotFound {
-		fmt.Printf("Student %s not found in program %d for year %d semester %d, creating new record...\n",
-			student.StudentID, student.ProgramID, semester, academicYear)
+		s.logger.DebugContext(ctx, "Student not found, creating new record",
+			"student_id", student.StudentID, "program_id", student.ProgramID, "academic_year", academicYear, "semester", semester)
 		if err := tx.Create(&student).Error; err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		return student, nil
+		return student, nil, nil
 	}
 
+	before := *existingStudent
 	existingStudent = updateStudentFields(existingStudent, student)
 	if err := tx.Save(&existingStudent).Error; err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return existingStudent, nil
+	return existingStudent, &before, nil
+}
+
+func studentAuditChange(before, after *models.Student) AuditChange {
+	change := AuditChange{Action: models.AuditActionCreate, EntityType: models.AuditEntityStudent}
+	if before != nil {
+		change.Action, change.Before = models.AuditActionUpdate, before
+	}
+	if after != nil {
+		change.EntityID, change.ProgramID, change.After = after.ID, after.ProgramID, after
+	}
+	return change
 }
 
 func updateStudentFields(existing, new *models.Student) *models.Student {
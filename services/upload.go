@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/url"
 	"path/filepath"
 	"strconv"
@@ -14,7 +13,8 @@ import (
 
 	"gorm.io/gorm"
 
-	"github.com/minio/minio-go/v7"
+	storage "github.com/project-box/db/storage"
+	"github.com/project-box/dtos"
 	"github.com/project-box/models"
 	"github.com/project-box/repositories"
 	"github.com/xuri/excelize/v2"
@@ -23,14 +23,14 @@ import (
 type UploadService interface {
 	UploadObject(ctx context.Context, bucketName string, objectName string, file io.Reader, fileSize int64, contentType string) (string, error)
 	GetObjectURL(ctx context.Context, bucketName string, objectName string) (*url.URL, error)
-	RemoveObject(ctx context.Context, bucketName string, objectName string, opt minio.RemoveObjectOptions) error
-	ProcessStudentEnrollmentFile(ctx context.Context, programId int, file *multipart.FileHeader) error
-	ProcessCreateProjectFile(ctx context.Context, programId int, file *multipart.FileHeader) error
-	ProcessCreateStaffFile(ctx context.Context, programId int, file *multipart.FileHeader) error
+	RemoveObject(ctx context.Context, bucketName string, objectName string) error
+	ProcessStudentEnrollmentFile(ctx context.Context, programId int, file io.Reader, opts ImportOptions) (*dtos.ImportReport, error)
+	ProcessCreateProjectFile(ctx context.Context, programId int, file io.Reader, opts ImportOptions) (*dtos.ImportReport, error)
+	ProcessCreateStaffFile(ctx context.Context, programId int, file io.Reader, opts ImportOptions) (*dtos.ImportReport, error)
 }
 
 type uploadServiceImpl struct {
-	client             *minio.Client
+	storage            storage.ObjectStorage
 	studentService     StudentService
 	staffService       StaffService
 	projectRoleService ProjectRoleService
@@ -39,11 +39,12 @@ type uploadServiceImpl struct {
 	projectRepo        repositories.ProjectRepository
 	programRepo        repositories.ProgramRepository
 	keywordRepo        repositories.KeywordRepository
+	auditService       AuditService
 }
 
-func NewUploadService(client *minio.Client, keywordRepo repositories.KeywordRepository, programRepo repositories.ProgramRepository, projectRepo repositories.ProjectRepository, staffService StaffService, projectRoleService ProjectRoleService, projectService ProjectService, configService ConfigService, studentService StudentService) UploadService {
+func NewUploadService(objectStorage storage.ObjectStorage, keywordRepo repositories.KeywordRepository, programRepo repositories.ProgramRepository, projectRepo repositories.ProjectRepository, staffService StaffService, projectRoleService ProjectRoleService, projectService ProjectService, configService ConfigService, studentService StudentService, auditService AuditService) UploadService {
 	return &uploadServiceImpl{
-		client:             client,
+		storage:            objectStorage,
 		programRepo:        programRepo,
 		projectRepo:        projectRepo,
 		staffService:       staffService,
@@ -52,6 +53,7 @@ func NewUploadService(client *minio.Client, keywordRepo repositories.KeywordRepo
 		configService:      configService,
 		studentService:     studentService,
 		keywordRepo:        keywordRepo,
+		auditService:       auditService,
 	}
 }
 
@@ -59,27 +61,29 @@ func matchColumn(col, target string) bool {
 	return strings.EqualFold(col, target)
 }
 
-func (s *uploadServiceImpl) validateFileType(file *multipart.FileHeader) error {
-	ext := strings.ToLower(filepath.Ext(file.Filename))
+// ImportOptions controls how an uploaded sheet is processed. OnProgress, when
+// set, is called as rows are checked and saved.
+type ImportOptions struct {
+	DryRun     bool
+	OnProgress func(processed, total int)
+}
+
+func (o ImportOptions) progress(processed, total int) {
+	if o.OnProgress != nil {
+		o.OnProgress(processed, total)
+	}
+}
+
+func validateExcelFileName(fileName string) error {
+	ext := strings.ToLower(filepath.Ext(fileName))
 	if ext != ".xlsx" && ext != ".xls" {
 		return errors.New("invalid file type: only Excel files are allowed")
 	}
 	return nil
 }
 
-func (s *uploadServiceImpl) readExcelFile(file *multipart.FileHeader) ([][]string, error) {
-	src, err := file.Open()
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
-	}
-	defer func(src multipart.File) {
-		err := src.Close()
-		if err != nil {
-
-		}
-	}(src)
-
-	excelFile, err := excelize.OpenReader(src)
+func (s *uploadServiceImpl) readExcelFile(file io.Reader) ([][]string, error) {
+	excelFile, err := excelize.OpenReader(file)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read Excel file: %w", err)
 	}
@@ -97,95 +101,164 @@ func (s *uploadServiceImpl) readExcelFile(file *multipart.FileHeader) ([][]strin
 	return rows, nil
 }
 
-func (s *uploadServiceImpl) ProcessStudentEnrollmentFile(ctx context.Context, programId int, file *multipart.FileHeader) error {
-	if err := s.validateFileType(file); err != nil {
-		return err
-	}
+// ErrImportValidation is returned together with a report whenever an uploaded
+// sheet contains rows that cannot be imported.
+var ErrImportValidation = errors.New("import file contains invalid rows")
+
+func (s *uploadServiceImpl) ProcessStudentEnrollmentFile(ctx context.Context, programId int, file io.Reader, opts ImportOptions) (*dtos.ImportReport, error) {
+	report := &dtos.ImportReport{DryRun: opts.DryRun}
 
 	rows, err := s.readExcelFile(file)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(rows) < 4 {
-		return errors.New("excel file is empty or does not have enough rows")
+		return nil, errors.New("excel file is empty or does not have enough rows")
 	}
 
 	studentInfoColumns, err := s.getStudentInfoColumns(rows[3])
 	if err != nil {
-		return err
+		report.AddError(4, "", err.Error())
+		return report, ErrImportValidation
+	}
+
+	students, err := s.parseStudents(ctx, rows[4:], 5, studentInfoColumns, programId, report)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.progress(0, report.TotalRows)
+	for i, student := range students {
+		existingStudent, err := s.studentService.GetStudentByStudentIdAndProgramIdOnCurrentYearAndSemester(ctx, student.StudentID, programId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if existingStudent != nil && err == nil {
+			report.WouldUpdate++
+		} else {
+			report.WouldCreate++
+		}
+		opts.progress(i+1, len(students))
 	}
 
-	students, err := s.parseStudents(ctx, rows[4:], studentInfoColumns, programId)
+	if report.HasErrors() {
+		return report, ErrImportValidation
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	upsertedStudents, err := s.studentService.UpsertStudents(ctx, students, programId)
 	if err != nil {
-		return err
+		report.AddError(0, "", fmt.Sprintf("failed to save student data: %v", err))
+		return report, fmt.Errorf("failed to save student data: %w", err)
 	}
-	if _, err := s.studentService.UpsertStudents(ctx, students, programId); err != nil {
-		return fmt.Errorf("failed to save student data: %w", err)
+	for _, student := range upsertedStudents {
+		report.EntityIDs = append(report.EntityIDs, student.ID)
 	}
+	report.Applied = true
 
-	return nil
+	return report, nil
 }
 
-func (s *uploadServiceImpl) ProcessCreateProjectFile(ctx context.Context, programId int, file *multipart.FileHeader) error {
-	if err := s.validateFileType(file); err != nil {
-		return err
-	}
+func (s *uploadServiceImpl) ProcessCreateProjectFile(ctx context.Context, programId int, file io.Reader, opts ImportOptions) (*dtos.ImportReport, error) {
+	report := &dtos.ImportReport{DryRun: opts.DryRun}
 
 	rows, err := s.readExcelFile(file)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(rows) < 3 {
-		return errors.New("excel file is empty or does not have enough rows")
+		return nil, errors.New("excel file is empty or does not have enough rows")
 	}
 
 	createProjectInfoColumns, err := s.getCreateProjectInfoColumns(rows[2])
 	if err != nil {
-		return err
+		report.AddError(3, "", err.Error())
+		return report, ErrImportValidation
 	}
-	projectRequests, err := s.parseProjects(ctx, rows[3:], createProjectInfoColumns, programId)
+
+	projectRequests, err := s.parseProjects(ctx, rows[3:], 4, createProjectInfoColumns, programId, report)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	err = s.projectService.CreateProjects(ctx, projectRequests)
+	report.WouldCreate = len(projectRequests)
+
+	if report.HasErrors() {
+		return report, ErrImportValidation
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	projects, err := s.applyProjects(ctx, projectRequests, programId, opts)
 	if err != nil {
-		return err
+		report.Applied = errors.Is(err, repositories.ErrProjectsNotLoaded)
+		report.AddError(0, "", fmt.Sprintf("failed to save project data: %v", err))
+		return report, err
 	}
+	for _, project := range projects {
+		report.EntityIDs = append(report.EntityIDs, project.ID)
+	}
+	report.Applied = true
 
-	return nil
+	return report, nil
 }
 
-func (s *uploadServiceImpl) ProcessCreateStaffFile(ctx context.Context, programId int, file *multipart.FileHeader) error {
-	if err := s.validateFileType(file); err != nil {
-		return err
-	}
+func (s *uploadServiceImpl) ProcessCreateStaffFile(ctx context.Context, programId int, file io.Reader, opts ImportOptions) (*dtos.ImportReport, error) {
+	report := &dtos.ImportReport{DryRun: opts.DryRun}
 
 	rows, err := s.readExcelFile(file)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(rows) < 3 {
-		return errors.New("excel file is empty or does not have enough rows")
+		return nil, errors.New("excel file is empty or does not have enough rows")
 	}
 
 	staffInfoColumns, err := s.getStaffInfoColumns(rows[1])
 	if err != nil {
-		return err
+		report.AddError(2, "", err.Error())
+		return report, ErrImportValidation
 	}
 
-	staffs, err := s.parseStaffs(rows[2:], staffInfoColumns, programId)
-	if err != nil {
-		return err
+	staffs := s.parseStaffs(rows[2:], 3, staffInfoColumns, programId, report)
+
+	opts.progress(0, report.TotalRows)
+	for i, staff := range staffs {
+		existingStaff, err := s.staffService.GetStaffByEmailAndProgramId(ctx, staff.Email, programId)
+		if err != nil {
+			return nil, err
+		}
+		if existingStaff != nil {
+			report.WouldUpdate++
+		} else {
+			report.WouldCreate++
+		}
+		opts.progress(i+1, len(staffs))
 	}
 
-	if err := s.staffService.CreateStaffs(ctx, staffs); err != nil {
-		return fmt.Errorf("failed to save staff data: %w", err)
+	if report.HasErrors() {
+		return report, ErrImportValidation
+	}
+	if opts.DryRun {
+		return report, nil
 	}
 
-	return nil
+	upsertedStaffs, err := s.staffService.UpsertStaffs(ctx, staffs)
+	if err != nil {
+		report.AddError(0, "", fmt.Sprintf("failed to save staff data: %v", err))
+		return report, fmt.Errorf("failed to save staff data: %w", err)
+	}
+	for _, staff := range upsertedStaffs {
+		report.EntityIDs = append(report.EntityIDs, staff.ID)
+	}
+	report.Applied = true
+
+	return report, nil
 }
 
 func (s *uploadServiceImpl) getStaffInfoColumns(headerRow []string) (map[string]int, error) {
@@ -296,27 +369,68 @@ func (s *uploadServiceImpl) getCreateProjectInfoColumns(headerRow []string) (map
 	return columns, nil
 }
 
-func (s *uploadServiceImpl) parseStudents(ctx context.Context, rows [][]string, columns map[string]int, programId int) ([]models.Student, error) {
+func cellValue(row []string, column int) string {
+	if column < 0 || column >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[column])
+}
+
+func isEmptyRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *uploadServiceImpl) parseStudents(ctx context.Context, rows [][]string, firstRow int, columns map[string]int, programId int, report *dtos.ImportReport) ([]models.Student, error) {
 	academicYear, semester, err := s.configService.GetCurrentAcademicYearAndSemester(ctx, programId)
 	if err != nil {
 		return nil, err
 	}
 
 	var students []models.Student
-	for _, row := range rows {
-		if len(row) <= columns["studentIdColumn"] || len(row) <= columns["secLabColumn"] || len(row) <= columns["studentNameColumn"]+1 {
-			return nil, fmt.Errorf("row does not have enough columns: %v", row)
+	seenStudentIds := map[string]int{}
+	for i, row := range rows {
+		rowNumber := firstRow + i
+		if isEmptyRow(row) {
+			continue
+		}
+		report.TotalRows++
+
+		studentId := cellValue(row, columns["studentIdColumn"])
+		firstName := cellValue(row, columns["studentNameColumn"])
+		lastName := cellValue(row, columns["studentNameColumn"]+1)
+
+		valid := true
+		if studentId == "" {
+			report.AddError(rowNumber, "รหัสนักศึกษา", "student ID is required")
+			valid = false
+		} else if previousRow, ok := seenStudentIds[studentId]; ok {
+			report.AddError(rowNumber, "รหัสนักศึกษา", fmt.Sprintf("student ID %s is already listed on row %d", studentId, previousRow))
+			valid = false
+		} else {
+			seenStudentIds[studentId] = rowNumber
+		}
+		if firstName == "" || lastName == "" {
+			report.AddError(rowNumber, "ชื่อ - นามสกุล", "first name and last name are required")
+			valid = false
+		}
+		if !valid {
+			continue
 		}
 
 		var email *string
-		if len(row) > columns["cmuAccountColumn"] && row[columns["cmuAccountColumn"]] != "" {
-			email = &row[columns["cmuAccountColumn"]]
+		if cmuAccount := cellValue(row, columns["cmuAccountColumn"]); cmuAccount != "" {
+			email = &cmuAccount
 		}
 		student := models.Student{
-			StudentID:    row[columns["studentIdColumn"]],
-			SecLab:       row[columns["secLabColumn"]],
-			FirstName:    row[columns["studentNameColumn"]],
-			LastName:     row[columns["studentNameColumn"]+1],
+			StudentID:    studentId,
+			SecLab:       cellValue(row, columns["secLabColumn"]),
+			FirstName:    firstName,
+			LastName:     lastName,
 			Email:        email,
 			Semester:     semester,
 			AcademicYear: academicYear,
@@ -328,124 +442,147 @@ func (s *uploadServiceImpl) parseStudents(ctx context.Context, rows [][]string,
 	return students, nil
 }
 
-func (s *uploadServiceImpl) parseStaffs(rows [][]string, columns map[string]int, programId int) ([]models.Staff, error) {
+func (s *uploadServiceImpl) parseStaffs(rows [][]string, firstRow int, columns map[string]int, programId int, report *dtos.ImportReport) []models.Staff {
 	var staffs []models.Staff
-	for _, row := range rows {
-		if len(row) <= columns["staffPrefixTHColumn"] || len(row) <= columns["staffPrefixENColumn"] || len(row) <= columns["staffNameTHColumn"] || len(row) <= columns["staffNameTHColumn"]+1 || len(row) <= columns["staffNameENColumn"] || len(row) <= columns["staffNameENColumn"]+1 || len(row) <= columns["staffEmailColumn"] || len(row) <= columns["staffIsActiveColumn"] {
+	seenEmails := map[string]int{}
+	for i, row := range rows {
+		rowNumber := firstRow + i
+		if isEmptyRow(row) {
 			continue
 		}
+		report.TotalRows++
+
+		email := cellValue(row, columns["staffEmailColumn"])
+		valid := true
+		if email == "" {
+			report.AddError(rowNumber, "Email (required)", "staff email is required")
+			valid = false
+		} else if previousRow, ok := seenEmails[strings.ToLower(email)]; ok {
+			report.AddError(rowNumber, "Email (required)", fmt.Sprintf("email %s is already listed on row %d", email, previousRow))
+			valid = false
+		} else {
+			seenEmails[strings.ToLower(email)] = rowNumber
+		}
 
-		if row[columns["staffEmailColumn"]] == "" {
-			return nil, fmt.Errorf("row does not have staff email: %v", row)
+		if cellValue(row, columns["staffNameTHColumn"]) == "" {
+			report.AddError(rowNumber, "ชื่อ-นามสกุล (TH)", "staff name is required")
+			valid = false
 		}
 
-		isActive, err := strconv.ParseBool(row[columns["staffIsActiveColumn"]])
+		isActiveValue := cellValue(row, columns["staffIsActiveColumn"])
+		isActive, err := strconv.ParseBool(isActiveValue)
 		if err != nil {
-			return nil, fmt.Errorf("invalid value for Is Resigned: %v", row[columns["staffIsActiveColumn"]])
+			report.AddError(rowNumber, "InActive", fmt.Sprintf("invalid boolean value %q", isActiveValue))
+			valid = false
+		}
+		if !valid {
+			continue
 		}
 
 		staff := models.Staff{
-			PrefixTH:    row[columns["staffPrefixTHColumn"]],
-			PrefixEN:    row[columns["staffPrefixENColumn"]],
-			FirstNameTH: row[columns["staffNameTHColumn"]],
-			LastNameTH:  row[columns["staffNameTHColumn"]+1],
-			FirstNameEN: row[columns["staffNameENColumn"]],
-			LastNameEN:  row[columns["staffNameENColumn"]+1],
-			Email:       row[columns["staffEmailColumn"]],
+			PrefixTH:    cellValue(row, columns["staffPrefixTHColumn"]),
+			PrefixEN:    cellValue(row, columns["staffPrefixENColumn"]),
+			FirstNameTH: cellValue(row, columns["staffNameTHColumn"]),
+			LastNameTH:  cellValue(row, columns["staffNameTHColumn"]+1),
+			FirstNameEN: cellValue(row, columns["staffNameENColumn"]),
+			LastNameEN:  cellValue(row, columns["staffNameENColumn"]+1),
+			Email:       email,
 			IsActive:    isActive,
 			ProgramID:   programId,
 		}
 		staffs = append(staffs, staff)
 	}
 
-	return staffs, nil
+	return staffs
 }
 
-func (s *uploadServiceImpl) parseProjects(ctx context.Context, rows [][]string, columns map[string]int, programId int) ([]models.ProjectRequest, error) {
+func (s *uploadServiceImpl) parseProjects(ctx context.Context, rows [][]string, firstRow int, columns map[string]int, programId int, report *dtos.ImportReport) ([]models.ProjectRequest, error) {
 	academicYear, semester, err := s.configService.GetCurrentAcademicYearAndSemester(ctx, programId)
 	if err != nil {
 		return nil, err
 	}
 
 	var projectRequests []models.ProjectRequest
+	seenTitles := map[string]int{}
 	for rowIdx, row := range rows {
 		if !s.isValidProjectRow(ctx, row, columns) {
 			continue
 		}
+		rowNumber := firstRow + rowIdx
+		report.TotalRows++
+		valid := true
+
+		titleTHValue := cellValue(row, columns["titleTHColumn"])
+		titleENValue := cellValue(row, columns["titleENColumn"])
+		if titleTHValue == "" && titleENValue == "" {
+			report.AddError(rowNumber, "Title (TH)", "project title is required")
+			valid = false
+		}
 
-		isProjectDuplicate, err := s.projectRepo.CheckDuplicateProjectByTitleAndSemester(ctx, row[columns["titleTHColumn"]], row[columns["titleENColumn"]], academicYear, semester)
+		for _, title := range []string{titleTHValue, titleENValue} {
+			if title == "" {
+				continue
+			}
+			if previousRow, ok := seenTitles[title]; ok {
+				report.AddError(rowNumber, "Title (TH)", fmt.Sprintf("project title %q is already listed on row %d", title, previousRow))
+				valid = false
+			} else {
+				seenTitles[title] = rowNumber
+			}
+		}
+
+		isProjectDuplicate, err := s.projectRepo.CheckDuplicateProjectByTitleAndSemester(ctx, titleTHValue, titleENValue, academicYear, semester)
 		if err != nil {
 			return nil, err
 		}
 		if isProjectDuplicate {
-			return nil, fmt.Errorf("project with title TH: %s and title EN: %s already exists", row[columns["titleTHColumn"]], row[columns["titleENColumn"]])
+			report.AddError(rowNumber, "Title (TH)", fmt.Sprintf("project with title TH: %s and title EN: %s already exists", titleTHValue, titleENValue))
+			valid = false
 		}
 
-		members, isSecLabSameOverAllMember, err := s.getProjectMembers(rows, rowIdx, columns, semester, academicYear, programId)
-		if err != nil {
-			return nil, err
-		}
+		members, isSecLabSameOverAllMember := s.getProjectMembers(rows, rowIdx, columns, semester, academicYear, programId)
 
-		members, err = s.studentService.UpsertStudents(ctx, members, programId)
+		projectStaffs, err := s.getProjectStaffs(ctx, rows, rowIdx, firstRow, columns, report)
 		if err != nil {
 			return nil, err
 		}
+		if projectStaffs == nil {
+			valid = false
+		}
 
-		projectStaffs, err := s.getProjectStaffs(ctx, rows, rowIdx, columns)
+		keywordArray, err := s.getProjectKeywords(ctx, cellValue(row, columns["keywordsColumn"]), programId)
 		if err != nil {
 			return nil, err
 		}
 
-		var keywordArray []models.Keyword
-		keywords := strings.Split(row[columns["keywordsColumn"]], ",")
-		for _, keyword := range keywords {
-			keyword = strings.TrimSpace(keyword)
-			if keyword == "" {
-				continue
-			}
+		isPublicValue := cellValue(row, columns["isPublicColumn"])
+		if isPublicValue == "TRUE" {
+			report.AddError(rowNumber, "Is Public", "imported projects start as proposals and cannot be public until they are completed")
+			valid = false
+		}
 
-			keywordModel, err := s.keywordRepo.FindByKeywordAndProgramId(ctx, keyword, programId)
-			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, err
-			}
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				keywordModel = &models.Keyword{
-					Keyword:   keyword,
-					ProgramID: programId,
-				}
-				if err := s.keywordRepo.Create(ctx, keywordModel); err != nil {
-					return nil, err
-				}
-			}
-			keywordArray = append(keywordArray, *keywordModel)
+		if !valid {
+			continue
 		}
 
-		titleTH := &row[columns["titleTHColumn"]]
-		titleEN := &row[columns["titleENColumn"]]
-		abstractText := &row[columns["abstractTextColumn"]]
-		sectionValue := row[columns["secLabColumn"]]
-		isPublicValue := row[columns["isPublicColumn"]]
+		titleTH := &titleTHValue
+		titleEN := &titleENValue
+		abstractText := cellValue(row, columns["abstractTextColumn"])
+		sectionValue := cellValue(row, columns["secLabColumn"])
 
 		var sectionID *string
 		if isSecLabSameOverAllMember {
 			sectionID = &sectionValue
 		}
-		var isPublic bool
-		if isPublicValue == "TRUE" {
-			isPublic = true
-		} else {
-			isPublic = false
-		}
 
 		project := models.ProjectRequest{
 			TitleTH:       titleTH,
 			TitleEN:       titleEN,
-			AbstractText:  abstractText,
+			AbstractText:  &abstractText,
 			AcademicYear:  academicYear,
 			Semester:      semester,
 			SectionID:     sectionID,
 			ProgramID:     programId,
-			IsPublic:      isPublic,
 			ProjectStaffs: projectStaffs,
 			Members:       members,
 			Keywords:      keywordArray,
@@ -456,6 +593,89 @@ func (s *uploadServiceImpl) parseProjects(ctx context.Context, rows [][]string,
 	return projectRequests, nil
 }
 
+// getProjectKeywords resolves comma separated keywords against the program's
+// existing keywords. Unknown keywords are returned without an ID and are only
+// created when the import is applied.
+func (s *uploadServiceImpl) getProjectKeywords(ctx context.Context, value string, programId int) ([]models.Keyword, error) {
+	var keywordArray []models.Keyword
+	seen := map[string]bool{}
+	for _, keyword := range strings.Split(value, ",") {
+		keyword = strings.TrimSpace(keyword)
+		if keyword == "" || seen[keyword] {
+			continue
+		}
+		seen[keyword] = true
+
+		keywordModel, err := s.keywordRepo.FindByKeywordAndProgramId(ctx, keyword, programId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			keywordModel = &models.Keyword{
+				Keyword:   keyword,
+				ProgramID: programId,
+			}
+		}
+		keywordArray = append(keywordArray, *keywordModel)
+	}
+	return keywordArray, nil
+}
+
+// applyProjects saves the members and new keywords of the projects together
+// with the projects in one transaction, so a run that fails leaves nothing
+// behind and can safely be retried. A new keyword used on several rows is
+// created once.
+func (s *uploadServiceImpl) applyProjects(ctx context.Context, projectRequests []models.ProjectRequest, programId int, opts ImportOptions) ([]*dtos.ProjectData, error) {
+	var changes []AuditChange
+	projects, err := s.projectService.CreateProjects(ctx, projectRequests, func(tx *gorm.DB) error {
+		changes = nil
+		newKeywords := map[string]models.Keyword{}
+		opts.progress(0, len(projectRequests))
+		for i := range projectRequests {
+			members, memberChanges, err := s.studentService.UpsertStudentsInTransaction(ctx, tx, projectRequests[i].Members, programId)
+			if err != nil {
+				return err
+			}
+			projectRequests[i].Members = members
+			changes = append(changes, memberChanges...)
+
+			for j, keyword := range projectRequests[i].Keywords {
+				if keyword.ID != 0 {
+					continue
+				}
+				if created, ok := newKeywords[keyword.Keyword]; ok {
+					projectRequests[i].Keywords[j] = created
+					continue
+				}
+
+				created, err := s.keywordRepo.FindOrCreateInTransaction(ctx, tx, &keyword)
+				if err != nil {
+					return err
+				}
+				if created {
+					changes = append(changes, AuditChange{
+						Action:     models.AuditActionCreate,
+						EntityType: models.AuditEntityKeyword,
+						EntityID:   keyword.ID,
+						ProgramID:  keyword.ProgramID,
+						After:      keyword,
+					})
+				}
+				newKeywords[keyword.Keyword] = keyword
+				projectRequests[i].Keywords[j] = keyword
+			}
+			opts.progress(i+1, len(projectRequests))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.auditService.Record(ctx, changes...)
+
+	return projects, nil
+}
+
 func (s *uploadServiceImpl) isValidProjectRow(ctx context.Context, row []string, columns map[string]int) bool {
 	return len(row) > columns["titleTHColumn"] &&
 		len(row) > columns["titleENColumn"] &&
@@ -467,26 +687,25 @@ func (s *uploadServiceImpl) isValidProjectRow(ctx context.Context, row []string,
 			row[columns["courseNoColumn"]] == "")
 }
 
-func (s *uploadServiceImpl) getProjectMembers(rows [][]string, rowIdx int, columns map[string]int, semester, academicYear, programId int) ([]models.Student, bool, error) {
+func (s *uploadServiceImpl) getProjectMembers(rows [][]string, rowIdx int, columns map[string]int, semester, academicYear, programId int) ([]models.Student, bool) {
 	var members []models.Student
 	memberIdx := 0
 	currentSeclab := ""
 	isSecLabSameOverAllMember := true
 	for rowIdx+memberIdx < len(rows) &&
-		columns["studentIdColumn"] < len(rows[rowIdx+memberIdx]) &&
-		len(rows[rowIdx+memberIdx]) > columns["studentIdColumn"] &&
-		rows[rowIdx+memberIdx][columns["studentIdColumn"]] != "" {
+		cellValue(rows[rowIdx+memberIdx], columns["studentIdColumn"]) != "" {
+		memberRow := rows[rowIdx+memberIdx]
 
-		if currentSeclab != "" && currentSeclab != rows[rowIdx+memberIdx][columns["secLabColumn"]] {
+		if currentSeclab != "" && currentSeclab != cellValue(memberRow, columns["secLabColumn"]) {
 			isSecLabSameOverAllMember = false
 		}
-		currentSeclab = rows[rowIdx+memberIdx][columns["secLabColumn"]]
+		currentSeclab = cellValue(memberRow, columns["secLabColumn"])
 
 		student := models.Student{
-			StudentID:    rows[rowIdx+memberIdx][columns["studentIdColumn"]],
-			SecLab:       rows[rowIdx+memberIdx][columns["secLabColumn"]],
-			FirstName:    rows[rowIdx+memberIdx][columns["studentNameColumn"]],
-			LastName:     rows[rowIdx+memberIdx][columns["studentNameColumn"]+1],
+			StudentID:    cellValue(memberRow, columns["studentIdColumn"]),
+			SecLab:       cellValue(memberRow, columns["secLabColumn"]),
+			FirstName:    cellValue(memberRow, columns["studentNameColumn"]),
+			LastName:     cellValue(memberRow, columns["studentNameColumn"]+1),
 			Semester:     semester,
 			AcademicYear: academicYear,
 			ProgramID:    programId,
@@ -494,29 +713,40 @@ func (s *uploadServiceImpl) getProjectMembers(rows [][]string, rowIdx int, colum
 		members = append(members, student)
 		memberIdx++
 	}
-	return members, isSecLabSameOverAllMember, nil
+	return members, isSecLabSameOverAllMember
 }
 
-func (s *uploadServiceImpl) getProjectStaffs(ctx context.Context, rows [][]string, rowIdx int, columns map[string]int) ([]models.ProjectStaff, error) {
-	var projectStaffs []models.ProjectStaff
+// getProjectStaffs reads the committee listed on the project's rows. Problems
+// are added to the report and a nil slice is returned so the project is
+// skipped.
+func (s *uploadServiceImpl) getProjectStaffs(ctx context.Context, rows [][]string, rowIdx int, firstRow int, columns map[string]int, report *dtos.ImportReport) ([]models.ProjectStaff, error) {
+	projectStaffs := []models.ProjectStaff{}
+	valid := true
 	staffIdx := 0
 	for rowIdx+staffIdx < len(rows) &&
-		columns["staffColumn"] < len(rows[rowIdx+staffIdx]) &&
-		len(rows[rowIdx+staffIdx]) > columns["staffColumn"] &&
-		rows[rowIdx+staffIdx][columns["staffColumn"]] != "" {
+		cellValue(rows[rowIdx+staffIdx], columns["staffColumn"]) != "" {
+		staffRow := rows[rowIdx+staffIdx]
+		rowNumber := firstRow + rowIdx + staffIdx
+		staffIdx++
 
-		staff, err := s.staffService.GetStaffByName(ctx, rows[rowIdx+staffIdx][columns["staffColumn"]])
+		staff, err := s.staffService.GetStaffByName(ctx, cellValue(staffRow, columns["staffColumn"]))
 		if err != nil {
-			return nil, err
+			report.AddError(rowNumber, "Committee(s)", err.Error())
+			valid = false
+			continue
 		}
 
-		if len(rows[rowIdx+staffIdx]) <= columns["staffRoleColumn"] {
-			return nil, errors.New("staff role column index out of range")
+		staffRoleTH := cellValue(staffRow, columns["staffRoleColumn"])
+		if staffRoleTH == "" {
+			report.AddError(rowNumber, "Staff Role", "staff role is required")
+			valid = false
+			continue
 		}
-		staffRoleTH := rows[rowIdx+staffIdx][columns["staffRoleColumn"]]
 		staffRole, err := s.projectRoleService.GetProjectRoleByRoleName(ctx, staffRoleTH)
 		if err != nil {
-			return nil, err
+			report.AddError(rowNumber, "Staff Role", fmt.Sprintf("%s: %s", err.Error(), staffRoleTH))
+			valid = false
+			continue
 		}
 
 		projectStaff := models.ProjectStaff{
@@ -524,30 +754,33 @@ func (s *uploadServiceImpl) getProjectStaffs(ctx context.Context, rows [][]strin
 			ProjectRoleID: staffRole.ID,
 		}
 		projectStaffs = append(projectStaffs, projectStaff)
-		staffIdx++
+	}
+
+	if !valid {
+		return nil, nil
 	}
 	return projectStaffs, nil
 }
 
 func (s *uploadServiceImpl) UploadObject(ctx context.Context, bucketName string, objectName string, file io.Reader, fileSize int64, contentType string) (string, error) {
-	_, err := s.client.PutObject(ctx, bucketName, objectName, file, fileSize, minio.PutObjectOptions{ContentType: contentType})
+	_, err := s.storage.PutObject(ctx, bucketName, objectName, file, fileSize, storage.PutOptions{ContentType: contentType})
 	if err != nil {
 		return "", fmt.Errorf("failed to upload object: %w", err)
 	}
 
-	url, err := s.client.PresignedGetObject(ctx, bucketName, objectName, time.Hour*24*7, nil)
+	url, err := s.storage.PresignGetObject(ctx, bucketName, objectName, time.Hour*24*7, nil)
 	if err != nil {
-		_ = s.RemoveObject(ctx, bucketName, objectName, minio.RemoveObjectOptions{})
+		_ = s.RemoveObject(ctx, bucketName, objectName)
 		return "", fmt.Errorf("failed to generate presigned URL: %w", err)
 	}
 
 	return url.String(), nil
 }
 
-func (s *uploadServiceImpl) RemoveObject(ctx context.Context, bucketName string, objectName string, opt minio.RemoveObjectOptions) error {
-	return s.client.RemoveObject(ctx, bucketName, objectName, opt)
+func (s *uploadServiceImpl) RemoveObject(ctx context.Context, bucketName string, objectName string) error {
+	return s.storage.RemoveObject(ctx, bucketName, objectName)
 }
 
 func (s *uploadServiceImpl) GetObjectURL(ctx context.Context, bucketName string, objectName string) (*url.URL, error) {
-	return s.client.PresignedGetObject(ctx, bucketName, objectName, time.Hour*2, nil)
+	return s.storage.PresignGetObject(ctx, bucketName, objectName, time.Hour*2, nil)
 }
@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "tracing:span"
+
+// querySpan is the span of a statement together with the context it
+// replaced, which is restored once the statement has run.
+type querySpan struct {
+	span   trace.Span
+	parent context.Context
+}
+
+// GormPlugin starts a client span for every statement GORM runs, as a child
+// of the span in the context the repository passed to WithContext. Register
+// it with db.Use.
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register("tracing:before_create", startQuerySpan("create")),
+		callback.Create().After("gorm:create").Register("tracing:after_create", endQuerySpan),
+		callback.Query().Before("gorm:query").Register("tracing:before_query", startQuerySpan("select")),
+		callback.Query().After("gorm:query").Register("tracing:after_query", endQuerySpan),
+		callback.Update().Before("gorm:update").Register("tracing:before_update", startQuerySpan("update")),
+		callback.Update().After("gorm:update").Register("tracing:after_update", endQuerySpan),
+		callback.Delete().Before("gorm:delete").Register("tracing:before_delete", startQuerySpan("delete")),
+		callback.Delete().After("gorm:delete").Register("tracing:after_delete", endQuerySpan),
+		callback.Row().Before("gorm:row").Register("tracing:before_row", startQuerySpan("row")),
+		callback.Row().After("gorm:row").Register("tracing:after_row", endQuerySpan),
+		callback.Raw().Before("gorm:raw").Register("tracing:before_raw", startQuerySpan("raw")),
+		callback.Raw().After("gorm:raw").Register("tracing:after_raw", endQuerySpan),
+	)
+}
+
+func startQuerySpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil || !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+			// Queries outside any request or job would each start a
+			// trace of their own, which is only noise.
+			return
+		}
+
+		name := "db." + operation
+		if db.Statement.Table != "" {
+			name += " " + db.Statement.Table
+		}
+		ctx, span := Tracer().Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemPostgreSQL,
+				semconv.DBOperationName(operation),
+				semconv.DBCollectionName(db.Statement.Table),
+			),
+		)
+		db.InstanceSet(gormSpanKey, querySpan{span: span, parent: db.Statement.Context})
+		db.Statement.Context = ctx
+	}
+}
+
+func endQuerySpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	query, ok := value.(querySpan)
+	if !ok {
+		return
+	}
+	db.Statement.Context = query.parent
+	span := query.span
+
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		semconv.DBCollectionName(db.Statement.Table),
+	)
+	// A lookup that finds nothing is an answer, not a failed query.
+	err := db.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	End(span, err)
+}
@@ -0,0 +1,105 @@
+// Package tracing sets up OpenTelemetry and holds the helpers shared by the
+// code that starts spans for HTTP requests, queries, object storage calls,
+// PDF extraction and RabbitMQ publishes.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/project-box/configs"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName names the tracer of the service.
+const InstrumentationName = "github.com/project-box"
+
+const shutdownTimeout = 5 * time.Second
+
+// NewTracerProvider installs the exporter selected by TRACING_EXPORTER as the
+// global tracer provider and the W3C trace context as the global propagator.
+// The returned cleanup function flushes the spans still buffered.
+func NewTracerProvider(logger *slog.Logger) (trace.TracerProvider, func(), error) {
+	config := configs.GetTracingConfig()
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch config.Exporter {
+	case configs.TracingExporterNone:
+		return otel.GetTracerProvider(), func() {}, nil
+	case configs.TracingExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case configs.TracingExporterOTLP:
+		exporter, err = otlptracehttp.New(context.Background())
+	default:
+		return nil, nil, fmt.Errorf("unknown tracing exporter %q", config.Exporter)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create %s trace exporter: %w", config.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(config.ServiceName)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	logger.Info("Tracing enabled", "exporter", config.Exporter, "sample_ratio", config.SampleRatio)
+
+	return provider, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			logger.Error("Failed to flush spans", "error", err)
+		}
+	}, nil
+}
+
+// Tracer returns the tracer of the service from the global provider. Spans
+// started before NewTracerProvider runs are dropped.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceParent returns the W3C traceparent of the span in ctx, or "" if
+// there is none, so work handed to a background job can continue the trace.
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// ContextWithTraceParent returns ctx with the remote span described by
+// traceParent as its parent. An empty or invalid traceParent leaves ctx as
+// it is.
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{"traceparent": traceParent})
+}
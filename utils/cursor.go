@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Cursor marks the last row of a keyset-paginated page: the value of the
+// sort column and the row ID used as a tie-breaker.
+type Cursor struct {
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+func EncodeCursor(value string, id int) string {
+	data, _ := json.Marshal(Cursor{Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(cursor string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var decoded Cursor
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return &decoded, nil
+}
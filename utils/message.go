@@ -27,7 +27,9 @@ func SanitizeProjectMessage(project *models.Project) *dtos.ProjectData {
 		AcademicYear: project.AcademicYear,
 		SectionID:    project.SectionID,
 		Semester:     project.Semester,
+		Status:       string(project.Status),
 		IsPublic:     project.IsPublic,
+		Version:      project.Version,
 		ProgramID:    project.ProgramID,
 		Program: dtos.Program{
 			ID:            project.Program.ID,
@@ -95,51 +97,57 @@ func SanitizeProjectMessage(project *models.Project) *dtos.ProjectData {
 		})
 	}
 	for _, resource := range project.ProjectResources {
-		resourceType := dtos.ResourceType{
-			ID:       resource.ResourceTypeID,
-			TypeName: resource.ResourceType.TypeName,
-		}
+		projectMessage.ProjectResources = append(projectMessage.ProjectResources, SanitizeProjectResource(resource))
+	}
 
-		projectResource := dtos.ProjectResource{
-			ID:              resource.ID,
-			ResourceName:    resource.ResourceName,
-			Path:            resource.Path,
-			Title:           resource.Title,
-			ResourceTypeID:  resource.ResourceTypeID,
-			ResourceType:    resourceType,
-			FileExtensionID: resource.FileExtensionID,
-			FileExtension: dtos.FileExtension{
-				ID:            resource.FileExtension.ID,
-				ExtensionName: resource.FileExtension.ExtensionName,
-				MimeType:      resource.FileExtension.MimeType,
-			},
-			ProjectID: resource.ProjectID,
-			CreatedAt: formatTime(resource.CreatedAt),
-		}
+	return &projectMessage
+}
 
-		if resource.URL != nil {
-			projectResource.URL = resource.URL
-		}
+func SanitizeProjectResource(resource models.ProjectResource) dtos.ProjectResource {
+	resourceType := dtos.ResourceType{
+		ID:       resource.ResourceTypeID,
+		TypeName: resource.ResourceType.TypeName,
+	}
 
-		if resource.PDF != nil {
-			pages := []dtos.PDFPage{}
-			for _, page := range resource.PDF.Pages {
-				pages = append(pages, dtos.PDFPage{
-					ID:         page.ID,
-					PDFID:      page.PDFID,
-					PageNumber: page.PageNumber,
-					Content:    page.Content,
-				})
-			}
-			projectResource.PDF = &dtos.PDF{
-				ID:                resource.PDF.ID,
-				ProjectResourceID: resource.PDF.ProjectResourceID,
-				Pages:             pages,
-			}
-		}
+	projectResource := dtos.ProjectResource{
+		ID:              resource.ID,
+		ResourceName:    resource.ResourceName,
+		Path:            resource.Path,
+		Title:           resource.Title,
+		ResourceTypeID:  resource.ResourceTypeID,
+		ResourceType:    resourceType,
+		FileExtensionID: resource.FileExtensionID,
+		FileExtension: dtos.FileExtension{
+			ID:            resource.FileExtension.ID,
+			ExtensionName: resource.FileExtension.ExtensionName,
+			MimeType:      resource.FileExtension.MimeType,
+		},
+		ProjectID: resource.ProjectID,
+		Version:   resource.Version,
+		IsCurrent: resource.IsCurrent,
+		CreatedAt: formatTime(resource.CreatedAt),
+	}
 
-		projectMessage.ProjectResources = append(projectMessage.ProjectResources, projectResource)
+	if resource.URL != nil {
+		projectResource.URL = resource.URL
 	}
 
-	return &projectMessage
+	if resource.PDF != nil {
+		pages := []dtos.PDFPage{}
+		for _, page := range resource.PDF.Pages {
+			pages = append(pages, dtos.PDFPage{
+				ID:         page.ID,
+				PDFID:      page.PDFID,
+				PageNumber: page.PageNumber,
+				Content:    page.Content,
+			})
+		}
+		projectResource.PDF = &dtos.PDF{
+			ID:                resource.PDF.ID,
+			ProjectResourceID: resource.PDF.ProjectResourceID,
+			Pages:             pages,
+		}
+	}
+
+	return projectResource
 }
@@ -1,12 +1,18 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"mime/multipart"
 	"os"
+	"time"
 
 	"github.com/heussd/pdftotext-go"
+	"github.com/project-box/metrics"
 	"github.com/project-box/models"
+	"github.com/project-box/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func readPDFFile(pdfFile string) ([]byte, error) {
@@ -17,8 +23,13 @@ func readPDFFile(pdfFile string) ([]byte, error) {
 	return content, nil
 }
 
-func extractTextFromPDF(pdfContent []byte) ([]pdftotext.PdfPage, error) {
+func extractTextFromPDF(ctx context.Context, pdfContent []byte) ([]pdftotext.PdfPage, error) {
+	_, span := tracing.Tracer().Start(ctx, "pdftotext.extract", trace.WithAttributes(attribute.Int("pdf.size", len(pdfContent))))
+	start := time.Now()
 	pages, err := pdftotext.Extract(pdfContent)
+	metrics.PDFExtractionDuration.WithLabelValues(metrics.Result(err)).Observe(metrics.Since(start))
+	span.SetAttributes(attribute.Int("pdf.pages", len(pages)))
+	tracing.End(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("error extracting text from PDF: %v", err)
 	}
@@ -39,14 +50,14 @@ func createPdfObject(pages []pdftotext.PdfPage) *models.PDF {
 	return &pdf
 }
 
-func ReadPdf(file *multipart.FileHeader) (*models.PDF, error) {
+func ReadPdf(ctx context.Context, file *multipart.FileHeader) (*models.PDF, error) {
 	srcForPDF, err := file.Open()
 	if err != nil {
 		return nil, err
 	}
 	defer srcForPDF.Close()
 
-	pdf, err := readPdfContent(srcForPDF)
+	pdf, err := readPdfContent(ctx, srcForPDF)
 	if err != nil {
 		return nil, fmt.Errorf("error reading PDF: %w", err)
 	}
@@ -54,13 +65,23 @@ func ReadPdf(file *multipart.FileHeader) (*models.PDF, error) {
 	return pdf, nil
 }
 
-func readPdfContent(file multipart.File) (*models.PDF, error) {
+// ReadPdfBytes extracts the text of a PDF that is already in memory, such as
+// one read back from object storage.
+func ReadPdfBytes(ctx context.Context, content []byte) (*models.PDF, error) {
+	pdfPages, err := extractTextFromPDF(ctx, content)
+	if err != nil {
+		return nil, fmt.Errorf("error reading PDF: %w", err)
+	}
+	return createPdfObject(pdfPages), nil
+}
+
+func readPdfContent(ctx context.Context, file multipart.File) (*models.PDF, error) {
 	contentBytes, err := ConvertMultipartFileToBytes(file)
 	if err != nil {
 		return nil, err
 	}
 
-	pdfPages, err := extractTextFromPDF(contentBytes)
+	pdfPages, err := extractTextFromPDF(ctx, contentBytes)
 	if err != nil {
 		return nil, err
 	}
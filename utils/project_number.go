@@ -3,10 +3,81 @@ package utils
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 )
 
-func FormatProjectID(nextProjectNumber int) string {
-	return fmt.Sprintf("P%03d", nextProjectNumber)
+// DefaultProjectNumberTemplate is used when a program has no
+// "project number template" config, e.g. P0001-2/66. It has no {abbr}, so
+// its sequence is shared by all programs.
+const DefaultProjectNumberTemplate = "P{seq:4}-{sem}/{yy}"
+
+// ProjectNumberParts are the values a project number template can refer to:
+// {abbr} program abbreviation, {seq} or {seq:N} sequence zero-padded to N
+// digits, {sem} semester, {yy} and {yyyy} academic year.
+type ProjectNumberParts struct {
+	Abbreviation string
+	Sequence     int
+	Semester     int
+	AcademicYear int
+}
+
+var projectNumberToken = regexp.MustCompile(`\{([a-z]+)(?::(\d+))?\}`)
+
+func ValidateProjectNumberTemplate(template string) error {
+	hasSequence := false
+	for _, match := range projectNumberToken.FindAllStringSubmatch(template, -1) {
+		switch match[1] {
+		case "seq":
+			hasSequence = true
+		case "abbr", "sem", "yy", "yyyy":
+			if match[2] != "" {
+				return fmt.Errorf("project number template: {%s} does not take a width", match[1])
+			}
+		default:
+			return fmt.Errorf("project number template: unknown placeholder {%s}", match[1])
+		}
+	}
+	if !hasSequence {
+		return fmt.Errorf("project number template must contain {seq}")
+	}
+	return nil
+}
+
+func FormatProjectNumber(template string, parts ProjectNumberParts) (string, error) {
+	if err := ValidateProjectNumberTemplate(template); err != nil {
+		return "", err
+	}
+
+	year := strconv.Itoa(parts.AcademicYear)
+	shortYear := fmt.Sprintf("%02d", parts.AcademicYear%100)
+	return projectNumberToken.ReplaceAllStringFunc(template, func(token string) string {
+		match := projectNumberToken.FindStringSubmatch(token)
+		switch match[1] {
+		case "abbr":
+			return parts.Abbreviation
+		case "seq":
+			width, _ := strconv.Atoi(match[2])
+			return fmt.Sprintf("%0*d", width, parts.Sequence)
+		case "sem":
+			return strconv.Itoa(parts.Semester)
+		case "yy":
+			return shortYear
+		default:
+			return year
+		}
+	}), nil
+}
+
+// ProjectNumberTemplateUsesAbbreviation reports whether numbers made from
+// template carry the program abbreviation and so cannot collide across
+// programs.
+func ProjectNumberTemplateUsesAbbreviation(template string) bool {
+	for _, match := range projectNumberToken.FindAllStringSubmatch(template, -1) {
+		if match[1] == "abbr" {
+			return true
+		}
+	}
+	return false
 }
 
 func IsValidProjectNumberFormat(projectNo string) error {
@@ -6,12 +6,15 @@ package main
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
-	minio "github.com/project-box/db/minio"
 	database "github.com/project-box/db/postgres"
 	rabbitMQ "github.com/project-box/db/rabbitmq"
+	storage "github.com/project-box/db/storage"
 	"github.com/project-box/handlers"
+	"github.com/project-box/logging"
+	"github.com/project-box/middlewares"
 	"github.com/project-box/repositories"
 	"github.com/project-box/services"
+	"github.com/project-box/tracing"
 )
 
 func InitializeApp() (*gin.Engine, func(), error) {
@@ -24,11 +27,35 @@ func InitializeApp() (*gin.Engine, func(), error) {
 	return gin.New(), func() {}, nil
 }
 
+func InitializeReindexService() (services.ReindexService, func(), error) {
+	wire.Build(
+		logging.NewLogger,
+		database.NewPostgresDatabase,
+		storage.NewObjectStorage,
+		rabbitMQ.NewRabbitMQPublisher,
+		repositories.NewProjectRepository,
+		repositories.NewProjectStaffRepository,
+		repositories.NewProjectNumberCounterRepository,
+		repositories.NewFileExtensionRepository,
+		repositories.NewResourceRepository,
+		repositories.NewResourceTypeRepository,
+		repositories.NewUploadRepository,
+		repositories.NewConfigRepository,
+		repositories.NewOutboxRepository,
+		services.NewReindexService,
+	)
+
+	return nil, func() {}, nil
+}
+
 var AppSet = wire.NewSet(
 	NewApp,
+	logging.NewLogger,
+	tracing.NewTracerProvider,
 	database.NewPostgresDatabase,
-	minio.NewMinIOConnection,
-	rabbitMQ.NewRabbitMQConnection,
+	storage.NewObjectStorage,
+	rabbitMQ.NewRabbitMQPublisher,
+	middlewares.NewAuthMiddleware,
 )
 
 var HandlerSet = wire.NewSet(
@@ -43,6 +70,14 @@ var HandlerSet = wire.NewSet(
 	handlers.NewStudentHandler,
 	handlers.NewUploadHandler,
 	handlers.NewKeywordHandler,
+	handlers.NewReindexHandler,
+	handlers.NewRubricHandler,
+	handlers.NewEvaluationHandler,
+	handlers.NewDefenseHandler,
+	handlers.NewProjectUploadHandler,
+	handlers.NewProjectTrashHandler,
+	handlers.NewAuditHandler,
+	handlers.NewHealthHandler,
 )
 
 var ServiceSet = wire.NewSet(
@@ -57,6 +92,16 @@ var ServiceSet = wire.NewSet(
 	services.NewStudentService,
 	services.NewUploadService,
 	services.NewKeywordService,
+	services.NewImportJobService,
+	services.NewOutboxRelayService,
+	services.NewReindexService,
+	services.NewRubricService,
+	services.NewEvaluationService,
+	services.NewDefenseService,
+	services.NewProjectUploadService,
+	services.NewProjectTrashService,
+	services.NewAuditService,
+	services.NewHealthService,
 )
 
 var RepositorySet = wire.NewSet(
@@ -75,6 +120,13 @@ var RepositorySet = wire.NewSet(
 	repositories.NewStudentRepository,
 	repositories.NewUploadRepository,
 	repositories.NewKeywordRepository,
+	repositories.NewImportJobRepository,
+	repositories.NewOutboxRepository,
+	repositories.NewRubricRepository,
+	repositories.NewEvaluationRepository,
+	repositories.NewDefenseRepository,
+	repositories.NewPendingUploadRepository,
+	repositories.NewAuditLogRepository,
 )
 
 var RedisSet = wire.NewSet()
@@ -9,78 +9,175 @@ package main
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
-	db3 "github.com/project-box/db/minio"
 	db2 "github.com/project-box/db/postgres"
 	"github.com/project-box/db/rabbitmq"
+	db3 "github.com/project-box/db/storage"
 	"github.com/project-box/handlers"
+	"github.com/project-box/logging"
+	"github.com/project-box/middlewares"
 	"github.com/project-box/repositories"
 	"github.com/project-box/services"
+	"github.com/project-box/tracing"
 )
 
 // Injectors from wire.go:
 
 func InitializeApp() (*gin.Engine, func(), error) {
-	channel := db.NewRabbitMQConnection()
-	gormDB := db2.NewPostgresDatabase()
+	logger := logging.NewLogger()
+	tracerProvider, cleanup, err := tracing.NewTracerProvider(logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	publisher, cleanup2 := db.NewRabbitMQPublisher(logger)
+	gormDB, cleanup3 := db2.NewPostgresDatabase(logger)
 	fileExtensionRepository := repositories.NewFileExtensionRepository(gormDB)
 	projectStaffRepository := repositories.NewProjectStaffRepository(gormDB)
 	projectNumberCounterRepository := repositories.NewProjectNumberCounterRepository(gormDB)
 	resourceTypeRepository := repositories.NewResourceTypeRepository(gormDB)
-	client, err := db3.NewMinIOConnection()
+	objectStorage, cleanup4, err := db3.NewObjectStorage(logger)
 	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
 		return nil, nil, err
 	}
-	uploadRepository := repositories.NewUploadRepository(client)
-	resourceRepository := repositories.NewResourceRepository(gormDB, resourceTypeRepository, fileExtensionRepository, uploadRepository)
-	projectRepository := repositories.NewProjectRepository(gormDB, fileExtensionRepository, projectStaffRepository, projectNumberCounterRepository, resourceRepository, resourceTypeRepository, uploadRepository)
+	uploadRepository := repositories.NewUploadRepository(objectStorage)
+	configRepository := repositories.NewConfigRepository(gormDB)
+	outboxRepository := repositories.NewOutboxRepository(gormDB)
+	resourceRepository := repositories.NewResourceRepository(gormDB, resourceTypeRepository, fileExtensionRepository, uploadRepository, outboxRepository)
+	projectRepository := repositories.NewProjectRepository(gormDB, fileExtensionRepository, projectStaffRepository, projectNumberCounterRepository, resourceRepository, resourceTypeRepository, uploadRepository, configRepository, outboxRepository)
+	outboxRelayService, cleanup5 := services.NewOutboxRelayService(publisher, outboxRepository, projectRepository, logger)
 	staffRepository := repositories.NewStaffRepository(gormDB)
 	programRepository := repositories.NewProgramRepository(gormDB)
-	projectService := services.NewProjectService(channel, projectRepository, staffRepository, programRepository, resourceRepository)
+	auditLogRepository := repositories.NewAuditLogRepository(gormDB)
+	auditService := services.NewAuditService(auditLogRepository, logger)
+	projectService := services.NewProjectService(outboxRelayService, auditService, projectRepository, projectStaffRepository, staffRepository, programRepository)
 	projectHandler := handlers.NewProjectHandler(projectService)
-	resourceService := services.NewResourceService(resourceRepository)
-	resourceHandler := handlers.NewResourceHandler(client, resourceService, projectService)
-	staffService := services.NewStaffService(staffRepository)
+	resourceService := services.NewResourceService(resourceRepository, projectRepository, objectStorage, outboxRelayService, auditService, logger)
+	resourceHandler := handlers.NewResourceHandler(objectStorage, resourceService, projectService)
+	staffService := services.NewStaffService(staffRepository, auditService)
 	staffHandler := handlers.NewStaffHandler(staffService)
-	configRepository := repositories.NewConfigRepository(gormDB)
-	configService := services.NewConfigService(configRepository)
+	configService, cleanup6 := services.NewConfigService(configRepository, auditService, logger)
 	configHandler := handlers.NewConfigHandler(configService)
 	keywordRepository := repositories.NewKeywordRepository(gormDB)
-	keywordService := services.NewKeywordService(keywordRepository)
+	keywordService := services.NewKeywordService(keywordRepository, auditService)
 	keywordHandler := handlers.NewKeywordHandler(keywordService)
 	projectConfigRepository := repositories.NewProjectConfigRepository(gormDB)
-	projectConfigService := services.NewProjectConfigService(projectConfigRepository)
+	projectConfigService := services.NewProjectConfigService(projectConfigRepository, auditService)
 	projectConfigHandler := handlers.NewProjectConfigHandler(projectConfigService)
 	projectResourceConfigRepository := repositories.NewProjectResourceConfigRepository(gormDB)
 	programService := services.NewProgramService(programRepository)
 	projectRoleRepository := repositories.NewProjectRoleRepository(gormDB)
 	projectRoleService := services.NewProjectRoleService(projectRoleRepository)
 	studentRepository := repositories.NewStudentRepository(gormDB, configRepository)
-	studentService := services.NewStudentService(configService, studentRepository, gormDB)
-	uploadService := services.NewUploadService(client, keywordRepository, programRepository, projectRepository, staffService, projectRoleService, projectService, configService, studentService)
-	projectResourceConfigService := services.NewProjectResourceConfigService(projectResourceConfigRepository, programService, uploadService)
+	studentService := services.NewStudentService(configService, studentRepository, auditService, gormDB, logger)
+	uploadService := services.NewUploadService(objectStorage, keywordRepository, programRepository, projectRepository, staffService, projectRoleService, projectService, configService, studentService, auditService)
+	projectResourceConfigService := services.NewProjectResourceConfigService(projectResourceConfigRepository, programService, uploadService, auditService)
 	projectResourceConfigHandler := handlers.NewProjectResourceConfigHandler(projectResourceConfigService)
 	projectRoleHandler := handlers.NewProjectRoleHandler(projectRoleService)
 	programHandler := handlers.NewProgramHandler(programService)
 	studentHandler := handlers.NewStudentHandler(studentService)
-	uploadHandler := handlers.NewUploadHandler(uploadService)
-	engine, err := NewApp(projectHandler, resourceHandler, staffHandler, configHandler, keywordHandler, projectConfigHandler, projectResourceConfigHandler, projectRoleHandler, programHandler, studentHandler, uploadHandler)
+	importJobRepository := repositories.NewImportJobRepository(gormDB)
+	importJobService, cleanup7 := services.NewImportJobService(importJobRepository, uploadService, auditService, logger)
+	uploadHandler := handlers.NewUploadHandler(importJobService)
+	reindexService, cleanup8 := services.NewReindexService(publisher, projectRepository)
+	reindexHandler := handlers.NewReindexHandler(reindexService)
+	rubricRepository := repositories.NewRubricRepository(gormDB)
+	rubricService := services.NewRubricService(rubricRepository, projectRoleRepository)
+	rubricHandler := handlers.NewRubricHandler(rubricService)
+	evaluationRepository := repositories.NewEvaluationRepository(gormDB)
+	evaluationService := services.NewEvaluationService(evaluationRepository, rubricRepository, projectRepository, projectStaffRepository)
+	evaluationHandler := handlers.NewEvaluationHandler(evaluationService, rubricService)
+	defenseRepository := repositories.NewDefenseRepository(gormDB)
+	defenseService := services.NewDefenseService(defenseRepository, projectRepository, projectStaffRepository)
+	defenseHandler := handlers.NewDefenseHandler(defenseService, projectService)
+	pendingUploadRepository := repositories.NewPendingUploadRepository(gormDB)
+	projectUploadService := services.NewProjectUploadService(pendingUploadRepository, projectRepository, fileExtensionRepository, objectStorage, outboxRelayService, auditService, logger)
+	projectUploadHandler := handlers.NewProjectUploadHandler(projectUploadService)
+	projectTrashService, cleanup9 := services.NewProjectTrashService(projectRepository, pendingUploadRepository, objectStorage, outboxRelayService, auditService, logger)
+	projectTrashHandler := handlers.NewProjectTrashHandler(projectTrashService)
+	auditHandler := handlers.NewAuditHandler(auditService, logger)
+	healthService := services.NewHealthService(gormDB, objectStorage, publisher)
+	healthHandler := handlers.NewHealthHandler(healthService)
+	authMiddleware, cleanup10, err := middlewares.NewAuthMiddleware(logger)
 	if err != nil {
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	engine, err := NewApp(projectHandler, resourceHandler, staffHandler, configHandler, keywordHandler, projectConfigHandler, projectResourceConfigHandler, projectRoleHandler, programHandler, studentHandler, uploadHandler, reindexHandler, rubricHandler, evaluationHandler, defenseHandler, projectUploadHandler, projectTrashHandler, auditHandler, healthHandler, objectStorage, authMiddleware, logger, tracerProvider)
+	if err != nil {
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
 		return nil, nil, err
 	}
 	return engine, func() {
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func InitializeReindexService() (services.ReindexService, func(), error) {
+	logger := logging.NewLogger()
+	publisher, cleanup := db.NewRabbitMQPublisher(logger)
+	gormDB, cleanup2 := db2.NewPostgresDatabase(logger)
+	fileExtensionRepository := repositories.NewFileExtensionRepository(gormDB)
+	projectStaffRepository := repositories.NewProjectStaffRepository(gormDB)
+	projectNumberCounterRepository := repositories.NewProjectNumberCounterRepository(gormDB)
+	resourceTypeRepository := repositories.NewResourceTypeRepository(gormDB)
+	objectStorage, cleanup3, err := db3.NewObjectStorage(logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	uploadRepository := repositories.NewUploadRepository(objectStorage)
+	configRepository := repositories.NewConfigRepository(gormDB)
+	outboxRepository := repositories.NewOutboxRepository(gormDB)
+	resourceRepository := repositories.NewResourceRepository(gormDB, resourceTypeRepository, fileExtensionRepository, uploadRepository, outboxRepository)
+	projectRepository := repositories.NewProjectRepository(gormDB, fileExtensionRepository, projectStaffRepository, projectNumberCounterRepository, resourceRepository, resourceTypeRepository, uploadRepository, configRepository, outboxRepository)
+	reindexService, cleanup4 := services.NewReindexService(publisher, projectRepository)
+	return reindexService, func() {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
 	}, nil
 }
 
 // wire.go:
 
 var AppSet = wire.NewSet(
-	NewApp, db2.NewPostgresDatabase, db3.NewMinIOConnection, db.NewRabbitMQConnection,
+	NewApp, logging.NewLogger, tracing.NewTracerProvider, db2.NewPostgresDatabase, db3.NewObjectStorage, db.NewRabbitMQPublisher, middlewares.NewAuthMiddleware,
 )
 
-var HandlerSet = wire.NewSet(handlers.NewProjectHandler, handlers.NewResourceHandler, handlers.NewStaffHandler, handlers.NewConfigHandler, handlers.NewProjectConfigHandler, handlers.NewProjectResourceConfigHandler, handlers.NewProjectRoleHandler, handlers.NewProgramHandler, handlers.NewStudentHandler, handlers.NewUploadHandler, handlers.NewKeywordHandler)
+var HandlerSet = wire.NewSet(handlers.NewProjectHandler, handlers.NewResourceHandler, handlers.NewStaffHandler, handlers.NewConfigHandler, handlers.NewProjectConfigHandler, handlers.NewProjectResourceConfigHandler, handlers.NewProjectRoleHandler, handlers.NewProgramHandler, handlers.NewStudentHandler, handlers.NewUploadHandler, handlers.NewKeywordHandler, handlers.NewReindexHandler, handlers.NewRubricHandler, handlers.NewEvaluationHandler, handlers.NewDefenseHandler, handlers.NewProjectUploadHandler, handlers.NewProjectTrashHandler, handlers.NewAuditHandler, handlers.NewHealthHandler)
 
-var ServiceSet = wire.NewSet(services.NewProjectService, services.NewResourceService, services.NewStaffService, services.NewConfigService, services.NewProjectConfigService, services.NewProjectResourceConfigService, services.NewProjectRoleService, services.NewProgramService, services.NewStudentService, services.NewUploadService, services.NewKeywordService)
+var ServiceSet = wire.NewSet(services.NewProjectService, services.NewResourceService, services.NewStaffService, services.NewConfigService, services.NewProjectConfigService, services.NewProjectResourceConfigService, services.NewProjectRoleService, services.NewProgramService, services.NewStudentService, services.NewUploadService, services.NewKeywordService, services.NewImportJobService, services.NewOutboxRelayService, services.NewReindexService, services.NewRubricService, services.NewEvaluationService, services.NewDefenseService, services.NewProjectUploadService, services.NewProjectTrashService, services.NewAuditService, services.NewHealthService)
 
-var RepositorySet = wire.NewSet(repositories.NewProjectRepository, repositories.NewProjectStaffRepository, repositories.NewProjectNumberCounterRepository, repositories.NewStaffRepository, repositories.NewFileExtensionRepository, repositories.NewProgramRepository, repositories.NewResourceRepository, repositories.NewResourceTypeRepository, repositories.NewConfigRepository, repositories.NewProjectConfigRepository, repositories.NewProjectResourceConfigRepository, repositories.NewProjectRoleRepository, repositories.NewStudentRepository, repositories.NewUploadRepository, repositories.NewKeywordRepository)
+var RepositorySet = wire.NewSet(repositories.NewProjectRepository, repositories.NewProjectStaffRepository, repositories.NewProjectNumberCounterRepository, repositories.NewStaffRepository, repositories.NewFileExtensionRepository, repositories.NewProgramRepository, repositories.NewResourceRepository, repositories.NewResourceTypeRepository, repositories.NewConfigRepository, repositories.NewProjectConfigRepository, repositories.NewProjectResourceConfigRepository, repositories.NewProjectRoleRepository, repositories.NewStudentRepository, repositories.NewUploadRepository, repositories.NewKeywordRepository, repositories.NewImportJobRepository, repositories.NewOutboxRepository, repositories.NewRubricRepository, repositories.NewEvaluationRepository, repositories.NewDefenseRepository, repositories.NewPendingUploadRepository, repositories.NewAuditLogRepository)
 
 var RedisSet = wire.NewSet()